@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mattn/go-isatty"
+)
+
+const (
+	colorReset  = "\033[0m"
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorCyan   = "\033[36m"
+)
+
+// console prints the human facing progress messages.  When quiet only the
+// warnings and errors are printed.
+type console struct {
+	quiet  bool
+	color  bool
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// isTerminal returns if the file descriptor is a terminal.
+var isTerminal = isatty.IsTerminal
+
+// newConsole creates the console for the color mode: auto, always or never.
+// Auto uses color when stdout is a terminal and NO_COLOR is not set.
+func newConsole(quiet bool, mode string) console {
+	color := mode == "always"
+	if mode == "auto" {
+		_, noColor := os.LookupEnv("NO_COLOR")
+		color = !noColor && isTerminal(os.Stdout.Fd())
+	}
+
+	return console{
+		quiet:  quiet,
+		color:  color,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+	}
+}
+
+func (c console) print(w io.Writer, color, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	if c.color {
+		msg = color + msg + colorReset
+	}
+	fmt.Fprintln(w, msg)
+}
+
+// Info prints a progress message.
+func (c console) Info(format string, args ...any) {
+	if !c.quiet {
+		c.print(c.stdout, colorCyan, format, args...)
+	}
+}
+
+// Success prints a message about something completed.
+func (c console) Success(format string, args ...any) {
+	if !c.quiet {
+		c.print(c.stdout, colorGreen, format, args...)
+	}
+}
+
+// Warn prints a warning to stderr.  Warnings are printed even when quiet.
+func (c console) Warn(format string, args ...any) {
+	c.print(c.stderr, colorYellow, "warning: "+format, args...)
+}
+
+// Error prints an error to stderr.  Errors are printed even when quiet.
+func (c console) Error(format string, args ...any) {
+	c.print(c.stderr, colorRed, "error: "+format, args...)
+}
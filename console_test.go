@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsoleQuiet(t *testing.T) {
+	tests := []struct {
+		description  string
+		quiet        bool
+		expectStdout string
+		expectStderr string
+	}{
+		{
+			description:  "everything",
+			expectStdout: "info 1\nsuccess 2\n",
+			expectStderr: "warning: warn 3\nerror: error 4\n",
+		}, {
+			description:  "quiet",
+			quiet:        true,
+			expectStderr: "warning: warn 3\nerror: error 4\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var stdout, stderr strings.Builder
+			c := newConsole(tc.quiet, "never")
+			c.stdout = &stdout
+			c.stderr = &stderr
+
+			c.Info("info %d", 1)
+			c.Success("success %d", 2)
+			c.Warn("warn %d", 3)
+			c.Error("error %d", 4)
+
+			assert.Equal(tc.expectStdout, stdout.String())
+			assert.Equal(tc.expectStderr, stderr.String())
+		})
+	}
+}
+
+func TestConsoleColor(t *testing.T) {
+	tests := []struct {
+		description string
+		mode        string
+		terminal    bool
+		noColor     bool
+		expect      bool
+	}{
+		{
+			description: "auto on a terminal",
+			mode:        "auto",
+			terminal:    true,
+			expect:      true,
+		}, {
+			description: "auto not a terminal",
+			mode:        "auto",
+		}, {
+			description: "auto with NO_COLOR",
+			mode:        "auto",
+			terminal:    true,
+			noColor:     true,
+		}, {
+			description: "always",
+			mode:        "always",
+			noColor:     true,
+			expect:      true,
+		}, {
+			description: "never",
+			mode:        "never",
+			terminal:    true,
+		},
+	}
+
+	defer func(orig func(uintptr) bool) { isTerminal = orig }(isTerminal)
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			isTerminal = func(uintptr) bool { return tc.terminal }
+			if tc.noColor {
+				t.Setenv("NO_COLOR", "")
+			} else if v, ok := os.LookupEnv("NO_COLOR"); ok {
+				os.Unsetenv("NO_COLOR")
+				defer os.Setenv("NO_COLOR", v)
+			}
+
+			c := newConsole(false, tc.mode)
+			assert.Equal(tc.expect, c.color)
+
+			var stdout strings.Builder
+			c.stdout = &stdout
+			c.Info("hi")
+			if tc.expect {
+				assert.Equal(colorCyan+"hi"+colorReset+"\n", stdout.String())
+			} else {
+				assert.Equal("hi\n", stdout.String())
+			}
+		})
+	}
+}
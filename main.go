@@ -4,57 +4,211 @@
 package main
 
 import (
-	"context"
 	_ "embed"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
 	"os"
+	"os/signal"
+	"path"
 	"path/filepath"
 	"sort"
 	"strings"
+	"syscall"
+	"text/tabwriter"
 	"time"
 
 	"github.com/alecthomas/kong"
 	"github.com/goschtalt/goschtalt"
 	_ "github.com/goschtalt/yaml-decoder"
 	_ "github.com/goschtalt/yaml-encoder"
-	gql "github.com/hasura/go-graphql-client"
 	"github.com/mitchellh/mapstructure"
-	"golang.org/x/oauth2"
+	"github.com/schmidtw/status-reportr/pkg/reportr"
 	"gopkg.in/dealancer/validate.v2"
+	"gopkg.in/yaml.v3"
 )
 
-var errConfig = errors.New("invalid configuration value")
+var (
+	errConfig          = errors.New("invalid configuration value")
+	errNothingToReport = errors.New("there were no items to report")
+)
+
+// The exit codes, so automation can tell the failures apart.
+const (
+	exitFailure = 1 // Any other failure.
+	exitConfig  = 2 // The configuration is not valid.
+	exitAuth    = 3 // Github refused the token.
+	exitAPI     = 4 // Github returned an error.
+	exitNothing = 5 // There were no items to report.
+)
+
+// version is set at build time using -ldflags "-X main.version=...".
+var version = "dev"
+
+// out is the console used for all the human facing messages.
+var out = newConsole(false, "auto")
+
+// telemetry counts the work done, served as prometheus metrics by the daemon.
+var telemetry reportr.Telemetry
 
 //go:embed default.yml
 var defaultConfig string
 
 type CLI struct {
-	Debug     bool     `optional:"" help:"Run in debug mode."`
-	Show      bool     `optional:"" short:"s" help:"Show the configuration and exit."`
-	Files     []string `optional:"" short:"f" name:"file" help:"Specific configuration files or directories."`
-	DryRun    bool     `optional:"" help:"When set, items are not archived."`
-	CacheFile string   `optional:"" help:"Use a local cache file for testing"`
+	Debug           bool     `optional:"" help:"Run in debug mode."`
+	Show            bool     `optional:"" short:"s" help:"Show the configuration and exit."`
+	Files           []string `optional:"" short:"f" name:"file" help:"Specific configuration files, directories or https urls."`
+	ConfigAuth      string   `optional:"" name:"config-auth" env:"SR_CONFIG_AUTH" help:"The Authorization header value used to fetch https configuration files."`
+	RemoteCommands  bool     `optional:"" name:"allow-remote-commands" help:"Allow the https configuration files to set hooks, exec deliveries and cmd: secrets, which run shell commands."`
+	DryRun          bool     `optional:"" help:"When set, items are not archived and the reports are not delivered.  The plan of what the real run would do is shown instead."`
+	PlanFormat      string   `optional:"" name:"plan-format" enum:"text,json,none" default:"text" help:"How the --dry-run plan is shown: text, json or none."`
+	AllProjects     bool     `optional:"" help:"Generate reports for every open project owned by the org, or for the configured projects."`
+	Interactive     bool     `optional:"" short:"i" help:"Review the items in a terminal browser before the reports are rendered and the items archived."`
+	CacheFile       string   `optional:"" help:"Use a local cache file for testing.  The format is based on the extension: .json, .yml, .yaml, optionally with .gz"`
+	IncludeArchived bool     `optional:"" name:"include-archived" help:"Keep the items already archived on the board, for regenerating historical reports."`
+	Quiet           bool     `optional:"" short:"q" help:"Only print warnings and errors, for running from cron."`
+	Color           string   `optional:"" enum:"auto,always,never" default:"auto" help:"When to colorize the output: auto, always or never."`
+	Gist            bool     `optional:"" help:"Also publish the latest report as a secret gist."`
+	Owner           string   `optional:"" help:"Override the owner (org) of the project."`
+	Project         int      `optional:"" help:"Override the project number."`
+	Team            string   `optional:"" help:"Override the team name."`
+	OutputDir       string   `optional:"" name:"output-dir" help:"Override the output directory."`
+	Record          string   `optional:"" xor:"recording" type:"path" help:"Record the raw github responses in the directory."`
+	Replay          string   `optional:"" xor:"recording" type:"path" help:"Replay the github responses recorded in the directory instead of calling github.  Implies --dry-run."`
+	TraceFile       string   `optional:"" name:"trace-file" type:"path" help:"Write every github graphql request and response to the file, with the token scrubbed."`
+
+	Report   struct{}    `cmd:"" default:"1" help:"Generate the status reports and archive the items (default)."`
+	List     ListCmd     `cmd:"" help:"List the matching items without generating reports."`
+	Snapshot SnapshotCmd `cmd:"" help:"Summarize the whole board by Status column without archiving anything."`
+	Demo     DemoCmd     `cmd:"" help:"Render the reports of fabricated items to try out the configuration without a token."`
+	Bot      struct{}    `cmd:"" help:"Answer the issues of the bot repo asking for a report with the current report, without archiving."`
+	Notes    NotesCmd    `cmd:"" help:"Write release notes of the pull requests merged in the most recent window without archiving anything."`
+	Daemon   DaemonCmd   `cmd:"" help:"Keep running, generating the reports every interval and serving prometheus metrics of the runs."`
+	Plan     PlanCmd     `cmd:"" help:"Write a signed plan file of the reports and the items to archive, without changing anything."`
+	Apply    ApplyCmd    `cmd:"" help:"Write the reports and archive the items of a plan file, exactly as planned."`
+}
+
+// PlanCmd is the first phase of the review then execute workflow.
+type PlanCmd struct {
+	Output string `optional:"" short:"o" default:"status-reportr.plan.json" type:"path" help:"The plan file to write."`
+}
+
+// ApplyCmd is the second phase of the review then execute workflow.
+type ApplyCmd struct {
+	File string `arg:"" type:"existingfile" help:"The plan file written by the plan command."`
+}
+
+// DaemonCmd is the long running mode generating the reports on an interval.
+type DaemonCmd struct {
+	Listen string        `optional:"" default:":9090" help:"The address to serve the prometheus metrics on at /metrics."`
+	Every  time.Duration `optional:"" default:"24h" help:"The time between the runs.  The first run starts right away."`
+}
+
+// NotesCmd is the release notes of the most recent window.
+type NotesCmd struct {
+	Output  string `optional:"" short:"o" help:"Write the release notes to the file instead of stdout."`
+	Publish bool   `optional:"" help:"Also create a draft github release with the notes of each repository."`
+}
+
+// DemoCmd is the reports of fabricated items.
+type DemoCmd struct {
+	Output string `optional:"" short:"o" default:"demo" type:"path" help:"The directory to write the demo items and reports to."`
+}
+
+// demoConfig fills in the required values that are not needed for the demo,
+// so the configuration can be tried out before it is complete.
+const demoConfig = `
+owner: demo-org
+project_number: 1
+team: Demo Team
+token: demo
+`
+
+// SnapshotCmd is the summary of the whole board.
+type SnapshotCmd struct {
+	Output string `optional:"" short:"o" help:"Write the snapshot to the file instead of stdout."`
+}
+
+// ListCmd is the ad-hoc query of the project items.
+type ListCmd struct {
+	Label  []string `optional:"" help:"Only list items with a label matching one of the globs."`
+	Status string   `optional:"" help:"Only list items with a Status field matching the glob."`
+	Author []string `optional:"" help:"Only list items with an author matching one of the globs."`
+	Since  string   `optional:"" help:"Only list items closed or merged on or after the date (YYYY-MM-DD)."`
+}
+
+// override replaces the config values with the ones set on the command line.
+func (cli CLI) override(cfg *reportr.Config) {
+	if len(cli.Owner) > 0 {
+		cfg.Owner = cli.Owner
+	}
+	if cli.Project > 0 {
+		cfg.Project = cli.Project
+	}
+	if len(cli.Team) > 0 {
+		cfg.Team = cli.Team
+	}
+	if len(cli.OutputDir) > 0 {
+		cfg.OutputDirectory = cli.OutputDir
+	}
 }
 
 func main() {
 	err := wrapped()
+	if errors.Is(err, errNothingToReport) {
+		out.Info("There were no items to report.")
+	} else if err != nil {
+		out.Error("%v", reportr.ExplainError(err))
+	}
 	if err != nil {
-		fmt.Printf("err: %v\n", err)
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
 
+// exitCode returns the exit code for the class of the error.
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, errNothingToReport):
+		return exitNothing
+	case errors.Is(err, errConfig):
+		return exitConfig
+	case reportr.IsAuthError(err):
+		return exitAuth
+	case reportr.IsAPIError(err):
+		return exitAPI
+	}
+	return exitFailure
+}
+
 func wrapped() error {
 	var cli CLI
-	_ = kong.Parse(&cli,
+	ctx := kong.Parse(&cli,
 		kong.Name("status-reportr"),
-		kong.Description("A status report generator and Github project manager."),
+		kong.Description("A status report generator and Github project manager.\n\n"+
+			"The exit code is 0 on success, 2 if the configuration is not valid, 3 if github "+
+			"refused the token, 4 for other github errors, 5 if there were no items to report "+
+			"and 1 for any other failure."),
 		kong.UsageOnError(),
 	)
 
+	out = newConsole(cli.Quiet, cli.Color)
+	reportr.Logger = out.Info
+	reportr.OnGraphQLErrors = telemetry.AddGraphQLErrors
+
+	files, remote, err := remoteConfigs(cli.Files, cli.ConfigAuth, cli.RemoteCommands)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errConfig, err)
+	}
+
+	demo := goschtalt.Options()
+	if ctx.Command() == "demo" {
+		demo = goschtalt.AddBuffer("demo.yml", []byte(demoConfig), goschtalt.AsDefault())
+	}
+
 	gs, err := goschtalt.New(
 		goschtalt.DefaultMarshalOptions(
 			goschtalt.IncludeOrigins(),
@@ -70,195 +224,1208 @@ func wrapped() error {
 			),
 		),
 		goschtalt.AddBuffer("default.yml", []byte(defaultConfig), goschtalt.AsDefault()),
-		goschtalt.AddJumbled(os.DirFS("/"), os.DirFS("."), cli.Files...),
+		demo,
+		goschtalt.AddJumbled(os.DirFS("/"), os.DirFS("."), files...),
+		goschtalt.Options(remote...),
 		goschtalt.ExpandEnv(),
 		goschtalt.AutoCompile(),
 	)
 	if err != nil {
-		return err
+		return fmt.Errorf("%w: %v", errConfig, err)
 	}
 
 	if cli.Show {
 		fmt.Fprintln(os.Stdout, gs.Explain())
 
-		out, err := gs.Marshal()
+		buf, err := gs.Marshal()
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
+			out.Error("%v", err)
 		} else {
-			fmt.Fprintln(os.Stdout, "---\n"+string(out))
+			fmt.Fprintln(os.Stdout, "---\n"+string(buf))
 		}
 		return nil
 	}
 
-	cfg, err := goschtalt.Unmarshal[Config](gs, "")
+	cfg, err := goschtalt.Unmarshal[reportr.Config](gs, "")
 	if err != nil {
-		return err
+		return fmt.Errorf("%w: %v", errConfig, err)
+	}
+
+	// The environment and command line overrides are applied after the files,
+	// so the values need to be validated again.
+	if err = reportr.ApplyEnv(&cfg, reportr.EnvPrefix, os.LookupEnv); err != nil {
+		return fmt.Errorf("%w: %v", errConfig, err)
+	}
+	cli.override(&cfg)
+	if err = reportr.ResolveSecrets(&cfg); err != nil {
+		return fmt.Errorf("%w: %v", errConfig, err)
+	}
+	sections, err := goschtalt.Unmarshal[[]map[string]any](gs, "sections")
+	if err != nil {
+		return fmt.Errorf("%w: %v", errConfig, err)
+	}
+	cfg.ApplySectionDefaults(sections)
+	if err = cfg.ApplyPresets(); err != nil {
+		return fmt.Errorf("%w: %v", errConfig, err)
+	}
+	if err = validate.Validate(&cfg); err != nil {
+		return fmt.Errorf("%w: %v", errConfig, err)
+	}
+
+	for _, c := range cfg.RenderOrderCollisions() {
+		out.Warn("sections share the render_order %s, they are rendered in the order they are declared", c)
 	}
 
 	cfg.Debug = cli.Debug
+	reportr.LegacyGlobs = cfg.Matching.LegacyGlobs
+	reportr.CaseInsensitive = cfg.Matching.CaseInsensitive
+	if cfg.Tuning.HTTPTimeout > 0 {
+		reportr.HTTPClient = &http.Client{Timeout: time.Duration(cfg.Tuning.HTTPTimeout) * time.Second}
+	}
+	cfg.Record = cli.Record
+	cfg.Replay = cli.Replay
+	cfg.Trace = cli.TraceFile
+	if len(cli.TraceFile) > 0 {
+		// Each run starts a fresh trace.
+		if err = os.WriteFile(cli.TraceFile, nil, 0600); err != nil {
+			return err
+		}
+	}
+	if len(cli.Replay) > 0 {
+		// Nothing is archived on github when replaying.
+		cli.DryRun = true
+	}
+	cfg.Version = version
+	cfg.Generated = time.Now()
 
-	var items Items
-	if len(cli.CacheFile) > 0 && fileExist(cli.CacheFile) {
-		buf, err := os.ReadFile(cli.CacheFile)
-		if err == nil {
-			err := json.Unmarshal(buf, &items)
-			if err != nil {
-				return err
-			}
-			fmt.Println("Read from disk.")
+	if err = cfg.CheckTemplates(); err != nil {
+		return fmt.Errorf("%w: %v", errConfig, err)
+	}
+	if err = cfg.ReportWindow.CheckHolidays(); err != nil {
+		return fmt.Errorf("%w: %v", errConfig, err)
+	}
+
+	formats := make([]string, 0, len(cfg.Formats))
+	for _, format := range cfg.Formats {
+		if _, ok := reportr.GetRenderer(format); !ok {
+			return fmt.Errorf("%w: unknown format '%s', must be one of: %s",
+				errConfig, format, strings.Join(reportr.RendererNames(), ", "))
 		}
-	} else {
-		fmt.Println("Fetching from GH")
-		client := login(cfg)
-		client = client.WithDebug(true)
+		if !contains(formats, format) {
+			formats = append(formats, format)
+		}
+	}
+	cfg.Formats = formats
+
+	if cli.Gist {
+		cfg.Deliver = append(cfg.Deliver, reportr.Delivery{Type: "gist", Formats: []string{"markdown"}})
+	}
 
-		id, err := fetchProjectInfo(cfg.Owner, cfg.Project, client)
+	deliverers := make([]reportr.Deliverer, 0, len(cfg.Deliver))
+	for _, d := range cfg.Deliver {
+		deliverer, err := reportr.NewDeliverer(d)
 		if err != nil {
+			return fmt.Errorf("%w: %v", errConfig, err)
+		}
+		deliverers = append(deliverers, deliverer)
+	}
+
+	switch ctx.Command() {
+	case "list":
+		return list(cfg, cli)
+	case "snapshot":
+		return snapshot(cfg, cli)
+	case "demo":
+		return demoReports(cfg, cli)
+	case "notes":
+		return notes(cfg, cli)
+	}
+
+	// Only one run at a time may write the reports and archive the items.
+	unlock, err := reportr.Lock(cfg.OutputDirectory)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if uerr := unlock(); uerr != nil {
+			out.Warn("unable to remove the lock: %v", uerr)
+		}
+	}()
+
+	switch ctx.Command() {
+	case "bot":
+		return bot(cfg, cli)
+	case "daemon":
+		return daemon(cfg, cli, deliverers)
+	case "plan":
+		return writePlan(cfg, cli)
+	case "apply <file>":
+		return apply(cfg, cli)
+	}
+
+	return generate(cfg, cli, deliverers)
+}
+
+// generate generates the reports of the configured project, or of every
+// project when sweeping.
+func generate(cfg reportr.Config, cli CLI, deliverers []reportr.Deliverer) error {
+	if cli.AllProjects || len(cfg.Projects) > 0 {
+		return sweep(cfg, cli, deliverers)
+	}
+
+	records, _, err := run(cfg, cli, deliverers)
+	if err != nil {
+		return err
+	}
+	if reported(records) == 0 {
+		return errNothingToReport
+	}
+	return nil
+}
+
+// daemon generates the reports every interval until it is interrupted, serving
+// the prometheus metrics of the runs.  A failed run is logged and retried at
+// the next interval.  The lock is held the whole time.
+func daemon(cfg reportr.Config, cli CLI, deliverers []reportr.Deliverer) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", &telemetry)
+	server := &http.Server{
+		Addr:              cli.Daemon.Listen,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	failed := make(chan error, 1)
+	go func() {
+		failed <- server.ListenAndServe()
+	}()
+	defer server.Close()
+	out.Info("Serving the metrics on %s/metrics.", cli.Daemon.Listen)
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(stop)
+
+	next := time.NewTimer(0)
+	defer next.Stop()
+	for {
+		select {
+		case err := <-failed:
 			return err
+		case <-stop:
+			out.Info("Stopping.")
+			return nil
+		case <-next.C:
 		}
 
-		items, err = fetchIssues(id, client,
-			cfg.Tuning.IssueCount,
-			cfg.Tuning.LabelCount,
-			cfg.Tuning.FieldValueCount)
+		cfg.Generated = time.Now()
+		err := generate(cfg, cli, deliverers)
+		if errors.Is(err, errNothingToReport) {
+			out.Info("There were no items to report.")
+			err = nil
+		}
 		if err != nil {
-			return err
+			out.Error("%v", reportr.ExplainError(err))
 		}
-		if len(cli.CacheFile) > 0 {
-			buf, err := json.MarshalIndent(items, "", "    ")
+		telemetry.ObserveRun(time.Now(), err)
+
+		next.Reset(cli.Daemon.Every)
+		out.Info("The next run is at %s.", time.Now().Add(cli.Daemon.Every).Format(time.RFC1123))
+	}
+}
+
+// reported returns the number of items shown in the reports.
+func reported(records []reportr.ReportRecord) int {
+	var n int
+	for _, r := range records {
+		n += r.Shown()
+	}
+	return n
+}
+
+// run generates the reports for the configured project and archives the
+// reported items.  The records of the reports generated are returned, and the
+// plan of what the real run would do when it is a dry run.
+func run(cfg reportr.Config, cli CLI, deliverers []reportr.Deliverer) ([]reportr.ReportRecord, *reportr.Plan, error) {
+	var err error
+
+	summary := reportr.RunSummary{
+		OutputDirectory: cfg.OutputDirectory,
+		DryRun:          cli.DryRun,
+	}
+
+	if err = reportr.RunHook("pre_fetch", cfg.Hooks.PreFetch, summary); err != nil {
+		return nil, nil, err
+	}
+
+	var skip reportr.SkipFunc
+	if cfg.OnItemError == "skip" {
+		skip = func(id string, err error) {
+			out.Warn("skipping item %s: %v", id, err)
+			summary.Skipped = append(summary.Skipped, id)
+		}
+	}
+
+	// The status updates are not in the cache file.
+	cached := len(cli.CacheFile) > 0 && fileExist(cli.CacheFile)
+
+	archiving := !cli.DryRun
+	if archiving {
+		if archiving, err = canArchive(cfg); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	items, err := fetch(cfg, cli, skip)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items = cfg.Cancelled.Mark(items)
+	done, open := cfg.Abandoned.Split(items)
+	weeks := reportr.SplitByWindow(done, time.Now(), cfg.ReportWindow)
+	if len(weeks) > 0 {
+		weeks[0].Open = open
+		if cfg.Capacity.Enabled {
+			weeks[0].Planned = cfg.Capacity.Planned(items, time.Now())
+		}
+	}
+
+	if cfg.ProjectDetails.Enabled() && !cached {
+		cfg.Details, err = reportr.FetchProjectDetails(cfg.Owner, cfg.Project, reportr.Login(cfg).WithDebug(true))
+		if err != nil {
+			out.Warn("unable to fetch the project details: %v", err)
+		}
+	}
+
+	if cfg.StatusUpdates.Enabled && !cached && len(weeks) > 0 {
+		// Enough updates are fetched for each week to have its own.
+		count := cfg.StatusUpdates.Count * len(weeks)
+		if count > 100 {
+			count = 100
+		}
+		updates, err := reportr.FetchStatusUpdates(cfg.Owner, cfg.Project, reportr.Login(cfg).WithDebug(true), count)
+		if err != nil {
+			out.Warn("unable to fetch the project status updates: %v", err)
+		}
+		for i := range weeks {
+			weeks[i].Updates = reportr.StatusUpdatesAsOf(updates, weeks[i].End, cfg.StatusUpdates.Count)
+		}
+	}
+
+	if cli.Interactive {
+		if weeks, err = review(cfg, weeks); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	historyFile := filepath.Join(cfg.OutputDirectory, reportr.HistoryFilename)
+	history, err := reportr.LoadHistory(historyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if cfg.Capacity.Enabled && cfg.Capacity.Burndown.Enabled && len(weeks) > 0 {
+		if b, ok := history.RecordBurndown(cfg.Capacity.Field, weeks[0].Planned, time.Now()); ok {
+			weeks[0].Burndown = &b
+		}
+	}
+
+	if cfg.NewContributors.Enabled {
+		history.RecordContributors(items)
+	}
+
+	if cfg.Reopened.Enabled && len(weeks) > 0 {
+		weeks[0].Open = history.MarkReopened(weeks[0].Open)
+	}
+
+	var records []reportr.ReportRecord
+
+	// Only the windows that are rendered are archived, the items of a skipped
+	// window stay on the board instead of being archived without a report.
+	var rendered []reportr.WeeklyItems
+	var deliveries []reportr.PlanDelivery
+	for _, week := range weeks {
+		prev, found := history.Find(week.Start, week.End)
+		if found && prev.Archived {
+			if cfg.AlreadyArchived == "skip" {
+				out.Info("Skipping %s, it was already archived.", reportr.ReportBasename(cfg, week))
+				continue
+			}
+
+			week.Items, err = reportr.MergeArchived(cfg, reportr.Login(cfg).WithDebug(true), week.Items, prev.ItemIDs, skip)
 			if err != nil {
-				return err
+				return nil, nil, err
 			}
-			err = os.WriteFile(cli.CacheFile, buf, 0644)
+		}
+
+		if cfg.NewItems.Enabled && found {
+			week.Since = prev.Generated
+			week.Items = week.Items.MarkNew(prev.ItemIDs)
+		}
+
+		if cfg.NewContributors.Enabled {
+			week.Items = history.MarkNewContributors(week.Items, week.Start, week.End)
+		}
+
+		if cfg.Compare.Enabled {
+			if prev, ok := history.Previous(week.Start); ok {
+				week.Previous = &prev
+			}
+		}
+
+		// The reports show the redacted items that are not hidden, the
+		// originals are archived.
+		shown := cfg.Redact.Week(cfg.Hide.Week(week))
+
+		var filename string
+		for i, format := range cfg.Formats {
+			r, _ := reportr.GetRenderer(format)
+			data, ext, err := r.Render(cfg, shown)
 			if err != nil {
-				return err
+				return nil, nil, err
+			}
+
+			name := reportr.ReportBasename(cfg, week) + ext
+			if cfg.Rolling.Enabled && ext == ".md" {
+				key := strings.TrimSuffix(name, ext)
+				name = cfg.Rolling.Filename
+				existing, err := os.ReadFile(filepath.Join(cfg.OutputDirectory, name))
+				if err != nil && !errors.Is(err, fs.ErrNotExist) {
+					return nil, nil, err
+				}
+				data = []byte(reportr.MergeRolling(string(existing), key, week.Start, string(data)))
+			}
+
+			err = os.WriteFile(filepath.Join(cfg.OutputDirectory, name), data, 0644)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			out.Success("Wrote %s", filepath.Join(cfg.OutputDirectory, name))
+
+			if i == 0 {
+				filename = name
+			}
+			summary.Reports = append(summary.Reports, filepath.Join(cfg.OutputDirectory, name))
+
+			report := reportr.Report{
+				Week:   shown,
+				Format: format,
+				Path:   filepath.Join(cfg.OutputDirectory, name),
+				Data:   data,
+			}
+			for j, d := range deliverers {
+				if !cfg.Deliver[j].Wants(format) {
+					continue
+				}
+				// A dry run only lists the deliveries in the plan.
+				if cli.DryRun {
+					deliveries = append(deliveries, reportr.PlanDelivery{
+						Type: cfg.Deliver[j].Type,
+						Path: report.Path,
+					})
+					continue
+				}
+				if err = d.Deliver(cfg, report); err != nil {
+					return nil, nil, err
+				}
 			}
-			fmt.Println("Cached to disk.")
 		}
+		record := reportr.NewReportRecord(filename, week, time.Now())
+		record.Done, record.Sections, record.Labels = reportr.Tally(cfg, shown)
+		record.Hidden = len(week.Items) - len(shown.Items)
+		history.Record(record)
+		record.Items = shown.Items
+		records = append(records, record)
+		summary.Items += len(shown.Items)
+		rendered = append(rendered, week)
 	}
 
-	weeks := splitByWeeks(items.GetDone(), time.Now())
+	if err = history.Save(historyFile); err != nil {
+		return nil, nil, err
+	}
 
-	_ = os.Mkdir(cfg.OutputDirectory, 0755)
+	if cfg.Index.Enabled {
+		err = os.WriteFile(filepath.Join(cfg.OutputDirectory, cfg.Index.Filename),
+			[]byte(reportr.RenderIndex(cfg, history)), 0644)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 
-	for _, week := range weeks {
-		data := render(cfg, week)
-		filename := fmt.Sprintf("%s-%s.md",
-			week.Start.Format("2006.01.02"),
-			week.End.AddDate(0, 0, -1).Format("2006.01.02"))
+	if len(summary.Skipped) > 0 {
+		out.Warn("skipped %d items: %s", len(summary.Skipped), strings.Join(summary.Skipped, ", "))
+	}
+
+	if err = reportr.RunHook("post_render", cfg.Hooks.PostRender, summary); err != nil {
+		return nil, nil, err
+	}
+	telemetry.AddItems(reported(records))
+
+	// The items excluded by label may also be kept on the board.
+	archive := cfg.Hide.Archivable(rendered)
+
+	var plan *reportr.Plan
+	if cli.DryRun {
+		files := append([]string{historyFile}, summary.Reports...)
+		if cfg.Index.Enabled {
+			files = append(files, filepath.Join(cfg.OutputDirectory, cfg.Index.Filename))
+		}
+		p := reportr.NewPlan(cfg, files, archive)
+		p.Deliveries = deliveries
+		plan = &p
+		if err = showPlan(cli.PlanFormat, p); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if archiving {
+		// The windows are archived even if some of their items are not, the
+		// items left are archived by the next run.
+		var aerr error
+		summary.Archived, aerr = archiveItems(cfg, reportr.ArchiveIDs(archive))
+		for _, week := range archive {
+			history.MarkArchived(week.Start, week.End)
+		}
+		telemetry.AddArchived(summary.Archived)
+		if err = history.Save(historyFile); err != nil {
+			return nil, nil, err
+		}
+		if aerr != nil {
+			return nil, nil, aerr
+		}
+		out.Success("Archived %d items.", summary.Archived)
+
+		if err = reportr.RunHook("post_archive", cfg.Hooks.PostArchive, summary); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return records, plan, nil
+}
+
+// archiveItems archives the items from the project along with the items a
+// previous run failed to archive, returning the number archived.  The items
+// that still fail are listed, saved for the next run and returned as an error.
+func archiveItems(cfg reportr.Config, ids []string) (int, error) {
+	client := reportr.Login(cfg)
+	client = client.WithDebug(true)
+
+	id, err := reportr.FetchProjectInfo(cfg.Owner, cfg.Project, client)
+	if err != nil {
+		return 0, err
+	}
+
+	pendingFile := filepath.Join(cfg.OutputDirectory, reportr.PendingFilename)
+	pending, err := reportr.LoadPending(pendingFile)
+	if err != nil {
+		return 0, err
+	}
+	if pending.ProjectID == id && len(pending.Items) > 0 {
+		out.Info("Resuming the archiving of %d items.", len(pending.Items))
+		for _, item := range ids {
+			if !contains(pending.Items, item) {
+				pending.Items = append(pending.Items, item)
+			}
+		}
+		ids = pending.Items
+	}
+
+	failed := reportr.Archive(id, client, ids, cfg.Tuning.ArchiveBatch, cfg.Tuning.ArchiveRetries)
+
+	pending = reportr.PendingArchive{ProjectID: id}
+	for _, f := range failed {
+		out.Warn("unable to archive item %s: %v", f.ID, f.Err)
+		pending.Items = append(pending.Items, f.ID)
+	}
+	if err = pending.Save(pendingFile); err != nil {
+		return len(ids) - len(failed), err
+	}
+	if len(failed) > 0 {
+		return len(ids) - len(failed), fmt.Errorf("%d of %d items were not archived, they are retried by the next run: %s: %w",
+			len(failed), len(ids), strings.Join(pending.Items, ", "), failed[0].Err)
+	}
+
+	return len(ids), nil
+}
+
+// canArchive checks the token can update the project before anything is
+// written, returning if the items should be archived.  A read only token is an
+// error unless on_read_only is skip.
+func canArchive(cfg reportr.Config) (bool, error) {
+	writable, err := reportr.FetchProjectWritable(cfg.Owner, cfg.Project, reportr.Login(cfg).WithDebug(true))
+	if err != nil {
+		return false, err
+	}
+	if writable {
+		return true, nil
+	}
+
+	if cfg.OnReadOnly == "skip" {
+		out.Warn("the token can not update project %d of %s, the items will not be archived", cfg.Project, cfg.Owner)
+		return false, nil
+	}
+	return false, fmt.Errorf("the token can not update project %d of %s to archive the items, "+
+		"it needs the 'project' scope and write access (or set on_read_only: skip)", cfg.Project, cfg.Owner)
+}
 
-		err = os.WriteFile(filepath.Join(cfg.OutputDirectory, filename), []byte(data), 0644)
+// planKey returns the key signing the plan files.
+func planKey(cfg reportr.Config) string {
+	if len(cfg.PlanKey) > 0 {
+		return cfg.PlanKey
+	}
+	return cfg.Token
+}
+
+// writePlan writes the signed plan of the run to the plan file without
+// changing anything.  The reports are rendered into a copy of the output
+// directory and the plan has their contents, so apply writes exactly what was
+// reviewed.  The deliveries and hooks are not part of the plan.
+func writePlan(cfg reportr.Config, cli CLI) error {
+	if cli.AllProjects || len(cfg.Projects) > 0 {
+		return fmt.Errorf("%w: a plan can only be made for a single project", errConfig)
+	}
+
+	scratch, err := os.MkdirTemp("", "status-reportr-plan-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(scratch)
+
+	if err = copyFiles(cfg.OutputDirectory, scratch); err != nil {
+		return err
+	}
+
+	format := cli.PlanFormat
+	cfg.OutputDirectory = scratch
+	cfg.Hooks = reportr.Hooks{}
+	cli.DryRun = true
+	cli.PlanFormat = "none"
+
+	_, plan, err := run(cfg, cli, nil)
+	if err != nil {
+		return err
+	}
+
+	// The files are planned relative to the output directory.
+	plan.Contents = make(map[string][]byte, len(plan.Files))
+	for i, file := range plan.Files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(scratch, file)
 		if err != nil {
 			return err
 		}
+		plan.Files[i] = rel
+		plan.Contents[rel] = data
 	}
 
-	if !cli.DryRun {
-		client := login(cfg)
-		client = client.WithDebug(true)
+	buf, err := reportr.SignPlan(*plan, planKey(cfg))
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(cli.Plan.Output, buf, 0600); err != nil {
+		return err
+	}
+
+	shown := *plan
+	shown.Contents = nil
+	if err = showPlan(format, shown); err != nil {
+		return err
+	}
+	out.Success("Wrote the plan to %s, run apply with it to execute it.", cli.Plan.Output)
+	return nil
+}
+
+// copyFiles copies the files directly in the src directory, except the lock, to
+// the dst directory.  A missing src directory has nothing to copy.
+func copyFiles(src, dst string) error {
+	entries, err := os.ReadDir(src)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
 
-		id, err := fetchProjectInfo(cfg.Owner, cfg.Project, client)
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || entry.Name() == reportr.LockFilename {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(src, entry.Name()))
 		if err != nil {
 			return err
 		}
+		if err = os.WriteFile(filepath.Join(dst, entry.Name()), data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-		err = archive(id, client, weeks)
+// apply executes the plan file exactly as planned: the planned files are
+// written and the planned mutations run, even if the board changed since.
+// With --dry-run only the files are written.
+func apply(cfg reportr.Config, cli CLI) error {
+	file, err := os.ReadFile(cli.Apply.File)
+	if err != nil {
+		return err
+	}
+	plan, err := reportr.OpenPlan(file, planKey(cfg))
+	if err != nil {
+		return err
+	}
+	if plan.Owner != cfg.Owner || plan.Project != cfg.Project {
+		return fmt.Errorf("%w: the plan is for project %d of %s, not project %d of %s",
+			errConfig, plan.Project, plan.Owner, cfg.Project, cfg.Owner)
+	}
+	for _, m := range plan.Mutations {
+		if m.Mutation != reportr.ArchiveMutation {
+			return fmt.Errorf("%w: unknown mutation '%s'", reportr.ErrPlanInvalid, m.Mutation)
+		}
+	}
+
+	archiving := !cli.DryRun
+	if archiving {
+		if archiving, err = canArchive(cfg); err != nil {
+			return err
+		}
+	}
+
+	if err = os.MkdirAll(cfg.OutputDirectory, 0755); err != nil {
+		return err
+	}
+	for _, f := range plan.Files {
+		path := filepath.Join(cfg.OutputDirectory, f)
+		if err = os.WriteFile(path, plan.Contents[f], 0644); err != nil {
+			return err
+		}
+		out.Success("Wrote %s", path)
+	}
+
+	if cli.DryRun {
+		out.Info("Not running the %d planned mutations, it is a dry run.", len(plan.Mutations))
+		return nil
+	}
+	if !archiving {
+		return nil
+	}
+
+	ids := make([]string, 0, len(plan.Mutations))
+	for _, m := range plan.Mutations {
+		ids = append(ids, m.ItemID)
+	}
+	archived, aerr := archiveItems(cfg, ids)
+
+	historyFile := filepath.Join(cfg.OutputDirectory, reportr.HistoryFilename)
+	history, err := reportr.LoadHistory(historyFile)
+	if err != nil {
+		return err
+	}
+	for _, w := range plan.Windows {
+		history.MarkArchived(w.Start, w.End)
+	}
+	if err = history.Save(historyFile); err != nil {
+		return err
+	}
+	telemetry.AddArchived(archived)
+	if aerr != nil {
+		return aerr
+	}
+	out.Success("Archived %d items.", archived)
+	return nil
+}
+
+// showPlan prints the plan of the dry run in the format.
+func showPlan(format string, plan reportr.Plan) error {
+	switch format {
+	case "json":
+		buf, err := json.MarshalIndent(plan, "", "  ")
 		if err != nil {
 			return err
 		}
+		fmt.Fprintln(os.Stdout, string(buf))
+	case "text":
+		fmt.Fprint(os.Stdout, plan.Text())
 	}
 	return nil
 }
 
-func fileExist(file string) bool {
-	if _, err := os.Stat(file); err == nil {
-		return true
+// fetch gets the project items from the cache file if present, otherwise from
+// github.  A failed fetch from github is resumed by the next run.
+func fetch(cfg reportr.Config, cli CLI, skip reportr.SkipFunc) (reportr.Items, error) {
+	var err error
+
+	_ = os.MkdirAll(cfg.OutputDirectory, 0755)
+
+	var items reportr.Items
+	if len(cli.CacheFile) > 0 && fileExist(cli.CacheFile) {
+		items, err = reportr.LoadCache(cli.CacheFile)
+		if err != nil {
+			return nil, err
+		}
+		out.Info("Read %d items from %s.", len(items), cli.CacheFile)
+		if !cli.IncludeArchived {
+			var archived reportr.Items
+			archived, items = items.ExtractArchived()
+			if len(archived) > 0 {
+				out.Info("Skipped %d archived items.", len(archived))
+			}
+		}
+	} else {
+		out.Info("Fetching the items from github.")
+		client := reportr.Login(cfg)
+		client = client.WithDebug(true)
+
+		id, err := reportr.FetchProjectInfo(cfg.Owner, cfg.Project, client)
+		if err != nil {
+			return nil, err
+		}
+
+		progressFile := filepath.Join(cfg.OutputDirectory, reportr.ProgressFilename)
+		progress, err := reportr.LoadProgress(progressFile)
+		if err != nil {
+			return nil, err
+		}
+		if len(progress.Cursor) > 0 && progress.ProjectID == id {
+			out.Info("Resuming after %d items.", len(progress.Items))
+		}
+
+		start := time.Now()
+		items, err = reportr.FetchIssues(id, client,
+			cfg.Tuning.IssueCount,
+			cfg.Tuning.LabelCount,
+			cfg.Tuning.FieldValueCount,
+			cfg.Tuning.Workers,
+			cli.IncludeArchived,
+			&progress,
+			skip)
+		telemetry.ObserveFetch(time.Since(start))
+		if err != nil {
+			if perr := progress.Save(progressFile); perr != nil {
+				out.Error("%v", perr)
+			}
+			return nil, err
+		}
+		_ = os.Remove(progressFile)
+		for _, d := range items.SchemaDrift() {
+			name := d.Name
+			if len(name) == 0 {
+				name = "unknown"
+			}
+			out.Warn("Ignored %d values of the field '%s' with the unsupported type %s.", d.Count, name, d.Type)
+		}
+		if len(cli.CacheFile) > 0 {
+			if err = reportr.SaveCache(cli.CacheFile, items); err != nil {
+				return nil, err
+			}
+			out.Info("Cached %d items to %s.", len(items), cli.CacheFile)
+		}
 	}
-	return false
+
+	return items, nil
 }
 
-func login(cfg Config) *gql.Client {
-	src := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: cfg.Token},
-	)
+// list prints the items matching the ad-hoc query as a table.
+func list(cfg reportr.Config, cli CLI) error {
+	items, err := fetch(cfg, cli, nil)
+	if err != nil {
+		return err
+	}
 
-	return gql.NewClient(cfg.Url, oauth2.NewClient(context.Background(), src))
+	q := cli.List
+	if len(q.Label) > 0 {
+		items, _ = items.ExtractByLabels(false, q.Label...)
+	}
+	if len(q.Status) > 0 {
+		items, _ = items.ExtractByField(false, "Status", q.Status)
+	}
+	if len(q.Author) > 0 {
+		items, _ = items.ExtractByAuthors(false, q.Author...)
+	}
+	if len(q.Since) > 0 {
+		since, err := time.ParseInLocation("2006-01-02", q.Since, time.Local)
+		if err != nil {
+			return fmt.Errorf("%w: --since: %v", errConfig, err)
+		}
+		items = items.GetSince(since)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NUMBER\tREPO\tSTATUS\tDONE\tTITLE")
+	for _, item := range items {
+		var done string
+		if !item.DoneAt.IsZero() {
+			done = item.DoneAt.Local().Format("2006-01-02")
+		}
+		fmt.Fprintf(w, "#%d\t%s\t%s\t%s\t%s\n",
+			item.Number, item.Repo.Slug, item.Fields["Status"].Value(), done, item.Title())
+	}
+	fmt.Fprintf(w, "\n%d items\n", len(items))
+	return w.Flush()
 }
 
-func render(cfg Config, week WeeklyItems) string {
-	sections := make(map[int]string, len(cfg.Sections))
+// snapshot writes the summary of the whole board to stdout or the output file.
+// The hidden items are left out and the items are redacted like in the reports.
+func snapshot(cfg reportr.Config, cli CLI) error {
+	items, err := fetch(cfg, cli, nil)
+	if err != nil {
+		return err
+	}
 
-	left := week.Items
+	doc := reportr.RenderSnapshot(cfg, cfg.Redact.Items(cfg.Hide.Items(items)), time.Now())
+	if len(cli.Snapshot.Output) == 0 {
+		_, err = fmt.Fprint(os.Stdout, doc)
+		return err
+	}
 
-	for _, section := range cfg.Sections {
-		var buf strings.Builder
-		left = section.ExtractAndRender(left, &buf)
-		sections[section.RenderOrder] = buf.String()
+	if err = os.WriteFile(cli.Snapshot.Output, []byte(doc), 0644); err != nil {
+		return err
 	}
+	out.Success("Wrote %s", cli.Snapshot.Output)
+	return nil
+}
 
-	if true {
-		var buf strings.Builder
-		Section{
-			Name:        cfg.Unclassified.Name,
-			RenderOrder: cfg.Unclassified.RenderOrder,
-			OmitIfEmpty: cfg.Unclassified.OmitIfEmpty,
-		}.Render(left, &buf)
-		sections[cfg.Unclassified.RenderOrder] = buf.String()
+// demoReports renders the reports of the fabricated items with the
+// configuration.  The items are saved in the output directory and reused by the
+// next demo, so they can be edited to try out more cases.
+func demoReports(cfg reportr.Config, cli CLI) error {
+	cfg.OutputDirectory = cli.Demo.Output
+	cfg.Hooks = reportr.Hooks{}
+	if err := os.MkdirAll(cfg.OutputDirectory, 0755); err != nil {
+		return err
 	}
 
-	if cfg.LabelSection.Enabled {
-		var buf strings.Builder
-		fmt.Fprintf(&buf, "\n## By Label\n\n")
-		labels := week.Items.GetUniqLabels()
-		keys := make([]string, 0, len(labels))
-		for key := range labels {
-			keys = append(keys, key)
+	file := filepath.Join(cfg.OutputDirectory, "demo.json")
+	if !fileExist(file) {
+		if err := reportr.SaveCache(file, reportr.DemoItems(time.Now())); err != nil {
+			return err
 		}
-		sort.Strings(keys)
+		out.Info("Wrote the demo items to %s, edit them to change the reports.", file)
+	}
+
+	cli.CacheFile = file
+	cli.DryRun = true
+	cli.PlanFormat = "none"
+	cli.Interactive = false
+
+	_, _, err := run(cfg, cli, nil)
+	return err
+}
+
+// notes writes the release notes of the pull requests merged in the most recent
+// window, and publishes them as draft releases if asked.  The hidden items are
+// left out and the items are redacted like in the reports.
+func notes(cfg reportr.Config, cli CLI) error {
+	items, err := fetch(cfg, cli, nil)
+	if err != nil {
+		return err
+	}
+
+	done, _ := cfg.Abandoned.Split(items)
+	weeks := reportr.SplitByWindow(done, time.Now(), cfg.ReportWindow)
+	if len(weeks) == 0 {
+		out.Info("There are no merged pull requests.")
+		return nil
+	}
+	week := cfg.Redact.Week(cfg.Hide.Week(weeks[0]))
 
-		for _, key := range keys {
-			fmt.Fprintf(&buf, "- %s (%d)\n", key, labels[key])
+	doc := cfg.ReleaseNotes.Render(cfg, week)
+	if len(cli.Notes.Output) == 0 {
+		_, err = fmt.Fprint(os.Stdout, doc)
+	} else if err = os.WriteFile(cli.Notes.Output, []byte(doc), 0644); err == nil {
+		out.Success("Wrote %s", cli.Notes.Output)
+	}
+	if err != nil || !cli.Notes.Publish {
+		return err
+	}
+
+	tag := week.End.AddDate(0, 0, -1).Format(cfg.ReleaseNotes.TagFormat)
+	byRepo, slugs := cfg.ReleaseNotes.ByRepo(cfg, week.Items, 1)
+	for _, slug := range slugs {
+		if cli.DryRun {
+			out.Info("Would create the draft release %s of %s", tag, slug)
+			continue
 		}
+		if err = reportr.PublishDraftRelease(cfg, slug, tag, byRepo[slug]); err != nil {
+			return err
+		}
+		out.Success("Created the draft release %s of %s", tag, slug)
+	}
+	return nil
+}
 
-		sections[cfg.LabelSection.RenderOrder] = buf.String()
+// bot answers the issues asking for a report with the markdown of the newest
+// report.  The reports are generated without archiving the items or running
+// the hooks, so the scheduled runs are not affected.  With --dry-run the
+// issues are only listed.
+func bot(cfg reportr.Config, cli CLI) error {
+	if len(cfg.Bot.Repo) == 0 {
+		return fmt.Errorf("%w: the bot requires the bot.repo to watch", errConfig)
 	}
 
-	if cfg.Summary.Enabled {
-		var buf strings.Builder
-		fmt.Fprintf(&buf, "\n## %s\n\n", cfg.Summary.Name)
-		fmt.Fprintf(&buf, "%s\n\n", cfg.Summary.Body)
-		sections[cfg.Summary.RenderOrder] = buf.String()
+	client := reportr.Login(cfg).WithDebug(true)
+	requests, err := reportr.FetchBotRequests(cfg.Bot, client)
+	if err != nil {
+		return err
+	}
+	if len(requests) == 0 {
+		out.Info("No report requests in %s.", cfg.Bot.Repo)
+		return nil
 	}
 
-	var rv strings.Builder
+	reply := !cli.DryRun
+	cli.DryRun = true
+	cli.PlanFormat = "none"
+	cli.Interactive = false
+	cfg.Hooks = reportr.Hooks{}
 
-	fmt.Fprintf(&rv, "# Status Report: %s ... %s\n\n## %s\n\n",
-		week.Start.Format("Jan 2, 2006"),
-		week.End.AddDate(0, 0, -1).Format("Jan 2, 2006"),
-		cfg.Team,
-	)
+	// The markdown report is rendered first, so it is the one recorded.
+	formats := []string{"markdown"}
+	for _, format := range cfg.Formats {
+		if format != "markdown" {
+			formats = append(formats, format)
+		}
+	}
+	cfg.Formats = formats
 
-	keys := make([]int, 0, len(sections))
-	for key := range sections {
-		keys = append(keys, key)
+	records, _, err := run(cfg, cli, nil)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		out.Warn("There are no items to report, the requests are not answered.")
+		return nil
 	}
-	sort.Ints(keys)
 
-	for _, key := range keys {
-		rv.WriteString(sections[key])
+	report, err := os.ReadFile(filepath.Join(cfg.OutputDirectory, records[0].Filename))
+	if err != nil {
+		return err
 	}
 
-	return rv.String()
+	for _, req := range requests {
+		if !reply {
+			out.Info("Would answer %s", req.URL)
+			continue
+		}
+		if err = reportr.ReplyToBotRequest(req, string(report), client); err != nil {
+			return err
+		}
+		out.Success("Answered %s", req.URL)
+	}
+	return nil
 }
 
-func archive(projectId string, client *gql.Client, weeks []WeeklyItems) error {
-	for _, week := range weeks {
-		for _, item := range week.Items {
-			if err := archiveItem(projectId, item.ID, client); err != nil {
+// sweep generates the reports for every open project owned by the org, or for
+// the configured projects, each in its own directory, and a combined rollup of
+// all the projects.
+func sweep(cfg reportr.Config, cli CLI, deliverers []reportr.Deliverer) error {
+	if len(cli.CacheFile) > 0 {
+		return fmt.Errorf("%w: a cache file can not be used with multiple projects", errConfig)
+	}
+
+	// Without configured projects every open project of the org is reported
+	// on.  With them, the reports of each owner are placed in a directory
+	// named after the owner so the projects of different hosts don't collide.
+	sources := cfg.Projects
+	if len(sources) == 0 {
+		sources = []reportr.ProjectSource{{}}
+	}
+
+	_ = os.Mkdir(cfg.OutputDirectory, 0755)
+
+	var rollup []reportr.ProjectRollup
+	var total int
+	for _, source := range sources {
+		scfg := source.Apply(cfg)
+
+		client := reportr.Login(scfg)
+		client = client.WithDebug(true)
+
+		projects, err := reportr.FetchProjects(scfg.Owner, client, scfg.Tuning.IssueCount)
+		if err != nil {
+			return fmt.Errorf("%s: %w", scfg.Owner, err)
+		}
+
+		for _, project := range projects {
+			if source.Project > 0 && source.Project != project.Number {
+				continue
+			}
+			out.Info("Project %s/%d: %s", scfg.Owner, project.Number, project.Title)
+
+			dir := project.Directory()
+			if len(cfg.Projects) > 0 {
+				dir = path.Join(scfg.Owner, dir)
+			}
+			pcfg := scfg
+			pcfg.Project = project.Number
+			pcfg.OutputDirectory = filepath.Join(cfg.OutputDirectory, dir)
+
+			records, _, err := run(pcfg, cli, deliverers)
+			if err != nil {
 				return err
 			}
+
+			rollup = append(rollup, reportr.ProjectRollup{
+				Project:   project,
+				Directory: dir,
+				Reports:   records,
+			})
+			total += reported(records)
 		}
 	}
 
-	return nil
+	err := os.WriteFile(filepath.Join(cfg.OutputDirectory, cfg.Rollup.Filename),
+		[]byte(reportr.RenderRollup(cfg, rollup)), 0644)
+	if err == nil && total == 0 {
+		return errNothingToReport
+	}
+	return err
+}
+
+// remoteConfigs splits the configuration files into the local files and the
+// options to fetch the https urls.  The urls are fetched when the
+// configuration is compiled and sorted with the local files by their names.
+// The remote files may only run commands if allowCommands is set.
+func remoteConfigs(all []string, auth string, allowCommands bool) ([]string, []goschtalt.Option, error) {
+	var files []string
+	var remote []goschtalt.Option
+
+	for _, file := range all {
+		if !strings.Contains(file, "://") {
+			files = append(files, file)
+			continue
+		}
+
+		u, err := url.Parse(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		if u.Scheme != "https" {
+			return nil, nil, fmt.Errorf("only https configuration urls are supported: %s", file)
+		}
+
+		name := path.Base(u.Path)
+		if ext := path.Ext(name); ext != ".yml" && ext != ".yaml" {
+			return nil, nil, fmt.Errorf("the configuration url must end in .yml or .yaml: %s", file)
+		}
+
+		file := file
+		remote = append(remote, goschtalt.AddBufferFn(name,
+			func(string, goschtalt.UnmarshalFunc) ([]byte, error) {
+				return fetchRemoteConfig(file, auth, allowCommands)
+			}))
+	}
+
+	return files, remote, nil
+}
+
+// fetchRemoteConfig fetches the configuration file at the url, refusing it if
+// it runs commands unless allowCommands is set.
+func fetchRemoteConfig(file, auth string, allowCommands bool) ([]byte, error) {
+	buf, err := fetchConfig(file, auth)
+	if err != nil || allowCommands {
+		return buf, err
+	}
+
+	var doc any
+	if err = yaml.Unmarshal(buf, &doc); err != nil {
+		return nil, fmt.Errorf("the configuration %s is not valid yaml: %v", file, err)
+	}
+	if found := commands(doc, ""); len(found) > 0 {
+		return nil, fmt.Errorf("the configuration %s runs commands (%s), pass --allow-remote-commands to trust it",
+			file, strings.Join(found, ", "))
+	}
+	return buf, nil
+}
+
+// commands returns the paths of the values in the configuration document that
+// run shell commands: the hooks, the exec deliveries and the cmd: secrets.
+func commands(node any, at string) []string {
+	var found []string
+	switch v := node.(type) {
+	case map[string]any:
+		for _, k := range sortedKeys(v) {
+			child := strings.TrimPrefix(at+"."+k, ".")
+			if hooks, ok := v[k].(map[string]any); ok && k == "hooks" {
+				for _, name := range sortedKeys(hooks) {
+					if s, ok := hooks[name].(string); ok && len(strings.TrimSpace(s)) > 0 {
+						found = append(found, child+"."+name)
+					}
+				}
+				continue
+			}
+			if k == "type" && v[k] == "exec" {
+				found = append(found, child)
+				continue
+			}
+			found = append(found, commands(v[k], child)...)
+		}
+	case []any:
+		for i, item := range v {
+			found = append(found, commands(item, fmt.Sprintf("%s[%d]", at, i))...)
+		}
+	case string:
+		if strings.HasPrefix(strings.TrimSpace(v), "cmd:") {
+			found = append(found, at)
+		}
+	}
+	return found
+}
+
+// sortedKeys returns the keys of the map in order.
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// configTimeout is the limit on fetching a remote configuration file.
+var configTimeout = 30 * time.Second
+
+// fetchConfig fetches the configuration file at the url using the optional
+// Authorization header value.
+func fetchConfig(file, auth string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, file, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(auth) > 0 {
+		req.Header.Set("Authorization", auth)
+	}
+
+	client := http.Client{Timeout: configTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching configuration %s failed: %s", file, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+func fileExist(file string) bool {
+	if _, err := os.Stat(file); err == nil {
+		return true
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
 }
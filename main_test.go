@@ -0,0 +1,513 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/goschtalt/goschtalt"
+	gql "github.com/hasura/go-graphql-client"
+	"github.com/schmidtw/status-reportr/pkg/reportr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testConfig returns the default configuration with the yml applied on top.
+func testConfig(t *testing.T, yml string) reportr.Config {
+	t.Helper()
+	require := require.New(t)
+
+	gs, err := goschtalt.New(
+		goschtalt.DefaultUnmarshalOptions(
+			goschtalt.WeaklyTypedInput(),
+			goschtalt.TagName("yaml"),
+		),
+		goschtalt.AddBuffer("default.yml", []byte(defaultConfig), goschtalt.AsDefault()),
+		goschtalt.AddBuffer("test.yml", []byte(yml)),
+		goschtalt.AutoCompile(),
+	)
+	require.NoError(err)
+
+	cfg, err := goschtalt.Unmarshal[reportr.Config](gs, "")
+	require.NoError(err)
+	sections, err := goschtalt.Unmarshal[[]map[string]any](gs, "sections")
+	require.NoError(err)
+	cfg.ApplySectionDefaults(sections)
+	return cfg
+}
+
+func TestRunAlreadyArchivedSkip(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	out = newConsole(true, "never")
+	dir := t.TempDir()
+	cfg := testConfig(t, "already_archived: skip\n")
+	cfg.OutputDirectory = dir
+
+	done := func(id string, when time.Time) reportr.Item {
+		return reportr.Item{
+			ID:     id,
+			DoneAt: when,
+			Fields: map[string]reportr.Field{"Status": {Type: reportr.FIELD_TEXT, Text: "Done"}},
+		}
+	}
+	now := time.Now()
+	items := reportr.Items{
+		done("recent", now.AddDate(0, 0, -7)),
+		done("late", now.AddDate(0, 0, -14)),
+	}
+	cache := filepath.Join(dir, "cache.json")
+	require.NoError(reportr.SaveCache(cache, items))
+
+	// The window of the late item was reported and archived before it was done.
+	weeks := reportr.SplitByWindow(items.GetDone(), now, cfg.ReportWindow)
+	require.Len(weeks, 2)
+	var history reportr.History
+	history.Record(reportr.NewReportRecord("old.md", reportr.WeeklyItems{Start: weeks[1].Start, End: weeks[1].End}, now))
+	history.MarkArchived(weeks[1].Start, weeks[1].End)
+	require.NoError(history.Save(filepath.Join(dir, reportr.HistoryFilename)))
+
+	records, plan, err := run(cfg, CLI{DryRun: true, PlanFormat: "none", CacheFile: cache}, nil)
+	require.NoError(err)
+	require.NotNil(plan)
+
+	require.Len(records, 1)
+	assert.Equal(weeks[0].Start, records[0].Start)
+	require.Len(plan.Archive, 1)
+	assert.Equal("recent", plan.Archive[0].ID)
+	require.Len(plan.Windows, 1)
+	assert.Equal(weeks[0].Start, plan.Windows[0].Start)
+}
+
+func TestGenerateAllHidden(t *testing.T) {
+	tests := []struct {
+		description string
+		yml         string
+		expectErr   error
+		expectItems int
+	}{
+		{
+			description: "shown",
+			expectItems: 2,
+		}, {
+			description: "some hidden",
+			yml:         "hide:\n  repos: [ org/hidden ]\n",
+			expectItems: 1,
+		}, {
+			description: "all hidden",
+			yml:         "hide:\n  repos: [ org/* ]\n",
+			expectErr:   errNothingToReport,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			out = newConsole(true, "never")
+			dir := t.TempDir()
+			cfg := testConfig(t, tc.yml)
+			cfg.OutputDirectory = dir
+
+			item := func(id, repo string) reportr.Item {
+				i := reportr.Item{
+					ID:     id,
+					DoneAt: time.Now().AddDate(0, 0, -7),
+					Fields: map[string]reportr.Field{"Status": {Type: reportr.FIELD_TEXT, Text: "Done"}},
+				}
+				i.Repo.Slug = repo
+				return i
+			}
+			cache := filepath.Join(dir, "cache.json")
+			require.NoError(reportr.SaveCache(cache, reportr.Items{
+				item("a", "org/shown"),
+				item("b", "org/hidden"),
+			}))
+
+			cli := CLI{DryRun: true, PlanFormat: "none", CacheFile: cache}
+			err := generate(cfg, cli, nil)
+			if tc.expectErr != nil {
+				assert.ErrorIs(err, tc.expectErr)
+				return
+			}
+			require.NoError(err)
+
+			records, _, err := run(cfg, cli, nil)
+			require.NoError(err)
+			assert.Equal(tc.expectItems, reported(records))
+		})
+	}
+}
+
+func TestRunDryRunDeliveries(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	out = newConsole(true, "never")
+	dir := t.TempDir()
+	delivered := filepath.Join(dir, "delivered.txt")
+	cfg := testConfig(t, "")
+	cfg.OutputDirectory = dir
+	cfg.Deliver = []reportr.Delivery{{Type: "exec", Command: "cat >> " + delivered}}
+
+	items := reportr.Items{
+		{
+			ID:     "a",
+			DoneAt: time.Now().AddDate(0, 0, -7),
+			Fields: map[string]reportr.Field{"Status": {Type: reportr.FIELD_TEXT, Text: "Done"}},
+		},
+	}
+	cache := filepath.Join(dir, "cache.json")
+	require.NoError(reportr.SaveCache(cache, items))
+
+	d, err := reportr.NewDeliverer(cfg.Deliver[0])
+	require.NoError(err)
+
+	records, plan, err := run(cfg, CLI{DryRun: true, PlanFormat: "none", CacheFile: cache}, []reportr.Deliverer{d})
+	require.NoError(err)
+	require.Len(records, 1)
+	assert.NoFileExists(delivered)
+
+	require.NotNil(plan)
+	assert.Equal([]reportr.PlanDelivery{
+		{Type: "exec", Path: filepath.Join(dir, records[0].Filename)},
+	}, plan.Deliveries)
+}
+
+func TestSnapshotRedacted(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	out = newConsole(true, "never")
+	dir := t.TempDir()
+	cfg := testConfig(t, `
+snapshot:
+  list_items: true
+redact:
+  enabled: true
+  mode: placeholder
+  placeholder: internal
+  urls: true
+  repos: true
+  people: true
+`)
+	cfg.OutputDirectory = dir
+
+	items := reportr.Items{
+		{
+			ID:     "a",
+			Number: 7,
+			URL:    "https://github.example.com/org/secret/issues/7",
+			Author: "octocat",
+			Fields: map[string]reportr.Field{
+				"Title":  {Type: reportr.FIELD_TEXT, Text: "Fix it"},
+				"Status": {Type: reportr.FIELD_TEXT, Text: "In Progress"},
+			},
+		},
+	}
+	items[0].Repo.Slug = "org/secret"
+	items[0].Repo.URL = "https://github.example.com/org/secret"
+	cache := filepath.Join(dir, "cache.json")
+	require.NoError(reportr.SaveCache(cache, items))
+
+	file := filepath.Join(dir, "snapshot.md")
+	cli := CLI{CacheFile: cache}
+	cli.Snapshot.Output = file
+	require.NoError(snapshot(cfg, cli))
+
+	buf, err := os.ReadFile(file)
+	require.NoError(err)
+	assert.Contains(string(buf), "Fix it **[#7]** (internal)")
+	assert.NotContains(string(buf), "org/secret")
+	assert.NotContains(string(buf), "github.example.com")
+}
+
+func TestSnapshotHidden(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	out = newConsole(true, "never")
+	dir := t.TempDir()
+	cfg := testConfig(t, `
+snapshot:
+  list_items: true
+hide:
+  private: true
+  repos: [ org/denied ]
+`)
+	cfg.OutputDirectory = dir
+
+	item := func(id, title, repo string, private bool) reportr.Item {
+		i := reportr.Item{
+			ID:      id,
+			Private: private,
+			Fields: map[string]reportr.Field{
+				"Title":  {Type: reportr.FIELD_TEXT, Text: title},
+				"Status": {Type: reportr.FIELD_TEXT, Text: "Todo"},
+			},
+		}
+		i.Repo.Slug = repo
+		return i
+	}
+	items := reportr.Items{
+		item("a", "Shown", "org/public", false),
+		item("b", "Private", "org/private", true),
+		item("c", "Denied", "org/denied", false),
+	}
+	cache := filepath.Join(dir, "cache.json")
+	require.NoError(reportr.SaveCache(cache, items))
+
+	file := filepath.Join(dir, "snapshot.md")
+	cli := CLI{CacheFile: cache}
+	cli.Snapshot.Output = file
+	require.NoError(snapshot(cfg, cli))
+
+	buf, err := os.ReadFile(file)
+	require.NoError(err)
+	assert.Contains(string(buf), "Shown")
+	assert.Contains(string(buf), "| **Total** | 1 |")
+	assert.NotContains(string(buf), "Private")
+	assert.NotContains(string(buf), "Denied")
+}
+
+func TestCLIOverride(t *testing.T) {
+	const file = "owner: file-org\nproject_number: 1\nteam: File Team\noutput_directory: file-dir\n"
+
+	tests := []struct {
+		description string
+		env         map[string]string
+		cli         CLI
+		expect      reportr.Config
+	}{
+		{
+			description: "nothing set",
+			expect:      reportr.Config{Owner: "file-org", Project: 1, Team: "File Team", OutputDirectory: "file-dir"},
+		}, {
+			description: "the flags override the file",
+			cli:         CLI{Owner: "cli-org", Project: 3, Team: "CLI Team", OutputDir: "cli-dir"},
+			expect:      reportr.Config{Owner: "cli-org", Project: 3, Team: "CLI Team", OutputDirectory: "cli-dir"},
+		}, {
+			description: "the flags override the environment",
+			env: map[string]string{
+				"SR_OWNER":            "env-org",
+				"SR_PROJECT_NUMBER":   "2",
+				"SR_TEAM":             "Env Team",
+				"SR_OUTPUT_DIRECTORY": "env-dir",
+			},
+			cli:    CLI{Owner: "cli-org", Project: 3, Team: "CLI Team", OutputDir: "cli-dir"},
+			expect: reportr.Config{Owner: "cli-org", Project: 3, Team: "CLI Team", OutputDirectory: "cli-dir"},
+		}, {
+			description: "unset flags leave the environment",
+			env: map[string]string{
+				"SR_OWNER":            "env-org",
+				"SR_PROJECT_NUMBER":   "2",
+				"SR_TEAM":             "Env Team",
+				"SR_OUTPUT_DIRECTORY": "env-dir",
+			},
+			cli:    CLI{Team: "CLI Team"},
+			expect: reportr.Config{Owner: "env-org", Project: 2, Team: "CLI Team", OutputDirectory: "env-dir"},
+		}, {
+			description: "some flags",
+			cli:         CLI{Owner: "cli-org", OutputDir: "cli-dir"},
+			expect:      reportr.Config{Owner: "cli-org", Project: 1, Team: "File Team", OutputDirectory: "cli-dir"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			cfg := testConfig(t, file)
+			lookup := func(key string) (string, bool) {
+				v, ok := tc.env[key]
+				return v, ok
+			}
+			require.NoError(reportr.ApplyEnv(&cfg, reportr.EnvPrefix, lookup))
+			tc.cli.override(&cfg)
+
+			assert.Equal(tc.expect.Owner, cfg.Owner)
+			assert.Equal(tc.expect.Project, cfg.Project)
+			assert.Equal(tc.expect.Team, cfg.Team)
+			assert.Equal(tc.expect.OutputDirectory, cfg.OutputDirectory)
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		description string
+		err         error
+		expect      int
+	}{
+		{
+			description: "other failure",
+			err:         errors.New("disk full"),
+			expect:      exitFailure,
+		}, {
+			description: "config",
+			err:         errConfig,
+			expect:      exitConfig,
+		}, {
+			description: "wrapped config",
+			err:         fmt.Errorf("%w: owner is required", errConfig),
+			expect:      exitConfig,
+		}, {
+			description: "auth",
+			err:         reportr.APIErrors{{Type: "FORBIDDEN", Message: "Resource not accessible by integration"}},
+			expect:      exitAuth,
+		}, {
+			description: "wrapped auth",
+			err:         fmt.Errorf("fetching items: %w", reportr.APIErrors{{Message: "Bad credentials"}}),
+			expect:      exitAuth,
+		}, {
+			description: "api",
+			err:         reportr.APIErrors{{Type: "NOT_FOUND", Message: "Could not resolve to a node"}},
+			expect:      exitAPI,
+		}, {
+			description: "wrapped graphql api",
+			err:         fmt.Errorf("archiving: %w", gql.Errors{{Message: "Something went wrong"}}),
+			expect:      exitAPI,
+		}, {
+			description: "nothing to report",
+			err:         errNothingToReport,
+			expect:      exitNothing,
+		}, {
+			description: "wrapped nothing to report",
+			err:         fmt.Errorf("project 5: %w", errNothingToReport),
+			expect:      exitNothing,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expect, exitCode(tc.err))
+		})
+	}
+}
+
+func TestFetchConfig(t *testing.T) {
+	tests := []struct {
+		description string
+		auth        string
+		status      int
+		body        string
+		delay       time.Duration
+		allow       bool
+		expect      string
+		expectErr   string
+	}{
+		{
+			description: "fetched",
+			status:      http.StatusOK,
+			body:        "team: Team A\n",
+			expect:      "team: Team A\n",
+		}, {
+			description: "with the auth header",
+			auth:        "Bearer token",
+			status:      http.StatusOK,
+			body:        "team: Team A\n",
+			expect:      "team: Team A\n",
+		}, {
+			description: "not found",
+			status:      http.StatusNotFound,
+			expectErr:   "404 Not Found",
+		}, {
+			description: "too slow",
+			status:      http.StatusOK,
+			delay:       500 * time.Millisecond,
+			expectErr:   "Timeout",
+		}, {
+			description: "hooks are refused",
+			status:      http.StatusOK,
+			body:        "hooks:\n  pre_fetch: rm -rf /\n  post_render: ''\n",
+			expectErr:   "runs commands (hooks.pre_fetch)",
+		}, {
+			description: "exec deliveries and cmd secrets are refused",
+			status:      http.StatusOK,
+			body:        "token: 'cmd: cat /secret'\ndeliver:\n  - type: file\n  - type: exec\n    command: sh\n",
+			expectErr:   "runs commands (deliver[1].type, token)",
+		}, {
+			description: "commands allowed",
+			status:      http.StatusOK,
+			body:        "hooks:\n  pre_fetch: echo hi\n",
+			allow:       true,
+			expect:      "hooks:\n  pre_fetch: echo hi\n",
+		},
+	}
+
+	configTimeout = 200 * time.Millisecond
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(tc.auth, r.Header.Get("Authorization"))
+				time.Sleep(tc.delay)
+				w.WriteHeader(tc.status)
+				fmt.Fprint(w, tc.body)
+			}))
+			defer ts.Close()
+
+			got, err := fetchRemoteConfig(ts.URL+"/team.yml", tc.auth, tc.allow)
+			if len(tc.expectErr) > 0 {
+				assert.ErrorContains(err, tc.expectErr)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tc.expect, string(got))
+		})
+	}
+}
+
+func TestRemoteConfigs(t *testing.T) {
+	tests := []struct {
+		description string
+		files       []string
+		expectFiles []string
+		expectCount int
+		expectErr   string
+	}{
+		{
+			description: "local and remote",
+			files:       []string{"local.yml", "https://example.com/team.yml", "dir"},
+			expectFiles: []string{"local.yml", "dir"},
+			expectCount: 1,
+		}, {
+			description: "not https",
+			files:       []string{"http://example.com/team.yml"},
+			expectErr:   "only https",
+		}, {
+			description: "not yaml",
+			files:       []string{"https://example.com/team.json"},
+			expectErr:   "must end in .yml or .yaml",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			files, remote, err := remoteConfigs(tc.files, "", false)
+			if len(tc.expectErr) > 0 {
+				assert.ErrorContains(err, tc.expectErr)
+				// The caller adds the configuration error.
+				assert.NotErrorIs(err, errConfig)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tc.expectFiles, files)
+			assert.Len(remote, tc.expectCount)
+		})
+	}
+}
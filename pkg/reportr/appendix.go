@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// appendixExt is the file extension of the appendix reports.
+const appendixExt = ".appendix.md"
+
+// renderAppendix renders the companion of a report with every item of the week
+// and all of its details: the dates, labels and every project field.  The
+// report itself can stay short (see max_items) and link to it.
+func renderAppendix(cfg Config, week WeeklyItems) ([]byte, string, error) {
+	var buf strings.Builder
+
+	fmt.Fprintf(&buf, "%s %s %s: %s ... %s\n", cfg.Markdown.Heading(0), cfg.Team, cfg.Locale.T("appendix"),
+		cfg.Locale.Date(week.Start), cfg.Locale.Date(week.End.AddDate(0, 0, -1)))
+
+	detail := func(name, value string) {
+		if len(value) > 0 {
+			fmt.Fprintf(&buf, "%s **%s:** %s\n", cfg.Markdown.ListMarker(), name, value)
+		}
+	}
+
+	for _, it := range week.Items {
+		fmt.Fprintf(&buf, "\n%s %s ([%s#%d](%s))\n\n", cfg.Markdown.Heading(1),
+			cfg.Markdown.Title(it.Title()), it.Repo.Slug, it.Number, it.URL)
+
+		detail(cfg.Locale.T("item_type"), it.ItemType)
+		detail(cfg.Locale.T("author"), it.Author)
+		if !it.CreatedAt.IsZero() {
+			detail(cfg.Locale.T("created"), cfg.Locale.Date(it.CreatedAt))
+		}
+		if !it.DoneAt.IsZero() {
+			detail(cfg.Locale.T("done_on"), cfg.Locale.Date(it.DoneAt))
+		}
+		if len(it.Labels) > 0 {
+			detail(cfg.Locale.T("labels"), "`"+strings.Join(it.Labels, "`, `")+"`")
+		}
+		if it.Parent != nil {
+			detail(cfg.Locale.T("parent"), fmt.Sprintf("%s ([#%d](%s))",
+				cfg.Markdown.Title(it.Parent.Title), it.Parent.Number, it.Parent.URL))
+		}
+
+		names := make([]string, 0, len(it.Fields))
+		for name := range it.Fields {
+			// The title is the heading already.
+			if name != "Title" {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			detail(name, it.Fields[name].Value())
+		}
+	}
+
+	return []byte(buf.String()), appendixExt, nil
+}
+
+// appendixName returns the file name of the appendix of the report for the
+// week, or the empty string if the appendix is not generated.
+func appendixName(cfg Config, week WeeklyItems) string {
+	for _, format := range cfg.Formats {
+		if format == "appendix" {
+			return ReportBasename(cfg, week) + appendixExt
+		}
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderAppendix(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	week := WeeklyItems{
+		Start: time.Date(2022, 11, 27, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2022, 12, 4, 0, 0, 0, 0, time.UTC),
+		Items: Items{
+			{
+				ItemType:  "PR",
+				Number:    23,
+				URL:       "https://github.com/org/repo/pull/23",
+				Author:    "alice",
+				CreatedAt: time.Date(2022, 11, 20, 0, 0, 0, 0, time.UTC),
+				DoneAt:    time.Date(2022, 11, 29, 0, 0, 0, 0, time.UTC),
+				Labels:    []string{"bug", "area/api"},
+				Parent:    &Parent{Number: 1, Title: "Epic", URL: "https://github.com/org/repo/issues/1"},
+				Fields: map[string]Field{
+					"Title":    {Type: FIELD_TEXT, Text: "Fix it"},
+					"Status":   {Type: FIELD_TEXT, Text: "Done"},
+					"Estimate": {Type: FIELD_NUMBER, Number: 3},
+				},
+			},
+			{
+				ItemType: "ISSUE",
+				Number:   7,
+				URL:      "https://github.com/org/other/issues/7",
+			},
+		},
+	}
+	week.Items[0].Repo.Slug = "org/repo"
+	week.Items[1].Repo.Slug = "org/other"
+
+	r, ok := GetRenderer("appendix")
+	require.True(ok)
+	data, ext, err := r.Render(Config{Team: "Team"}, week)
+	require.NoError(err)
+
+	assert.Equal(".appendix.md", ext)
+	assert.Equal("# Team Appendix: Nov 27, 2022 ... Dec 3, 2022\n"+
+		"\n## Fix it ([org/repo#23](https://github.com/org/repo/pull/23))\n\n"+
+		"- **Type:** PR\n"+
+		"- **Author:** alice\n"+
+		"- **Created:** Nov 20, 2022\n"+
+		"- **Done:** Nov 29, 2022\n"+
+		"- **Labels:** `bug`, `area/api`\n"+
+		"- **Parent:** Epic ([#1](https://github.com/org/repo/issues/1))\n"+
+		"- **Estimate:** 3\n"+
+		"- **Status:** Done\n"+
+		"\n##  ([org/other#7](https://github.com/org/other/issues/7))\n\n"+
+		"- **Type:** ISSUE\n",
+		string(data))
+}
+
+func TestAppendixLink(t *testing.T) {
+	week := WeeklyItems{
+		Start: time.Date(2022, 11, 27, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2022, 12, 4, 0, 0, 0, 0, time.UTC),
+	}
+	list := Items{{Number: 1, URL: "u1"}, {Number: 2, URL: "u2"}}
+
+	tests := []struct {
+		description string
+		formats     []string
+		expect      string
+	}{
+		{
+			description: "without an appendix",
+			formats:     []string{"markdown"},
+			expect:      "- _\u2026 and 1 more_\n",
+		}, {
+			description: "with an appendix",
+			formats:     []string{"markdown", "appendix"},
+			expect:      "- _[\u2026 and 1 more](2022.11.27-2022.12.03.appendix.md)_\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			cfg := Config{Formats: tc.formats}
+			cfg.Appendix = appendixName(cfg, week)
+
+			var buf strings.Builder
+			Section{Name: "Name", MaxItems: 1}.Render(cfg, list, &buf)
+			assert.True(strings.HasSuffix(buf.String(), tc.expect), buf.String())
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"time"
+
+	gql "github.com/hasura/go-graphql-client"
+)
+
+// PendingFilename is the name of the file in the output directory that holds
+// the items a previous run failed to archive.
+const PendingFilename = ".status-reportr-pending.json"
+
+// archiveBackoff is the wait before the first retry of an item, each further
+// retry waits longer.
+var archiveBackoff = 2 * time.Second
+
+// ArchiveFailure is an item that could not be archived.
+type ArchiveFailure struct {
+	ID  string
+	Err error
+}
+
+// PendingArchive is the list of items still to archive from the project,
+// resumed by the next run.
+type PendingArchive struct {
+	ProjectID string   `json:"project_id"`
+	Items     []string `json:"items"`
+}
+
+// ArchiveIDs returns the ids of the items in the weeks.
+func ArchiveIDs(weeks []WeeklyItems) []string {
+	var rv []string
+	for _, week := range weeks {
+		for _, item := range week.Items {
+			rv = append(rv, item.ID)
+		}
+	}
+	return rv
+}
+
+// Archive archives the items from the project in batches of up to batch items
+// per request.  The items that fail are tried again, up to retries more times,
+// after the rest are done.  The items that are still not archived are
+// returned.  Github refusing the token fails every item that is left without
+// trying them.
+func Archive(projectId string, client *gql.Client, ids []string, batch, retries int) []ArchiveFailure {
+	if batch < 1 {
+		batch = 1
+	}
+
+	for attempt := 0; ; attempt++ {
+		var failed []ArchiveFailure
+		for start := 0; start < len(ids); start += batch {
+			end := start + batch
+			if end > len(ids) {
+				end = len(ids)
+			}
+
+			list, err := ArchiveItems(projectId, ids[start:end], client)
+			for _, id := range list {
+				failed = append(failed, ArchiveFailure{ID: id, Err: err})
+			}
+			if IsAuthError(err) {
+				for _, id := range ids[end:] {
+					failed = append(failed, ArchiveFailure{ID: id, Err: err})
+				}
+				return failed
+			}
+		}
+
+		if len(failed) == 0 || attempt >= retries {
+			return failed
+		}
+
+		time.Sleep(time.Duration(attempt+1) * archiveBackoff)
+		ids = make([]string, 0, len(failed))
+		for _, f := range failed {
+			ids = append(ids, f.ID)
+		}
+	}
+}
+
+// LoadPending reads the pending archive file.  A missing file results in
+// nothing pending.
+func LoadPending(filename string) (PendingArchive, error) {
+	var p PendingArchive
+
+	buf, err := os.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return p, nil
+		}
+		return p, err
+	}
+
+	err = json.Unmarshal(buf, &p)
+	return p, err
+}
+
+// Save writes the pending archive file, or removes it if nothing is pending.
+func (p PendingArchive) Save(filename string) error {
+	if len(p.Items) == 0 {
+		err := os.Remove(filename)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	buf, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, buf, 0644)
+}
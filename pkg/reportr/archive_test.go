@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	gql "github.com/hasura/go-graphql-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchive(t *testing.T) {
+	archiveBackoff = 0
+
+	tests := []struct {
+		description    string
+		ids            []string
+		batch          int
+		retries        int
+		responses      map[string][]string // The results for each item, the last one repeats.
+		expect         []string
+		expectCalls    map[string]int
+		expectRequests int
+	}{
+		{
+			description:    "all archived",
+			ids:            []string{"a", "b"},
+			batch:          20,
+			responses:      map[string][]string{"a": {"ok"}, "b": {"ok"}},
+			expectCalls:    map[string]int{"a": 1, "b": 1},
+			expectRequests: 1,
+		}, {
+			description:    "batches",
+			ids:            []string{"a", "b", "c", "d", "e"},
+			batch:          2,
+			responses:      map[string][]string{"a": {"ok"}, "b": {"ok"}, "c": {"ok"}, "d": {"ok"}, "e": {"ok"}},
+			expectCalls:    map[string]int{"a": 1, "b": 1, "c": 1, "d": 1, "e": 1},
+			expectRequests: 3,
+		}, {
+			description:    "one at a time",
+			ids:            []string{"a", "b"},
+			responses:      map[string][]string{"a": {"ok"}, "b": {"ok"}},
+			expectCalls:    map[string]int{"a": 1, "b": 1},
+			expectRequests: 2,
+		}, {
+			description:    "archived after a retry",
+			ids:            []string{"a", "b"},
+			batch:          20,
+			retries:        2,
+			responses:      map[string][]string{"a": {"error", "ok"}, "b": {"ok"}},
+			expectCalls:    map[string]int{"a": 2, "b": 1},
+			expectRequests: 2,
+		}, {
+			description:    "continues after a failure",
+			ids:            []string{"a", "b", "c"},
+			batch:          1,
+			retries:        2,
+			responses:      map[string][]string{"a": {"ok"}, "b": {"error"}, "c": {"ok"}},
+			expect:         []string{"b"},
+			expectCalls:    map[string]int{"a": 1, "b": 3, "c": 1},
+			expectRequests: 5,
+		}, {
+			description:    "no retries",
+			ids:            []string{"a"},
+			batch:          20,
+			responses:      map[string][]string{"a": {"error", "ok"}},
+			expect:         []string{"a"},
+			expectCalls:    map[string]int{"a": 1},
+			expectRequests: 1,
+		}, {
+			description:    "a refused token stops",
+			ids:            []string{"a", "b", "c"},
+			batch:          2,
+			retries:        2,
+			responses:      map[string][]string{"a": {"ok"}, "b": {"forbidden"}, "c": {"ok"}},
+			expect:         []string{"b", "c"},
+			expectCalls:    map[string]int{"a": 1, "b": 1},
+			expectRequests: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var lock sync.Mutex
+			var requests int
+			calls := map[string]int{}
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					Query     string            `json:"query"`
+					Variables map[string]string `json:"variables"`
+				}
+				_ = json.NewDecoder(r.Body).Decode(&req)
+				r.Body.Close()
+				assert.Contains(req.Query, "archiveProjectV2Item")
+				assert.Equal("project", req.Variables["projectId"])
+
+				lock.Lock()
+				defer lock.Unlock()
+				requests++
+
+				data := map[string]any{}
+				var errs []map[string]string
+				for alias, id := range req.Variables {
+					if alias == "projectId" {
+						continue
+					}
+					list := tc.responses[id]
+					i := calls[id]
+					if i >= len(list) {
+						i = len(list) - 1
+					}
+					calls[id]++
+
+					switch list[i] {
+					case "ok":
+						data[alias] = map[string]any{"clientMutationId": nil}
+					case "forbidden":
+						data[alias] = nil
+						errs = append(errs, map[string]string{"type": "FORBIDDEN", "message": "Resource not accessible"})
+					default:
+						data[alias] = nil
+						errs = append(errs, map[string]string{"type": "INTERNAL", "message": "Something went wrong"})
+					}
+				}
+				_ = json.NewEncoder(w).Encode(map[string]any{"data": data, "errors": errs})
+			}))
+			defer ts.Close()
+
+			var got []string
+			for _, f := range Archive("project", gql.NewClient(ts.URL, nil).WithDebug(true), tc.ids, tc.batch, tc.retries) {
+				assert.Error(f.Err)
+				got = append(got, f.ID)
+			}
+			assert.Equal(tc.expect, got)
+			assert.Equal(tc.expectCalls, calls)
+			assert.Equal(tc.expectRequests, requests)
+		})
+	}
+}
+
+func TestArchiveIDs(t *testing.T) {
+	assert := assert.New(t)
+
+	weeks := []WeeklyItems{
+		{Items: Items{{ID: "a"}, {ID: "b"}}},
+		{},
+		{Items: Items{{ID: "c"}}},
+	}
+	assert.Equal([]string{"a", "b", "c"}, ArchiveIDs(weeks))
+	assert.Nil(ArchiveIDs(nil))
+}
+
+func TestPending(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	file := filepath.Join(t.TempDir(), PendingFilename)
+
+	got, err := LoadPending(file)
+	require.NoError(err)
+	assert.Equal(PendingArchive{}, got)
+
+	p := PendingArchive{ProjectID: "project", Items: []string{"a", "b"}}
+	require.NoError(p.Save(file))
+	got, err = LoadPending(file)
+	require.NoError(err)
+	assert.Equal(p, got)
+
+	// Nothing pending removes the file.
+	require.NoError(PendingArchive{ProjectID: "project"}.Save(file))
+	_, err = os.Stat(file)
+	assert.True(os.IsNotExist(err))
+	require.NoError(PendingArchive{}.Save(file))
+}
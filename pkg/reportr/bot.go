@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"strings"
+	"time"
+)
+
+// botMarker is the hidden marker that identifies the replies of the bot, so
+// the requests are only answered once.
+const botMarker = "<!-- status-reportr -->"
+
+// BotRequest is an issue asking for the current report.
+type BotRequest struct {
+	ID     string
+	Number int
+	URL    string
+}
+
+// botComment is a comment on an issue watched by the bot.
+type botComment struct {
+	Body    string
+	Created time.Time
+}
+
+// pending returns if the issue with the labels and comments asks for a report
+// that was not answered yet.  A request is either the label on the issue, or a
+// comment starting with the command.  A command after the last reply asks for
+// a new report.
+func (b Bot) pending(labels []string, comments []botComment) bool {
+	var asked, replied time.Time
+	for _, c := range comments {
+		body := strings.TrimSpace(c.Body)
+		switch {
+		case strings.Contains(body, botMarker):
+			if c.Created.After(replied) {
+				replied = c.Created
+			}
+		case len(b.Command) > 0 && strings.HasPrefix(body, b.Command):
+			if c.Created.After(asked) {
+				asked = c.Created
+			}
+		}
+	}
+
+	if asked.After(replied) {
+		return true
+	}
+	if len(b.Label) > 0 && replied.IsZero() {
+		for _, label := range labels {
+			if strings.EqualFold(NormalizeText(label), b.Label) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// botReply returns the body of the reply with the report.
+func botReply(report string) string {
+	return strings.TrimRight(report, "\n") + "\n\n" + botMarker + "\n"
+}
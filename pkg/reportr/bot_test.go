@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gql "github.com/hasura/go-graphql-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBotPending(t *testing.T) {
+	comment := func(body, at string) botComment {
+		return botComment{Body: body, Created: mustParseTime(at)}
+	}
+	b := Bot{Command: "/report", Label: "status-report"}
+
+	tests := []struct {
+		description string
+		bot         Bot
+		labels      []string
+		comments    []botComment
+		expect      bool
+	}{
+		{
+			description: "nothing asked",
+			labels:      []string{"bug"},
+			comments:    []botComment{comment("Looks good", "2022-11-14T00:00:00Z")},
+		}, {
+			description: "labeled",
+			labels:      []string{"Status-Report"},
+			expect:      true,
+		}, {
+			description: "labeled and answered",
+			labels:      []string{"status-report"},
+			comments:    []botComment{comment(botReply("report"), "2022-11-14T00:00:00Z")},
+		}, {
+			description: "label ignored",
+			bot:         Bot{Command: "/report"},
+			labels:      []string{"status-report"},
+		}, {
+			description: "command",
+			comments:    []botComment{comment("  /report please", "2022-11-14T00:00:00Z")},
+			expect:      true,
+		}, {
+			description: "command answered",
+			comments: []botComment{
+				comment("/report", "2022-11-14T00:00:00Z"),
+				comment(botReply("report"), "2022-11-14T00:01:00Z"),
+			},
+		}, {
+			description: "command after the answer",
+			labels:      []string{"status-report"},
+			comments: []botComment{
+				comment(botReply("report"), "2022-11-14T00:01:00Z"),
+				comment("/report", "2022-11-21T00:00:00Z"),
+			},
+			expect: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			bot := b
+			if len(tc.bot.Command) > 0 {
+				bot = tc.bot
+			}
+			assert.Equal(t, tc.expect, bot.pending(tc.labels, tc.comments))
+		})
+	}
+}
+
+func TestBotRequests(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var replied string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body.Close()
+
+		if strings.Contains(string(body), "addComment") {
+			replied = string(body)
+			fmt.Fprintln(w, `{"data": {"addComment": {"clientMutationId": ""}}}`)
+			return
+		}
+
+		assert.Contains(string(body), "repository(owner: $owner, name: $name)")
+		fmt.Fprintln(w, `
+{
+  "data": {
+    "repository": {
+      "issues": {
+        "nodes": [
+          {
+            "id": "I_1",
+            "number": 1,
+            "url": "https://github.com/org/reports/issues/1",
+            "labels": { "nodes": [] },
+            "comments": { "nodes": [ { "body": "/report", "createdAt": "2022-11-14T00:00:00Z" } ] }
+          },
+          {
+            "id": "I_2",
+            "number": 2,
+            "url": "https://github.com/org/reports/issues/2",
+            "labels": { "nodes": [ { "name": "bug" } ] },
+            "comments": { "nodes": [] }
+          }
+        ]
+      }
+    }
+  }
+}`)
+	}))
+	defer ts.Close()
+
+	client := gql.NewClient(ts.URL, nil)
+
+	_, err := FetchBotRequests(Bot{Repo: "reports"}, client)
+	assert.Error(err)
+
+	got, err := FetchBotRequests(Bot{Repo: "org/reports", Command: "/report"}, client)
+	require.NoError(err)
+	require.Equal([]BotRequest{
+		{ID: "I_1", Number: 1, URL: "https://github.com/org/reports/issues/1"},
+	}, got)
+
+	require.NoError(ReplyToBotRequest(got[0], "# Report\n", client))
+	assert.Contains(replied, "addComment(input: {subjectId: $id, body: $body})")
+	assert.Contains(replied, `"id":"I_1"`)
+	assert.Contains(replied, `status-reportr --`)
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// burndownDay is the format of the days in the burndown records.
+const burndownDay = "2006-01-02"
+
+// Burndown is the number of planned items remaining each day of an iteration.
+type Burndown struct {
+	ID        string
+	Title     string
+	Start     time.Time
+	End       time.Time
+	Remaining map[string]int // The number of items remaining at the end of each day.
+}
+
+// RecordBurndown records the number of planned items remaining today in the
+// burndown of the current iteration and returns the burndown.  The earlier days
+// of the iteration that were not recorded by a previous run are filled in based
+// on when the planned items were completed.
+func (h *History) RecordBurndown(field string, planned Items, now time.Time) (Burndown, bool) {
+	var iteration Field
+	for _, item := range planned {
+		if f, ok := item.Iteration(field, now); ok {
+			iteration = f
+			break
+		}
+	}
+	if len(iteration.IterationId) == 0 {
+		return Burndown{}, false
+	}
+
+	var b *Burndown
+	for i := range h.Iterations {
+		if h.Iterations[i].ID == iteration.IterationId {
+			b = &h.Iterations[i]
+			break
+		}
+	}
+	if b == nil {
+		h.Iterations = append(h.Iterations, Burndown{ID: iteration.IterationId})
+		b = &h.Iterations[len(h.Iterations)-1]
+	}
+	b.Title = iteration.Title
+	b.Start = iteration.StartDate
+	b.End = iteration.StartDate.Add(iteration.Duration)
+	if b.Remaining == nil {
+		b.Remaining = make(map[string]int)
+	}
+
+	today := now.UTC().Format(burndownDay)
+	for day := b.Start; day.Before(b.End); day = day.AddDate(0, 0, 1) {
+		key := day.Format(burndownDay)
+		if key == today {
+			b.Remaining[key] = len(planned.GetNotDone())
+			break
+		}
+		if _, ok := b.Remaining[key]; ok {
+			continue
+		}
+
+		var remaining int
+		next := day.AddDate(0, 0, 1)
+		for _, item := range planned {
+			if at := item.Done(); at.IsZero() || !at.Before(next) {
+				remaining++
+			}
+		}
+		b.Remaining[key] = remaining
+	}
+
+	return *b, true
+}
+
+// Render renders the burndown as a mermaid chart or as a table.
+func (b Burndown) Render(cfg Config, format string, w io.Writer) {
+	var days []string
+	var counts []string
+	var max int
+	for day := b.Start; day.Before(b.End); day = day.AddDate(0, 0, 1) {
+		n, ok := b.Remaining[day.Format(burndownDay)]
+		if !ok {
+			break
+		}
+		days = append(days, day.Format("01-02"))
+		counts = append(counts, fmt.Sprintf("%d", n))
+		if n > max {
+			max = n
+		}
+	}
+	if len(days) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\n%s %s: %s\n\n", cfg.Markdown.Heading(2), cfg.Locale.T("burndown"), b.Title)
+
+	if format == "table" {
+		fmt.Fprintf(w, "| %s | %s |\n", cfg.Locale.T("day"), cfg.Locale.T("remaining"))
+		fmt.Fprintf(w, "| --- | ---: |\n")
+		for i := range days {
+			fmt.Fprintf(w, "| %s | %s |\n", days[i], counts[i])
+		}
+		return
+	}
+
+	fmt.Fprintf(w, "```mermaid\nxychart-beta\n")
+	fmt.Fprintf(w, "    x-axis [%s]\n", strings.Join(days, ", "))
+	fmt.Fprintf(w, "    y-axis \"%s\" 0 --> %d\n", cfg.Locale.T("remaining"), max)
+	fmt.Fprintf(w, "    line [%s]\n", strings.Join(counts, ", "))
+	fmt.Fprintf(w, "```\n")
+}
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CacheVersion is the current version of the cache file schema.  It must be
+// incremented when the Item structure changes in a way that needs a migration.
+const CacheVersion = 1
+
+var ErrCacheVersion = errors.New("unsupported cache version")
+
+// cacheFile is the structure of the cache file.
+type cacheFile struct {
+	Version int   `json:"version" yaml:"version"`
+	Items   Items `json:"items" yaml:"items"`
+}
+
+// LoadCache reads the items from a cache file.  The format is determined by
+// the file extension: .json, .yml or .yaml, optionally followed by .gz for
+// gzip compression.  Older cache versions are migrated to the current version.
+func LoadCache(filename string) (Items, error) {
+	buf, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	if ext == ".gz" {
+		r, err := gzip.NewReader(bytes.NewReader(buf))
+		if err != nil {
+			return nil, err
+		}
+		buf, err = io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		ext = strings.ToLower(filepath.Ext(strings.TrimSuffix(filename, filepath.Ext(filename))))
+	}
+
+	var cache cacheFile
+	switch ext {
+	case ".yml", ".yaml":
+		err = yaml.Unmarshal(buf, &cache)
+	default:
+		// Version 0 of the cache was a bare json array of items.
+		if trimmed := bytes.TrimSpace(buf); len(trimmed) > 0 && trimmed[0] == '[' {
+			err = json.Unmarshal(buf, &cache.Items)
+		} else {
+			err = json.Unmarshal(buf, &cache)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return migrateCache(cache)
+}
+
+// migrateCache converts older versions of the cache into the current version.
+func migrateCache(cache cacheFile) (Items, error) {
+	if cache.Version > CacheVersion {
+		return nil, fmt.Errorf("%w: %d is newer than %d", ErrCacheVersion, cache.Version, CacheVersion)
+	}
+
+	// Version 0 -> 1: the items were wrapped in a versioned structure, the
+	// items themselves did not change.
+
+	return cache.Items, nil
+}
+
+// SaveCache writes the items to a cache file using the format determined by
+// the file extension.  See LoadCache for the supported formats.
+func SaveCache(filename string, items Items) error {
+	cache := cacheFile{
+		Version: CacheVersion,
+		Items:   items,
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	compress := ext == ".gz"
+	if compress {
+		ext = strings.ToLower(filepath.Ext(strings.TrimSuffix(filename, filepath.Ext(filename))))
+	}
+
+	var buf []byte
+	var err error
+	switch ext {
+	case ".yml", ".yaml":
+		buf, err = yaml.Marshal(cache)
+	default:
+		buf, err = json.MarshalIndent(cache, "", "    ")
+	}
+	if err != nil {
+		return err
+	}
+
+	if compress {
+		var b bytes.Buffer
+		w := gzip.NewWriter(&b)
+		if _, err = w.Write(buf); err != nil {
+			return err
+		}
+		if err = w.Close(); err != nil {
+			return err
+		}
+		buf = b.Bytes()
+	}
+
+	return os.WriteFile(filename, buf, 0644)
+}
+
+// ProgressFilename is the name of the file in the output directory that holds
+// the progress of a failed fetch.
+const ProgressFilename = ".status-reportr-progress.json"
+
+// LoadProgress reads the fetch progress file.  A missing file results in empty
+// progress.
+func LoadProgress(filename string) (FetchProgress, error) {
+	var p FetchProgress
+
+	buf, err := os.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return p, nil
+		}
+		return p, err
+	}
+
+	err = json.Unmarshal(buf, &p)
+	return p, err
+}
+
+// Save writes the fetch progress file.
+func (p FetchProgress) Save(filename string) error {
+	buf, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, buf, 0644)
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheRoundTrip(t *testing.T) {
+	items := Items{itemIssue88, itemIssue89, itemPr23, itemPr24}
+
+	for _, name := range []string{"cache.json", "cache.yml", "cache.yaml", "cache.json.gz", "cache.yml.gz"} {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			filename := filepath.Join(t.TempDir(), name)
+			require.NoError(SaveCache(filename, items))
+
+			got, err := LoadCache(filename)
+			require.NoError(err)
+			assert.Empty(cmp.Diff(items, got, cmpopts.EquateEmpty()))
+		})
+	}
+}
+
+func TestCacheMigration(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir := t.TempDir()
+
+	// Version 0 was a bare array of items.
+	buf, err := json.Marshal(Items{itemPr23})
+	require.NoError(err)
+	filename := filepath.Join(dir, "v0.json")
+	require.NoError(os.WriteFile(filename, buf, 0644))
+
+	got, err := LoadCache(filename)
+	require.NoError(err)
+	assert.Empty(cmp.Diff(Items{itemPr23}, got))
+
+	filename = filepath.Join(dir, "future.json")
+	require.NoError(os.WriteFile(filename, []byte(`{"version": 999, "items": []}`), 0644))
+
+	_, err = LoadCache(filename)
+	assert.True(errors.Is(err, ErrCacheVersion))
+}
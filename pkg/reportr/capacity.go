@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Iteration returns the iteration field with the name if the item is in the
+// iteration that contains the time.
+func (it Item) Iteration(name string, when time.Time) (Field, bool) {
+	field, ok := it.Fields[strings.TrimSpace(name)]
+	if !ok || field.Type != FIELD_ITERATION {
+		return Field{}, false
+	}
+
+	end := field.StartDate.Add(field.Duration)
+	if when.Before(field.StartDate) || !when.Before(end) {
+		return Field{}, false
+	}
+	return field, true
+}
+
+// Planned returns the items, done or not, in the iteration that contains the
+// time.
+func (c Capacity) Planned(list Items, when time.Time) Items {
+	var rv Items
+	for _, item := range list {
+		if _, ok := item.Iteration(c.Field, when); ok {
+			rv = append(rv, item)
+		}
+	}
+	return rv
+}
+
+// Render renders the table comparing the planned items of each section to the
+// items completed.  Sections without planned items are left out.
+func (c Capacity) Render(cfg Config, planned Items, w io.Writer) {
+	if len(planned) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\n%s %s (%s)\n\n", cfg.Markdown.Heading(1), c.Name, iterationTitle(c.Field, planned))
+	fmt.Fprintf(w, "| %s | %s | %s | %s |\n", cfg.Locale.T("section"), cfg.Locale.T("planned"),
+		cfg.Locale.T("completed"), cfg.Locale.T("complete"))
+	fmt.Fprintf(w, "| --- | ---: | ---: | ---: |\n")
+
+	row := func(name string, list Items) {
+		done := len(list.GetDone())
+		fmt.Fprintf(w, "| %s | %d | %d | %d%% |\n", name, len(list), done, done*100/len(list))
+	}
+
+	for _, s := range Classify(cfg, planned) {
+		if len(s.Items) > 0 {
+			row(s.Section.Name, s.Items)
+		}
+	}
+	row(fmt.Sprintf("**%s**", cfg.Locale.T("total")), planned)
+}
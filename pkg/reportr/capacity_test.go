@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapacity(t *testing.T) {
+	assert := assert.New(t)
+
+	sprint := func(status, label string, start time.Time) Item {
+		return Item{
+			Labels: []string{label},
+			DoneAt: start.AddDate(0, 0, 1),
+			Fields: map[string]Field{
+				"Status": {Type: FIELD_TEXT, Text: status},
+				"Iteration": {
+					Type:      FIELD_ITERATION,
+					Title:     "Sprint 5",
+					StartDate: start,
+					Duration:  14 * 24 * time.Hour,
+				},
+			},
+		}
+	}
+
+	now := mustParseTime("2022-08-10T00:00:00Z")
+	current := mustParseTime("2022-08-01T00:00:00Z")
+	list := Items{
+		sprint("Done", "bug", current),
+		sprint("Todo", "bug", current),
+		sprint("Done", "feature", current),
+		sprint("Done", "bug", current.AddDate(0, 0, -14)),
+		itemIssue88,
+	}
+
+	cfg := Config{
+		Capacity: Capacity{Enabled: true, Name: "Capacity", Field: "Iteration"},
+		Sections: []Section{
+			{Name: "Bugs", Match: Match{Labels: []string{"bug"}}},
+			{Name: "Features", Match: Match{Labels: []string{"feature"}}},
+			{Name: "Empty", Match: Match{Labels: []string{"empty"}}},
+		},
+		Unclassified: Unclassified{Name: "Other"},
+	}
+
+	planned := cfg.Capacity.Planned(list, now)
+	assert.Len(planned, 3)
+	assert.Empty(cfg.Capacity.Planned(list, current.AddDate(0, 0, 14)))
+
+	var buf strings.Builder
+	cfg.Capacity.Render(cfg, planned, &buf)
+	got := buf.String()
+	assert.Contains(got, "## Capacity (Sprint 5)")
+	assert.Contains(got, "| Bugs | 2 | 1 | 50% |")
+	assert.Contains(got, "| Features | 1 | 1 | 100% |")
+	assert.Contains(got, "| **Total** | 3 | 2 | 66% |")
+	assert.NotContains(got, "Empty")
+	assert.NotContains(got, "Other")
+
+	buf.Reset()
+	cfg.Capacity.Render(cfg, nil, &buf)
+	assert.Empty(buf.String())
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Tally returns the number of items done in the week, in each section and with
+// each label, as counted in the report.  The cancelled items and excluded
+// drafts are not part of the week's work, so they are only counted in their
+// sections.
+func Tally(cfg Config, week WeeklyItems) (done int, sections, labels map[string]int) {
+	_, list := week.Items.ExtractCancelled()
+	_, list = cfg.Drafts.Extract(list)
+
+	sections = make(map[string]int)
+	for _, s := range Classify(cfg, week.Items) {
+		if len(s.Items) > 0 {
+			sections[s.Section.Name] += len(s.Items)
+		}
+	}
+
+	return len(list), sections, list.GetUniqLabels()
+}
+
+// Render writes the section comparing the week to the previous report.  Only
+// the sections and labels with a different number of items are listed.
+// Nothing is written without a previous report.
+func (c Compare) Render(cfg Config, week WeeklyItems, w io.Writer) {
+	prev := week.Previous
+	if prev == nil {
+		return
+	}
+
+	done, sections, labels := Tally(cfg, week)
+	prevDone := prev.Done
+	if prevDone == 0 {
+		// The records written before the tallies were kept only have the ids.
+		prevDone = len(prev.ItemIDs)
+	}
+
+	fmt.Fprintf(w, "\n%s %s\n\n", cfg.Markdown.Heading(1), c.Name)
+
+	table := func(heading string, now, before map[string]int) {
+		fmt.Fprintf(w, "| %s | %s | %s | %s |\n", heading, cfg.Locale.T("this_week"),
+			cfg.Locale.T("last_week"), cfg.Locale.T("change"))
+		fmt.Fprintf(w, "| --- | ---: | ---: | ---: |\n")
+		if heading == cfg.Locale.T("section") {
+			fmt.Fprintf(w, "| **%s** | %d | %d | %s |\n", cfg.Locale.T("total"), done, prevDone, delta(done, prevDone))
+		}
+		for _, name := range changed(now, before) {
+			fmt.Fprintf(w, "| %s | %d | %d | %s |\n", name, now[name], before[name], delta(now[name], before[name]))
+		}
+	}
+
+	table(cfg.Locale.T("section"), sections, prev.Sections)
+	if names := changed(labels, prev.Labels); len(names) > 0 {
+		fmt.Fprintln(w)
+		table(cfg.Locale.T("label"), labels, prev.Labels)
+	}
+}
+
+// changed returns the sorted names with a different count in the maps.
+func changed(now, before map[string]int) []string {
+	var rv []string
+	for name, n := range now {
+		if before[name] != n {
+			rv = append(rv, name)
+		}
+	}
+	for name := range before {
+		if _, ok := now[name]; !ok {
+			rv = append(rv, name)
+		}
+	}
+	sort.Strings(rv)
+	return rv
+}
+
+// delta returns the signed difference of the counts.
+func delta(now, before int) string {
+	if now > before {
+		return fmt.Sprintf("+%d", now-before)
+	}
+	return fmt.Sprintf("%d", now-before)
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	labeled := func(labels ...string) Item {
+		return Item{Labels: labels}
+	}
+
+	cfg := Config{
+		Compare: Compare{Enabled: true, Name: "Compared to Last Week"},
+		Sections: []Section{
+			{Name: "Bugs", Match: Match{Labels: []string{"bug"}}},
+			{Name: "Features", Match: Match{Labels: []string{"feature"}}},
+		},
+		Unclassified: Unclassified{Name: "Other"},
+	}
+	week := WeeklyItems{
+		Items: Items{labeled("bug"), labeled("bug", "ui"), labeled("feature"), {}},
+	}
+
+	done, sections, labels := Tally(cfg, week)
+	assert.Equal(t, 4, done)
+	assert.Equal(t, map[string]int{"Bugs": 2, "Features": 1, "Other": 1}, sections)
+	assert.Equal(t, map[string]int{"bug": 2, "feature": 1, "ui": 1}, labels)
+
+	tests := []struct {
+		description string
+		previous    *ReportRecord
+		expect      []string
+		unexpected  []string
+	}{
+		{
+			description: "no previous report",
+		}, {
+			description: "changes",
+			previous: &ReportRecord{
+				Done:     3,
+				Sections: map[string]int{"Bugs": 1, "Features": 1, "Docs": 1},
+				Labels:   map[string]int{"bug": 1, "feature": 1, "docs": 1},
+			},
+			expect: []string{
+				"## Compared to Last Week\n\n",
+				"| Section | This Week | Last Week | Change |\n",
+				"| **Total** | 4 | 3 | +1 |\n",
+				"| Bugs | 2 | 1 | +1 |\n",
+				"| Docs | 0 | 1 | -1 |\n",
+				"| Other | 1 | 0 | +1 |\n",
+				"| Label | This Week | Last Week | Change |\n",
+				"| bug | 2 | 1 | +1 |\n",
+				"| docs | 0 | 1 | -1 |\n",
+				"| ui | 1 | 0 | +1 |\n",
+			},
+			unexpected: []string{"| Features |", "| feature |"},
+		}, {
+			description: "older record without tallies",
+			previous: &ReportRecord{
+				ItemIDs:  []string{"a", "b", "c", "d"},
+				Sections: map[string]int{"Bugs": 2, "Features": 1, "Other": 1},
+				Labels:   map[string]int{"bug": 2, "feature": 1, "ui": 1},
+			},
+			expect:     []string{"| **Total** | 4 | 4 | 0 |\n"},
+			unexpected: []string{"| Label |", "| Bugs |"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			w := week
+			w.Previous = tc.previous
+
+			var buf strings.Builder
+			cfg.Compare.Render(cfg, w, &buf)
+			got := buf.String()
+
+			if tc.previous == nil {
+				assert.Empty(got)
+			}
+			for _, s := range tc.expect {
+				assert.Contains(got, s)
+			}
+			for _, s := range tc.unexpected {
+				assert.NotContains(got, s)
+			}
+		})
+	}
+}
@@ -0,0 +1,1061 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config the general program config structure.  See default.yml for usage details.
+type Config struct {
+	Debug           bool           `yaml:"-"`                                             // If debugging information should be output.
+	Url             string         `yaml:"url" validate:"format=url"`                     // The github url to use.
+	Owner           string         `yaml:"owner" validate:"empty=false"`                  // The github org or owner of the project.
+	Token           string         `yaml:"token" validate:"empty=false" secret:"true"`    // The github token to use for access.
+	PlanKey         string         `yaml:"plan_key" secret:"true"`                        // The key signing the plan files, the token if empty.
+	Team            string         `yaml:"team" validate:"empty=false"`                   // The team name.
+	Project         int            `yaml:"project_number"`                                // The github project number to work with.
+	OutputDirectory string         `yaml:"output_directory" validate:"empty=false"`       // Where the reports are placed.
+	AlreadyArchived string         `yaml:"already_archived" validate:"one_of=skip,merge"` // How to handle windows that were already archived.
+	OnItemError     string         `yaml:"on_item_error" validate:"one_of=fail,skip"`     // How to handle items that can not be fetched or converted.
+	OnReadOnly      string         `yaml:"on_read_only" validate:"one_of=fail,skip"`      // How to handle a token that can not archive the items.
+	Formats         []string       `yaml:"formats" validate:"empty=false"`                // The report formats to generate.
+	HeaderTemplate  string         `yaml:"header_template"`                               // The template for the report header.
+	FooterTemplate  string         `yaml:"footer_template"`                               // The template for the report footer.
+	Version         string         `yaml:"-"`                                             // The version of the tool.
+	Generated       time.Time      `yaml:"-"`                                             // When the reports are generated.
+	Details         ProjectDetails `yaml:"-"`                                             // The details fetched from the project.
+	Record          string         `yaml:"-"`                                             // The directory to record the github responses in.
+	Replay          string         `yaml:"-"`                                             // The directory to replay the github responses from.
+	Trace           string         `yaml:"-"`                                             // The file to trace the github requests and responses to.
+	Appendix        string         `yaml:"-"`                                             // The file name of the appendix of the report being rendered.
+
+	Tuning          Tuning           `yaml:"tuning"`
+	ReportWindow    ReportWindow     `yaml:"report_window"`
+	LabelSection    LabelSection     `yaml:"label_section"`
+	RepoSection     RepoSection      `yaml:"repo_section"`
+	Contributors    Contributors     `yaml:"contributor_section"`
+	Unclassified    Unclassified     `yaml:"unclassified"`
+	Cancelled       Cancelled        `yaml:"cancelled"`
+	Abandoned       Abandoned        `yaml:"abandoned"`
+	Drafts          Drafts           `yaml:"drafts"`
+	Summary         Summary          `yaml:"summary"`
+	Blocked         Blocked          `yaml:"blocked"`
+	Dependencies    Dependencies     `yaml:"dependency_section"`
+	Points          Points           `yaml:"points"`
+	Capacity        Capacity         `yaml:"capacity"`
+	Compare         Compare          `yaml:"compare"`
+	Reviews         Reviews          `yaml:"reviews"`
+	Metrics         Metrics          `yaml:"metrics"`
+	Reopened        Reopened         `yaml:"reopened"`
+	Highlights      Highlights       `yaml:"highlights"`
+	Bot             Bot              `yaml:"bot"`
+	ReleaseNotes    ReleaseNotes     `yaml:"release_notes"`
+	Snapshot        Snapshot         `yaml:"snapshot"`
+	StatusUpdates   StatusUpdates    `yaml:"status_updates"`
+	ProjectDetails  ProjectHeader    `yaml:"project_details"`
+	Redact          Redact           `yaml:"redact"`
+	Hide            Hide             `yaml:"hide"`
+	Priority        Priority         `yaml:"priority"`
+	Index           Index            `yaml:"index"`
+	Rollup          Rollup           `yaml:"rollup"`
+	Fiscal          Fiscal           `yaml:"fiscal"`
+	Rolling         Rolling          `yaml:"rolling"`
+	NewItems        NewItems         `yaml:"new_items"`
+	Hooks           Hooks            `yaml:"hooks"`
+	Metadata        Metadata         `yaml:"metadata"`
+	MultiMatch      MultiMatch       `yaml:"multi_match"`
+	Matching        Matching         `yaml:"matching"`
+	Overdue         Overdue          `yaml:"overdue"`
+	NewContributors NewContributors  `yaml:"new_contributors"`
+	Deliver         []Delivery       `yaml:"deliver"`  // Where to deliver the reports.
+	Projects        []ProjectSource  `yaml:"projects"` // The projects reported on in multi-project mode.
+	Locale          Locale           `yaml:"locale"`
+	Markdown        Markdown         `yaml:"markdown"`
+	MatchPresets    map[string]Match `yaml:"match_presets"`    // Named match criteria the sections can include.
+	SectionDefaults SectionDefaults  `yaml:"section_defaults"` // The settings inherited by the sections.
+	Sections        []Section        `yaml:"sections"`         // User defined sections.
+}
+
+var ErrUnknownPreset = errors.New("unknown match preset")
+
+// ApplyPresets merges the criteria of the named match presets into the
+// sections, blocked and dependency matches that include them.
+func (c *Config) ApplyPresets() error {
+	var err error
+	if c.Blocked.Match, err = c.withPresets(c.Blocked.Match); err != nil {
+		return fmt.Errorf("blocked: %w", err)
+	}
+	if c.Dependencies.Match, err = c.withPresets(c.Dependencies.Match); err != nil {
+		return fmt.Errorf("dependency_section: %w", err)
+	}
+	for i := range c.Sections {
+		if c.Sections[i].Match, err = c.withPresets(c.Sections[i].Match); err != nil {
+			return fmt.Errorf("section '%s': %w", c.Sections[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// withPresets returns the match with the criteria of its presets added.
+func (c Config) withPresets(m Match) (Match, error) {
+	for _, name := range m.Presets {
+		p, ok := c.MatchPresets[name]
+		if !ok {
+			return m, fmt.Errorf("%w: '%s'", ErrUnknownPreset, name)
+		}
+		if len(p.Presets) > 0 {
+			return m, fmt.Errorf("the match preset '%s' can not include other presets", name)
+		}
+		m.Labels = append(m.Labels, p.Labels...)
+		m.Prefixes = append(m.Prefixes, p.Prefixes...)
+		m.Authors = append(m.Authors, p.Authors...)
+		m.Branches = append(m.Branches, p.Branches...)
+		m.Fields = append(m.Fields, p.Fields...)
+		m.CaseInsensitive = m.CaseInsensitive || p.CaseInsensitive
+	}
+	m.Presets = nil
+	return m, nil
+}
+
+// The query tuning parameters.
+type Tuning struct {
+	IssueCount      int `yaml:"issue_count"`       // The number of issues to fetch in a single query.
+	LabelCount      int `yaml:"label_count"`       // The number of labels to fetch in a single query.
+	FieldValueCount int `yaml:"field_value_count"` // The number of field values to fetch in a single query.
+	Workers         int `yaml:"workers"`           // The number of pages of items to fetch concurrently.
+	ArchiveBatch    int `yaml:"archive_batch"`     // The number of items to archive in a single request.
+	ArchiveRetries  int `yaml:"archive_retries"`   // The number of times to retry archiving an item.
+	HTTPTimeout     int `yaml:"http_timeout"`      // The seconds a delivery request may take.
+}
+
+// The report start and stop times to use.
+type ReportWindow struct {
+	// The number of days per report.  If the value is a multiple of 7, then the
+	// StartOnWeekday value is honored if not empty.  Otherwise it is ignored.
+	Days int `yaml:"days"`
+
+	// The starting day of the report if not empty string and Days is a multiple
+	// of 7.
+	StartOnWeekday string `yaml:"start_on_weekday"`
+
+	// ISOWeeks uses ISO-8601 weeks (starting on Monday) for the reports and
+	// names the report files by the ISO week number.
+	ISOWeeks bool `yaml:"iso_weeks"`
+
+	// BusinessDays is the number of business days per report if not 0.  The
+	// weekends and holidays are also left out of the durations.
+	BusinessDays int `yaml:"business_days"`
+
+	// Holidays are the dates (YYYY-MM-DD) that are not business days.
+	Holidays []string `yaml:"holidays"`
+}
+
+// FirstWeekday returns the weekday that the reports start on.
+func (r ReportWindow) FirstWeekday() time.Weekday {
+	if r.ISOWeeks {
+		return time.Monday
+	}
+	return time.Sunday
+}
+
+// Fiscal defines the fiscal calendar used to label the reports.
+type Fiscal struct {
+	// If the reports are named by their fiscal quarter and week.
+	Enabled bool `yaml:"enabled"`
+
+	// The month the fiscal year starts in, 1 to 12.
+	StartMonth int `yaml:"start_month" validate:"gte=1 & lte=12"`
+
+	// The template of the label, using the FiscalPeriod values.
+	LabelTemplate string `yaml:"label"`
+}
+
+// The label section configuration.
+type LabelSection struct {
+	Enabled     bool `yaml:"enabled"`      // Include the label section if enabled.
+	RenderOrder int  `yaml:"render_order"` // The order to render the section relative to the others.
+}
+
+// The repository section configuration.
+type RepoSection struct {
+	Enabled     bool `yaml:"enabled"`      // Include the repository section if enabled.
+	RenderOrder int  `yaml:"render_order"` // The order to render the section relative to the others.
+}
+
+// The contributor section configuration.
+type Contributors struct {
+	Enabled     bool `yaml:"enabled"`       // Include the contributor section if enabled.
+	RenderOrder int  `yaml:"render_order"`  // The order to render the section relative to the others.
+	ExcludeBots bool `yaml:"exclude_bots"`  // If bot authors should be left out.
+	SortByCount bool `yaml:"sort_by_count"` // Sort by the number of items instead of by name.
+}
+
+// How to handle unclassified items that were missed.
+type Unclassified struct {
+	Name        string `yaml:"name"`          // The name to use for the section.
+	RenderOrder int    `yaml:"render_order"`  // The order to render the section relative to the others.
+	OmitIfEmpty bool   `yaml:"omit_if_empty"` // If the section should be present if it is empty.
+}
+
+// Cancelled defines how the items with a status meaning they will not be done,
+// like "Won't Do", are handled.  They are normally left on the board.
+type Cancelled struct {
+	Mode        string   `yaml:"mode" validate:"one_of=ignore,exclude,section"` // How to handle the cancelled items.
+	Statuses    []string `yaml:"statuses"`                                      // The status globs that mean cancelled.
+	Name        string   `yaml:"name"`                                          // The name to use for the section.
+	RenderOrder int      `yaml:"render_order"`                                  // The order to render the section relative to the others.
+	OmitIfEmpty bool     `yaml:"omit_if_empty"`                                 // If the section should be present if it is empty.
+}
+
+// Mark returns a copy of the list with the items that have a cancelled status
+// marked as cancelled.  The statuses are compared ignoring case.  Nothing is
+// marked if cancelled items are ignored.
+func (c Cancelled) Mark(list Items) Items {
+	if c.Mode == "" || c.Mode == "ignore" {
+		return list
+	}
+
+	rv := make(Items, 0, len(list))
+	for _, item := range list {
+		if status, ok := item.Fields["Status"]; ok && status.Type == FIELD_TEXT {
+			text := strings.ToLower(strings.TrimSpace(status.Text))
+			for _, g := range c.Statuses {
+				if globMatch(strings.ToLower(strings.TrimSpace(g)), text) {
+					item.Cancelled = true
+					break
+				}
+			}
+		}
+		rv = append(rv, item)
+	}
+	return rv
+}
+
+// section returns the section the cancelled items are rendered in.
+func (c Cancelled) section() Section {
+	return Section{
+		Name:        c.Name,
+		RenderOrder: c.RenderOrder,
+		OmitIfEmpty: c.OmitIfEmpty,
+	}
+}
+
+// Abandoned defines how the pull requests closed without being merged are
+// handled.  They are normally reported like the merged pull requests.
+type Abandoned struct {
+	Mode        string `yaml:"mode" validate:"one_of=done,not_done,section"` // How to handle the abandoned pull requests.
+	Name        string `yaml:"name"`                                         // The name to use for the section.
+	RenderOrder int    `yaml:"render_order"`                                 // The order to render the section relative to the others.
+	OmitIfEmpty bool   `yaml:"omit_if_empty"`                                // If the section should be present if it is empty.
+}
+
+// Split returns the items that are done, and the items that are not.  The
+// abandoned pull requests are not done if configured that way.
+func (a Abandoned) Split(list Items) (done, open Items) {
+	done, open = list.GetDone(), list.GetNotDone()
+	if a.Mode != "not_done" {
+		return done, open
+	}
+
+	abandoned, done := done.ExtractAbandoned()
+	return done, append(open, abandoned...)
+}
+
+// section returns the section the abandoned pull requests are rendered in.
+func (a Abandoned) section() Section {
+	return Section{
+		Name:        a.Name,
+		RenderOrder: a.RenderOrder,
+		OmitIfEmpty: a.OmitIfEmpty,
+	}
+}
+
+// Drafts defines how the draft pull requests are handled.  They are normally
+// reported like any other pull request.
+type Drafts struct {
+	Mode        string `yaml:"mode" validate:"one_of=include,exclude,section"` // How to handle the draft pull requests.
+	Name        string `yaml:"name"`                                           // The name to use for the section.
+	RenderOrder int    `yaml:"render_order"`                                   // The order to render the section relative to the others.
+	OmitIfEmpty bool   `yaml:"omit_if_empty"`                                  // If the section should be present if it is empty.
+}
+
+// Extract returns the draft pull requests, and the items that are left over.
+// Nothing is extracted if the drafts are included like any other item.
+func (d Drafts) Extract(list Items) (drafts, left Items) {
+	if d.Mode != "exclude" && d.Mode != "section" {
+		return nil, list
+	}
+	return list.ExtractDrafts()
+}
+
+// section returns the section the draft pull requests are rendered in.
+func (d Drafts) section() Section {
+	return Section{
+		Name:        d.Name,
+		RenderOrder: d.RenderOrder,
+		OmitIfEmpty: d.OmitIfEmpty,
+	}
+}
+
+// Summary defines the optional free form summary section.
+type Summary struct {
+	Enabled     bool   `yaml:"enabled"`      // Include the summary section if enabled.
+	Name        string `yaml:"name"`         // The name to use for the section.
+	RenderOrder int    `yaml:"render_order"` // The order to render the section relative to the others.
+	Body        string `yaml:"body"`         // The body to populate
+}
+
+// Index defines the optional index of all the reports in the output directory.
+type Index struct {
+	Enabled  bool   `yaml:"enabled"`  // Generate the index if enabled.
+	Filename string `yaml:"filename"` // The name of the index file.
+}
+
+// Rollup defines the combined report of all the projects when every project
+// of the org is reported on.
+type Rollup struct {
+	Filename string `yaml:"filename"` // The name of the rollup file.
+}
+
+// ProjectSource defines the projects of an owner reported on in multi-project
+// mode and the credentials to use for them.  The empty values are inherited
+// from the top level configuration, so a single run can cover projects on
+// github.com and a github enterprise server.
+type ProjectSource struct {
+	Url     string `yaml:"url"`                 // The github url to use.
+	Owner   string `yaml:"owner"`               // The github org or owner of the projects.
+	Token   string `yaml:"token" secret:"true"` // The github token to use for access.
+	Project int    `yaml:"project_number"`      // The project number, or 0 for every open project.
+}
+
+// Apply returns the configuration with the values of the source in place of
+// the top level values.
+func (s ProjectSource) Apply(cfg Config) Config {
+	if len(s.Url) > 0 {
+		cfg.Url = s.Url
+	}
+	if len(s.Owner) > 0 {
+		cfg.Owner = s.Owner
+	}
+	if len(s.Token) > 0 {
+		cfg.Token = s.Token
+	}
+	return cfg
+}
+
+// Rolling defines the optional single cumulative report file mode.
+type Rolling struct {
+	Enabled  bool   `yaml:"enabled"`  // Write all reports to a single file if enabled.
+	Filename string `yaml:"filename"` // The name of the rolling report file.
+}
+
+// NewItems defines how items that are new since the report was previously
+// generated are shown.
+type NewItems struct {
+	Enabled     bool   `yaml:"enabled"`      // Show the new items if enabled.
+	Separate    bool   `yaml:"separate"`     // Place the new items in their own section instead of marking them.
+	Marker      string `yaml:"marker"`       // The marker to append to new items.
+	Name        string `yaml:"name"`         // The name to use for the separate section.
+	RenderOrder int    `yaml:"render_order"` // The order to render the separate section relative to the others.
+}
+
+// Hooks defines the shell commands to run at points during the run.  The run
+// summary is passed to the commands via environment variables.
+type Hooks struct {
+	PreFetch    string `yaml:"pre_fetch"`    // Run before the items are fetched.
+	PostRender  string `yaml:"post_render"`  // Run after the reports are written.
+	PostArchive string `yaml:"post_archive"` // Run after the items are archived.
+}
+
+// Matching defines how the globs of the match criteria are interpreted.
+type Matching struct {
+	LegacyGlobs     bool `yaml:"legacy_globs"`     // Only support '*' in the globs, like before.
+	CaseInsensitive bool `yaml:"case_insensitive"` // Ignore the case in all the matching.
+}
+
+// MultiMatch defines if the items are listed in every section they match
+// instead of only the first one.
+type MultiMatch struct {
+	Enabled bool `yaml:"enabled"` // List the items in every matching section if enabled.
+	Note    bool `yaml:"note"`    // Note the other sections the items are listed in.
+}
+
+// matchSections returns the items of each of the user defined sections and the
+// items that are left over.  Items are only in the first section they match
+// unless multi_match is enabled.
+func (c Config) matchSections(list Items) ([]Items, Items) {
+	rv := make([]Items, len(c.Sections))
+
+	if !c.MultiMatch.Enabled {
+		left := list
+		for i, section := range c.Sections {
+			rv[i], left = section.Extract(left)
+		}
+		return rv, left
+	}
+
+	matched := make(map[string][]string)
+	for i, section := range c.Sections {
+		rv[i], _ = section.Extract(list)
+		for _, item := range rv[i] {
+			matched[item.ID] = append(matched[item.ID], section.Name)
+		}
+	}
+
+	var left Items
+	for _, item := range list {
+		if _, ok := matched[item.ID]; !ok {
+			left = append(left, item)
+		}
+	}
+
+	if c.MultiMatch.Note {
+		for i, section := range c.Sections {
+			mine := make(Items, 0, len(rv[i]))
+			for _, item := range rv[i] {
+				for _, name := range matched[item.ID] {
+					if name != section.Name {
+						item.AlsoIn = append(item.AlsoIn, name)
+					}
+				}
+				mine = append(mine, item)
+			}
+			rv[i] = mine
+		}
+	}
+
+	return rv, left
+}
+
+// NewContributors defines the callout of the authors whose first pull request
+// was merged in the report window.
+type NewContributors struct {
+	Enabled     bool   `yaml:"enabled"`      // Include the callout if enabled.
+	Name        string `yaml:"name"`         // The name to use for the callout.
+	RenderOrder int    `yaml:"render_order"` // The order to render the callout relative to the sections.
+	Marker      string `yaml:"marker"`       // The marker to append to the items of new contributors.
+}
+
+// Overdue defines how the items completed after their goal date are flagged.
+type Overdue struct {
+	Enabled bool   `yaml:"enabled"` // Flag the overdue items if enabled.
+	Field   string `yaml:"field"`   // The name of the date project field with the goal date.
+	Marker  string `yaml:"marker"`  // The marker to append to overdue items.
+}
+
+// Count returns the number of overdue items in the list, or 0 if not enabled.
+func (o Overdue) Count(list Items) int {
+	if !o.Enabled {
+		return 0
+	}
+
+	var count int
+	for _, item := range list {
+		if item.IsLate(o.Field) {
+			count++
+		}
+	}
+	return count
+}
+
+// Metadata defines the generation metadata footer added to the reports.
+type Metadata struct {
+	Enabled bool `yaml:"enabled"` // Add the metadata footer if enabled.
+}
+
+// Points defines the numeric project field that is summed per section and per
+// report.
+type Points struct {
+	Enabled bool   `yaml:"enabled"` // Include the point totals if enabled.
+	Field   string `yaml:"field"`   // The name of the numeric project field to sum.
+	Unit    string `yaml:"unit"`    // The unit to show after the total.
+}
+
+// Compare defines the section comparing the number of items of the week to the
+// previous report.
+type Compare struct {
+	Enabled     bool   `yaml:"enabled"`      // Include the comparison section if enabled.
+	Name        string `yaml:"name"`         // The name to use for the section.
+	RenderOrder int    `yaml:"render_order"` // The order to render the section relative to the others.
+}
+
+// Reviews defines the section with the review activity of the merged pull
+// requests.
+type Reviews struct {
+	Enabled     bool   `yaml:"enabled"`      // Include the review activity section if enabled.
+	Name        string `yaml:"name"`         // The name to use for the section.
+	RenderOrder int    `yaml:"render_order"` // The order to render the section relative to the others.
+}
+
+// Metrics defines the section with the engineering metrics of the week, like
+// the time to merge the pull requests.
+type Metrics struct {
+	Enabled     bool   `yaml:"enabled"`      // Include the metrics section if enabled.
+	Name        string `yaml:"name"`         // The name to use for the section.
+	RenderOrder int    `yaml:"render_order"` // The order to render the section relative to the others.
+}
+
+// Capacity defines the section that compares the items planned for the current
+// iteration to the items completed.
+type Capacity struct {
+	Enabled     bool   `yaml:"enabled"`      // Include the capacity section if enabled.
+	Name        string `yaml:"name"`         // The name to use for the section.
+	RenderOrder int    `yaml:"render_order"` // The order to render the section relative to the others.
+	Field       string `yaml:"field"`        // The name of the iteration project field.
+
+	Burndown CapacityBurndown `yaml:"burndown"`
+}
+
+// CapacityBurndown defines the burndown of the current iteration rendered in
+// the capacity section.
+type CapacityBurndown struct {
+	Enabled bool   `yaml:"enabled"`                                // Include the burndown if enabled.
+	Format  string `yaml:"format" validate:"one_of=mermaid,table"` // How to render the burndown.
+}
+
+// Snapshot defines the summary of the whole board rendered by the snapshot
+// command.
+type Snapshot struct {
+	Columns   []string `yaml:"columns"`    // The Status columns to list first, in order.
+	ListItems bool     `yaml:"list_items"` // If the items of each column should be listed after the counts.
+}
+
+// StatusUpdates defines how the status updates posted on the project are
+// included in the reports.
+type StatusUpdates struct {
+	Enabled   bool   `yaml:"enabled"`                                    // Include the status updates if enabled.
+	Count     int    `yaml:"count"`                                      // The number of updates to include.
+	Placement string `yaml:"placement" validate:"one_of=header,summary"` // Where to include the updates.
+}
+
+// ProjectHeader defines which of the project details fetched from github are
+// included in the report header.
+type ProjectHeader struct {
+	Title       bool `yaml:"title"`       // Include the project title after the team name.
+	Description bool `yaml:"description"` // Include the project short description.
+	Readme      bool `yaml:"readme"`      // Include the project readme.
+}
+
+// Enabled returns if any of the project details are included.
+func (p ProjectHeader) Enabled() bool {
+	return p.Title || p.Description || p.Readme
+}
+
+// Priority defines how the items are badged based on the value of a numeric
+// priority field, where lower values are more important, like P0.
+type Priority struct {
+	Enabled     bool    `yaml:"enabled"`      // Badge the items by priority if enabled.
+	Field       string  `yaml:"field"`        // The name of the numeric project field with the priority.
+	High        float64 `yaml:"high"`         // Items with a priority of this or less are high priority.
+	BoldHigh    bool    `yaml:"bold_high"`    // If the titles of the high priority items should be bold.
+	HighMarker  string  `yaml:"high_marker"`  // The marker to append to the high priority items.
+	Low         float64 `yaml:"low"`          // Items with a priority of this or more are low priority.
+	CollapseLow bool    `yaml:"collapse_low"` // If the low priority items should be collapsed after the others.
+}
+
+// IsHigh returns if the item is high priority.
+func (p Priority) IsHigh(item Item) bool {
+	n, ok := item.FieldNumber(p.Field)
+	return p.Enabled && ok && n <= p.High
+}
+
+// ExtractLow returns the items that are low priority and should be collapsed,
+// and the items that are left over.
+func (p Priority) ExtractLow(list Items) (low, left Items) {
+	if !p.Enabled || !p.CollapseLow {
+		return nil, list
+	}
+
+	for _, item := range list {
+		if n, ok := item.FieldNumber(p.Field); ok && n >= p.Low {
+			low = append(low, item)
+		} else {
+			left = append(left, item)
+		}
+	}
+	return low, left
+}
+
+// Summarize returns the item count, and the point total if enabled, in a
+// form suitable for a heading.  The words come from the locale strings.
+func (c Config) Summarize(list Items) string {
+	if !c.Points.Enabled {
+		return fmt.Sprintf("%d", len(list))
+	}
+
+	return fmt.Sprintf("%d %s, %s %s", len(list), c.Locale.T("items"),
+		strconv.FormatFloat(list.SumField(c.Points.Field), 'f', -1, 64), c.Points.Unit)
+}
+
+// Bot defines the issues the bot command answers with the current report.
+type Bot struct {
+	Repo    string `yaml:"repo"`    // The owner/name of the repository with the issues.
+	Command string `yaml:"command"` // The comment that asks for the report.
+	Label   string `yaml:"label"`   // The label that asks for the report.
+}
+
+// Reopened captures the configuration for the section that calls out items
+// that were reported as done, but are not done any more.
+type Reopened struct {
+	Enabled     bool   `yaml:"enabled"`       // Include the reopened section if enabled.
+	Name        string `yaml:"name"`          // The name to use for the section.
+	RenderOrder int    `yaml:"render_order"`  // The order to render the section relative to the others.
+	OmitIfEmpty bool   `yaml:"omit_if_empty"` // If the section should be present if it is empty.
+}
+
+// Highlights captures the configuration for the section with the top items of
+// the week, picked by a score.
+type Highlights struct {
+	Enabled     bool             `yaml:"enabled"`                // Include the highlights section if enabled.
+	Name        string           `yaml:"name"`                   // The name to use for the section.
+	RenderOrder int              `yaml:"render_order"`           // The order to render the section relative to the others.
+	OmitIfEmpty bool             `yaml:"omit_if_empty"`          // If the section should be present if it is empty.
+	Count       int              `yaml:"count" validate:"gte=1"` // The number of items to highlight.
+	PinLabel    string           `yaml:"pin_label"`              // The label of the items always highlighted first.
+	Weights     HighlightWeights `yaml:"weights"`
+}
+
+// HighlightWeights are the weights of the parts of the highlight score.
+type HighlightWeights struct {
+	Priority float64            `yaml:"priority"` // Divided by 1 + the priority field value.
+	Points   float64            `yaml:"points"`   // Multiplied by the points field value.
+	Labels   map[string]float64 `yaml:"labels"`   // Added for each matching label glob.
+}
+
+// Blocked captures the configuration for the section that calls out items that
+// are not done but are blocked.
+type Blocked struct {
+	Enabled     bool   `yaml:"enabled"`       // Include the blocked section if enabled.
+	Name        string `yaml:"name"`          // The name to use for the section.
+	RenderOrder int    `yaml:"render_order"`  // The order to render the section relative to the others.
+	OmitIfEmpty bool   `yaml:"omit_if_empty"` // If the section should be present if it is empty.
+
+	Match Match `yaml:"match_on"`
+}
+
+// Dependencies captures the configuration for the section that groups the
+// automated dependency update items together.
+type Dependencies struct {
+	Enabled     bool   `yaml:"enabled"`       // Include the dependency section if enabled.
+	Name        string `yaml:"name"`          // The name to use for the section.
+	RenderOrder int    `yaml:"render_order"`  // The order to render the section relative to the others.
+	OmitIfEmpty bool   `yaml:"omit_if_empty"` // If the section should be present if it is empty.
+	ListItems   bool   `yaml:"list_items"`    // If the items should be listed after the summary.
+	Collapsible bool   `yaml:"collapsible"`   // If the listed items should be collapsed by default.
+
+	Match Match `yaml:"match_on"`
+}
+
+// ExtractAndRender extracts the dependency update items and renders a summary
+// of them by repository to a writer.  The unconsumed items are returned.
+func (d Dependencies) ExtractAndRender(cfg Config, list Items, w io.Writer) Items {
+	s := Section{
+		Name:        d.Name,
+		RenderOrder: d.RenderOrder,
+		OmitIfEmpty: d.OmitIfEmpty,
+		Collapsible: d.Collapsible,
+		Match:       d.Match,
+	}
+
+	mine, left := s.Extract(list)
+	if d.OmitIfEmpty && len(mine) == 0 {
+		return left
+	}
+
+	repos := mine.GetUniqRepos()
+	urls := mine.GetRepoURLs()
+	keys := make([]string, 0, len(repos))
+	for key := range repos {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	l := newLinks(cfg)
+	defer l.write(w)
+
+	fmt.Fprintf(w, "\n%s %s (%s)\n\n", cfg.Markdown.Heading(1), d.Name, cfg.Summarize(mine))
+	fmt.Fprintf(w, cfg.Locale.T("dependency_summary")+"\n\n", len(mine), len(repos))
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s %s (%d)\n", cfg.Markdown.ListMarker(), l.link(key, urls[key]), repos[key])
+	}
+
+	if d.ListItems && len(mine) > 0 {
+		fmt.Fprintln(w)
+		if d.Collapsible {
+			fmt.Fprintf(w, "<details><summary>%d %s</summary>\n\n", len(mine), cfg.Locale.T("items"))
+		}
+		s.renderItems(cfg, mine, w, l)
+		if d.Collapsible {
+			fmt.Fprintf(w, "\n</details>\n")
+		}
+	}
+
+	return left
+}
+
+// Section captures the user configurable section information.
+type Section struct {
+	Name        string `yaml:"name"`          // The name to use for the section.
+	RenderOrder int    `yaml:"render_order"`  // The order to render the section relative to the others.
+	OmitIfEmpty bool   `yaml:"omit_if_empty"` // If the section should be present if it is empty.
+	Collapsible bool   `yaml:"collapsible"`   // If the section items should be collapsed by default.
+	Nest        bool   `yaml:"nest"`          // If sub-issues should be nested under their parent.
+	GroupBy     string `yaml:"group_by"`      // The project field to group the items by.
+	MaxItems    int    `yaml:"max_items"`     // The most items to list, 0 for no limit.
+	Icon        string `yaml:"icon"`          // The emoji prefixed to the section name.
+
+	LabelIcons   LabelIcons   `yaml:"label_icons"`
+	Excerpt      Excerpt      `yaml:"excerpt"`
+	InlineLabels InlineLabels `yaml:"inline_labels"`
+	Match        Match        `yaml:"match_on"`
+}
+
+// LabelIcons maps label globs to the emoji prefixed to the items with a
+// matching label, like "bug" to a bug emoji.
+type LabelIcons map[string]string
+
+// For returns the emoji of the labels of the item followed by a space, or the
+// empty string if none of the labels have one.  The emoji are in the order of
+// the globs and each is only used once.
+func (li LabelIcons) For(item Item) string {
+	globs := make([]string, 0, len(li))
+	for glob := range li {
+		globs = append(globs, glob)
+	}
+	sort.Strings(globs)
+
+	var icons []string
+	for _, glob := range globs {
+		icon := li[glob]
+		if len(icon) > 0 && item.HasLabel(glob) && !contains(icons, icon) {
+			icons = append(icons, icon)
+		}
+	}
+	if len(icons) == 0 {
+		return ""
+	}
+	return strings.Join(icons, "") + " "
+}
+
+// InlineLabels defines which labels of an item are rendered on the same line as
+// the item.
+type InlineLabels struct {
+	Enabled bool     `yaml:"enabled"` // Include the labels if enabled.
+	Allow   []string `yaml:"allow"`   // A list of label globs to include, empty includes all.
+}
+
+// SectionDefaults are the section settings inherited by every section that
+// does not set them.
+type SectionDefaults struct {
+	OmitIfEmpty  bool         `yaml:"omit_if_empty"`
+	Collapsible  bool         `yaml:"collapsible"`
+	Nest         bool         `yaml:"nest"`
+	GroupBy      string       `yaml:"group_by"`
+	MaxItems     int          `yaml:"max_items"`
+	LabelIcons   LabelIcons   `yaml:"label_icons"`
+	Excerpt      Excerpt      `yaml:"excerpt"`
+	InlineLabels InlineLabels `yaml:"inline_labels"`
+}
+
+// ApplySectionDefaults sets the section_defaults values of each section that
+// does not set them.  The set list holds the configuration of each section as
+// written, in the same order as the sections, so values explicitly set to
+// false or empty are kept.
+func (c *Config) ApplySectionDefaults(set []map[string]any) {
+	for i := range c.Sections {
+		var keys map[string]any
+		if i < len(set) {
+			keys = set[i]
+		}
+		inherit(reflect.ValueOf(&c.Sections[i]).Elem(), reflect.ValueOf(c.SectionDefaults), keys)
+	}
+}
+
+// inherit copies the fields of from into the fields of to with the same yaml
+// key unless the key is present in set.  Nested structures are inherited field
+// by field.
+func inherit(to, from reflect.Value, set map[string]any) {
+	for i := 0; i < from.NumField(); i++ {
+		key := yamlKey(from.Type().Field(i))
+
+		var field reflect.Value
+		for j := 0; j < to.NumField(); j++ {
+			if yamlKey(to.Type().Field(j)) == key {
+				field = to.Field(j)
+				break
+			}
+		}
+		if !field.IsValid() {
+			continue
+		}
+
+		val, present := set[key]
+		if from.Field(i).Kind() == reflect.Struct {
+			nested, _ := val.(map[string]any)
+			inherit(field, from.Field(i), nested)
+			continue
+		}
+		if !present {
+			field.Set(from.Field(i))
+		}
+	}
+}
+
+// Excerpt defines how much of the item body to render under each item.
+type Excerpt struct {
+	Enabled       bool `yaml:"enabled"`        // Include the body excerpt if enabled.
+	FirstSentence bool `yaml:"first_sentence"` // Only include the first sentence of the body.
+	Length        int  `yaml:"length"`         // The maximum number of characters to include, 0 is unlimited.
+}
+
+// Match defines the matching conditions to use for including an item in a section.
+// Matching conditions are a logical OR, so any match includes the item.
+type Match struct {
+	Labels   []string `yaml:"labels"`   // A list of labels to match against.
+	Prefixes []string `yaml:"prefixes"` // A list of prefixes to match against the commit message.
+	Authors  []string `yaml:"authors"`  // A list of authors to match against.
+	Presets  []string `yaml:"presets"`  // The names of the match presets to include.
+
+	CaseInsensitive bool `yaml:"case_insensitive"` // Ignore the case when matching.
+
+	Branches []Branch     `yaml:"branches"`
+	Fields   []FieldMatch `yaml:"fields"`
+}
+
+// FieldMatch defines a project field name and the text value to match against.
+type FieldMatch struct {
+	Name  string `yaml:"name"`  // The name of the project field.
+	Value string `yaml:"value"` // The value of the field to match.
+}
+
+// Branch defines the org/repo and branch to match against.  This allows for easy
+// inclusion of specific areas of repos into sections.
+type Branch struct {
+	Org    string `yaml:"org"`    // The github org/owner value to match.
+	Repo   string `yaml:"repo"`   // The github repo value to match.
+	Branch string `yaml:"branch"` // The git branch to match.
+}
+
+// ExtractAndRender extracts the items that match and renders them to a writer.
+// The unconsumed items are returned.
+func (s Section) ExtractAndRender(cfg Config, list Items, w io.Writer) Items {
+	mine, left := s.Extract(list)
+	s.Render(cfg, mine, w)
+
+	return left
+}
+
+// Extract extracts the items that match and returns the list of matching items
+// and non-maching items.
+func (s Section) Extract(list Items) (mine, left Items) {
+	var tmp Items
+
+	left = list
+	fold := s.Match.CaseInsensitive
+	tmp, left = left.ExtractByLabels(fold, s.Match.Labels...)
+	mine = tmp
+
+	tmp, left = left.ExtractByPrefixes(fold, s.Match.Prefixes...)
+	mine = append(mine, tmp...)
+
+	tmp, left = left.ExtractByAuthors(fold, s.Match.Authors...)
+	mine = append(mine, tmp...)
+
+	for _, b := range s.Match.Branches {
+		tmp, left = left.ExtractByBranch(fold, b.Org, b.Repo, b.Branch)
+		mine = append(mine, tmp...)
+	}
+
+	for _, f := range s.Match.Fields {
+		tmp, left = left.ExtractByField(fold, f.Name, f.Value)
+		mine = append(mine, tmp...)
+	}
+	return mine, left
+}
+
+// Render converts a list of items into a markdown document section.
+func (s Section) Render(cfg Config, list Items, w io.Writer) {
+	if s.OmitIfEmpty && len(list) == 0 {
+		return
+	}
+
+	summary := cfg.Summarize(list)
+	if n := cfg.Overdue.Count(list); n > 0 {
+		summary += ", " + fmt.Sprintf(cfg.Locale.T("overdue"), n)
+	}
+
+	name := s.Name
+	if len(s.Icon) > 0 {
+		name = s.Icon + " " + name
+	}
+
+	fmt.Fprintf(w, "\n%s %s (%s)\n\n", cfg.Markdown.Heading(1), name, summary)
+	if s.Collapsible && len(list) > 0 {
+		fmt.Fprintf(w, "<details><summary>%d %s</summary>\n\n", len(list), cfg.Locale.T("items"))
+		defer fmt.Fprintf(w, "\n</details>\n")
+	}
+
+	l := newLinks(cfg)
+	defer l.write(w)
+
+	list, more := s.limit(cfg, list)
+
+	if len(s.GroupBy) == 0 {
+		s.renderItems(cfg, list, w, l)
+	} else {
+		groups, values := list.GroupByField(s.GroupBy)
+		for _, value := range values {
+			fmt.Fprintf(w, "%s %s (%s)\n\n", cfg.Markdown.Heading(2), value, cfg.Summarize(groups[value]))
+			s.renderItems(cfg, groups[value], w, l)
+			fmt.Fprintln(w)
+		}
+		if rest, ok := groups[""]; ok {
+			fmt.Fprintf(w, "%s %s (%s)\n\n", cfg.Markdown.Heading(2), cfg.Locale.T("ungrouped"), cfg.Summarize(rest))
+			s.renderItems(cfg, rest, w, l)
+			fmt.Fprintln(w)
+		}
+	}
+
+	if more > 0 {
+		text := fmt.Sprintf(cfg.Locale.T("and_more"), more)
+		if len(cfg.Appendix) > 0 {
+			text = fmt.Sprintf("[%s](%s)", text, url.PathEscape(cfg.Appendix))
+		}
+		fmt.Fprintf(w, "%s _%s_\n", cfg.Markdown.ListMarker(), text)
+	}
+}
+
+// limit returns the first max_items of the list, with the low priority items
+// after the others, and the number of items left out.
+func (s Section) limit(cfg Config, list Items) (Items, int) {
+	if s.MaxItems <= 0 || len(list) <= s.MaxItems {
+		return list, 0
+	}
+
+	low, left := cfg.Priority.ExtractLow(list)
+	ordered := make(Items, 0, len(list))
+	ordered = append(ordered, left...)
+	ordered = append(ordered, low...)
+
+	return ordered[:s.MaxItems], len(list) - s.MaxItems
+}
+
+// RenderItems renders the list of items as markdown bullets.
+func (s Section) RenderItems(cfg Config, list Items, w io.Writer) {
+	l := newLinks(cfg)
+	s.renderItems(cfg, list, w, l)
+	l.write(w)
+}
+
+// renderItems renders the list of items using the links.  The low priority
+// items are collapsed after the others if configured.
+func (s Section) renderItems(cfg Config, list Items, w io.Writer, l *links) {
+	low, list := cfg.Priority.ExtractLow(list)
+	s.renderList(cfg, list, w, l)
+
+	if len(low) > 0 {
+		fmt.Fprintf(w, "\n<details><summary>%d %s</summary>\n\n", len(low), cfg.Locale.T("low_priority"))
+		s.renderList(cfg, low, w, l)
+		fmt.Fprintf(w, "\n</details>\n")
+	}
+}
+
+// renderList renders the list of items, nesting them if configured.
+func (s Section) renderList(cfg Config, list Items, w io.Writer, l *links) {
+	if !s.Nest {
+		for _, item := range list {
+			s.renderItem(cfg, item, "", w, l)
+		}
+		return
+	}
+
+	present := make(map[string]bool, len(list))
+	for _, item := range list {
+		present[item.URL] = true
+	}
+
+	children := make(map[string]Items)
+	for _, item := range list {
+		if item.Parent != nil {
+			children[item.Parent.URL] = append(children[item.Parent.URL], item)
+		}
+	}
+
+	// Children are rendered under their parent.  If the parent is not in the
+	// list, the parent is rendered as a plain heading item where the first
+	// child would have been.
+	done := make(map[string]bool)
+	for _, item := range list {
+		if item.Parent != nil {
+			parent := item.Parent.URL
+			if present[parent] || done[parent] {
+				continue
+			}
+			done[parent] = true
+			fmt.Fprintf(w, "%s %s **[%s]**\n", cfg.Markdown.ListMarker(), cfg.Markdown.Title(item.Parent.Title),
+				l.link(fmt.Sprintf("#%d", item.Parent.Number), parent))
+			for _, child := range children[parent] {
+				s.renderItem(cfg, child, "  ", w, l)
+			}
+			continue
+		}
+
+		s.renderItem(cfg, item, "", w, l)
+		for _, child := range children[item.URL] {
+			s.renderItem(cfg, child, "  ", w, l)
+		}
+	}
+}
+
+// renderItem renders a single item with the indent before it.
+func (s Section) renderItem(cfg Config, item Item, indent string, w io.Writer, l *links) {
+	title := cfg.Markdown.Title(item.Title())
+	high := cfg.Priority.IsHigh(item)
+	if high && cfg.Priority.BoldHigh {
+		title = "**" + title + "**"
+	}
+
+	fmt.Fprintf(w, "%s%s %s%s **[%s]** (%s)", indent, cfg.Markdown.ListMarker(), s.LabelIcons.For(item), title,
+		l.link(fmt.Sprintf("#%d", item.Number), item.URL),
+		l.link(item.Repo.Slug, item.Repo.URL))
+	if high && len(cfg.Priority.HighMarker) > 0 {
+		fmt.Fprintf(w, " %s", cfg.Priority.HighMarker)
+	}
+	if cfg.NewItems.Enabled && !cfg.NewItems.Separate && item.IsNew {
+		fmt.Fprintf(w, " %s", cfg.NewItems.Marker)
+	}
+	if cfg.Overdue.Enabled && item.IsLate(cfg.Overdue.Field) {
+		fmt.Fprintf(w, " %s", cfg.Overdue.Marker)
+	}
+	if cfg.NewContributors.Enabled && item.NewContributor && len(cfg.NewContributors.Marker) > 0 {
+		fmt.Fprintf(w, " %s", cfg.NewContributors.Marker)
+	}
+	if !item.ReportedDone.IsZero() {
+		fmt.Fprintf(w, " _(%s)_", fmt.Sprintf(cfg.Locale.T("reported_done"), cfg.Locale.Date(item.ReportedDone)))
+	}
+	if len(item.AlsoIn) > 0 {
+		fmt.Fprintf(w, " _(%s)_", fmt.Sprintf(cfg.Locale.T("also_in"), strings.Join(item.AlsoIn, ", ")))
+	}
+	if s.InlineLabels.Enabled {
+		for _, label := range item.FilterLabels(s.InlineLabels.Allow...) {
+			fmt.Fprintf(w, " `%s`", label)
+		}
+	}
+	fmt.Fprintln(w)
+
+	if s.Excerpt.Enabled {
+		if excerpt := item.Excerpt(s.Excerpt.FirstSentence, s.Excerpt.Length); len(excerpt) > 0 {
+			fmt.Fprintf(w, "%s  > %s\n", indent, excerpt)
+		}
+	}
+}
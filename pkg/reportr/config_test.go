@@ -0,0 +1,576 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		description string
+		section     Section
+		expectMine  Items
+		expectLeft  Items
+	}{
+		{
+			description: "extract by label",
+			section: Section{
+				Match: Match{
+					Labels: []string{"dogs", "deployment"},
+				},
+			},
+			expectMine: Items{itemIssue88, itemIssue89},
+			expectLeft: Items{itemPr24, itemPr23},
+		}, {
+			description: "extract by label glob",
+			section: Section{
+				Match: Match{
+					Labels: []string{"*"},
+				},
+			},
+			expectMine: Items{itemIssue88, itemIssue89},
+			expectLeft: Items{itemPr24, itemPr23},
+		}, {
+			description: "label case mismatch",
+			section: Section{
+				Match: Match{
+					Labels: []string{"DEPLOYMENT"},
+				},
+			},
+			expectLeft: Items{itemPr24, itemIssue88, itemIssue89, itemPr23},
+		}, {
+			description: "extract by label ignoring case",
+			section: Section{
+				Match: Match{
+					Labels:          []string{"DEPLOYMENT"},
+					CaseInsensitive: true,
+				},
+			},
+			expectMine: Items{itemIssue88, itemIssue89},
+			expectLeft: Items{itemPr24, itemPr23},
+		}, {
+			description: "extract by prefix ignoring case",
+			section: Section{
+				Match: Match{
+					Prefixes:        []string{"update SOMETHING"},
+					Authors:         []string{"OCTOCAT"},
+					CaseInsensitive: true,
+				},
+			},
+			expectMine: Items{itemPr24, itemPr23, itemIssue88},
+			expectLeft: Items{itemIssue89},
+		}, {
+			description: "extract by prefix",
+			section: Section{
+				Match: Match{
+					Prefixes: []string{"Update Something"},
+				},
+			},
+			expectMine: Items{itemPr24, itemPr23},
+			expectLeft: Items{itemIssue88, itemIssue89},
+		}, {
+			description: "extract by globbed prefix",
+			section: Section{
+				Match: Match{
+					Prefixes: []string{"Up*"},
+				},
+			},
+			expectMine: Items{itemPr24, itemPr23},
+			expectLeft: Items{itemIssue88, itemIssue89},
+		}, {
+			description: "extract by author",
+			section: Section{
+				Match: Match{
+					Authors: []string{"*[bot]"},
+				},
+			},
+			expectMine: Items{itemPr24, itemPr23},
+			expectLeft: Items{itemIssue88, itemIssue89},
+		}, {
+			description: "extract by branch",
+			section: Section{
+				Match: Match{
+					Branches: []Branch{
+						{
+							Org:    "*",
+							Repo:   "*",
+							Branch: "*",
+						},
+					},
+				},
+			},
+			expectMine: Items{itemPr24, itemPr23},
+			expectLeft: Items{itemIssue88, itemIssue89},
+		}, {
+			description: "extract none by branch",
+			section: Section{
+				Match: Match{
+					Branches: []Branch{
+						{
+							Org:    "no-match",
+							Repo:   "*",
+							Branch: "*",
+						},
+					},
+				},
+			},
+			expectLeft: Items{itemPr24, itemIssue88, itemIssue89, itemPr23},
+		}, {
+			description: "extract by field",
+			section: Section{
+				Match: Match{
+					Fields: []FieldMatch{
+						{
+							Name:  "Status",
+							Value: "To*",
+						},
+					},
+				},
+			},
+			expectMine: Items{itemPr24, itemIssue88, itemIssue89, itemPr23},
+		}, {
+			description: "extract none by field",
+			section: Section{
+				Match: Match{
+					Fields: []FieldMatch{
+						{
+							Name:  "Priority",
+							Value: "*",
+						},
+					},
+				},
+			},
+			expectLeft: Items{itemPr24, itemIssue88, itemIssue89, itemPr23},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+			items := Items{itemPr24, itemIssue88, itemIssue89, itemPr23}
+
+			mine, left := tc.section.Extract(items)
+
+			require.Equal(len(tc.expectMine), len(mine))
+			require.Equal(len(tc.expectLeft), len(left))
+			assert.ElementsMatch(tc.expectMine, mine)
+			assert.ElementsMatch(tc.expectLeft, left)
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		description string
+		section     Section
+		list        Items
+		expect      string
+	}{
+		{
+			description: "empty omitted",
+			section: Section{
+				Name:        "Name",
+				OmitIfEmpty: true,
+			},
+		}, {
+			description: "empty",
+			section: Section{
+				Name: "Name",
+			},
+			expect: "\n## Name (0)\n\n",
+		}, {
+			description: "simple",
+			section: Section{
+				Name: "Name",
+			},
+			list: Items{itemPr23},
+			expect: "\n## Name (1)\n\n" +
+				"- Update Something **[[#23](https://github.com/org/repo/pull/23)]** ([org/repo](https://github.com/org/repo))\n",
+		}, {
+			description: "collapsible",
+			section: Section{
+				Name:        "Name",
+				Collapsible: true,
+			},
+			list: Items{itemPr23},
+			expect: "\n## Name (1)\n\n" +
+				"<details><summary>1 items</summary>\n\n" +
+				"- Update Something **[[#23](https://github.com/org/repo/pull/23)]** ([org/repo](https://github.com/org/repo))\n" +
+				"\n</details>\n",
+		}, {
+			description: "nested",
+			section: Section{
+				Name: "Name",
+				Nest: true,
+			},
+			list: Items{
+				{Number: 2, URL: "u2", Parent: &Parent{Number: 1, Title: "Epic", URL: "u1"}},
+				{Number: 3, URL: "u3"},
+				{Number: 4, URL: "u4", Parent: &Parent{Number: 3, URL: "u3"}},
+				{Number: 5, URL: "u5", Parent: &Parent{Number: 1, Title: "Epic", URL: "u1"}},
+			},
+			expect: "\n## Name (4)\n\n" +
+				"- Epic **[[#1](u1)]**\n" +
+				"  -  **[[#2](u2)]** ([]())\n" +
+				"  -  **[[#5](u5)]** ([]())\n" +
+				"-  **[[#3](u3)]** ([]())\n" +
+				"  -  **[[#4](u4)]** ([]())\n",
+		}, {
+			description: "grouped",
+			section: Section{
+				Name:    "Name",
+				GroupBy: "Epic",
+			},
+			list: Items{
+				{Number: 1, URL: "u1", Fields: map[string]Field{"Epic": {Type: FIELD_TEXT, Text: "Zeta"}}},
+				{Number: 2, URL: "u2"},
+				{Number: 3, URL: "u3", Fields: map[string]Field{"Epic": {Type: FIELD_TEXT, Text: "Alpha"}}},
+				{Number: 4, URL: "u4", Fields: map[string]Field{"Epic": {Type: FIELD_TEXT, Text: "Zeta"}}},
+			},
+			expect: "\n## Name (4)\n\n" +
+				"### Alpha (1)\n\n" +
+				"-  **[[#3](u3)]** ([]())\n\n" +
+				"### Zeta (2)\n\n" +
+				"-  **[[#1](u1)]** ([]())\n" +
+				"-  **[[#4](u4)]** ([]())\n\n" +
+				"### Other (1)\n\n" +
+				"-  **[[#2](u2)]** ([]())\n\n",
+		}, {
+			description: "limited",
+			section: Section{
+				Name:     "Name",
+				MaxItems: 2,
+			},
+			list: Items{
+				{Number: 1, URL: "u1"},
+				{Number: 2, URL: "u2"},
+				{Number: 3, URL: "u3"},
+				{Number: 4, URL: "u4"},
+			},
+			expect: "\n## Name (4)\n\n" +
+				"-  **[[#1](u1)]** ([]())\n" +
+				"-  **[[#2](u2)]** ([]())\n" +
+				"- _\u2026 and 2 more_\n",
+		}, {
+			description: "icons",
+			section: Section{
+				Name:       "Bugs",
+				Icon:       "\U0001F41B",
+				LabelIcons: LabelIcons{"security": "\U0001F512"},
+			},
+			list: Items{
+				{Number: 1, URL: "u1", Labels: []string{"security"}},
+				{Number: 2, URL: "u2"},
+			},
+			expect: "\n## \U0001F41B Bugs (2)\n\n" +
+				"- \U0001F512  **[[#1](u1)]** ([]())\n" +
+				"-  **[[#2](u2)]** ([]())\n",
+		}, {
+			description: "within the limit",
+			section: Section{
+				Name:     "Name",
+				MaxItems: 2,
+			},
+			list: Items{
+				{Number: 1, URL: "u1"},
+				{Number: 2, URL: "u2"},
+			},
+			expect: "\n## Name (2)\n\n" +
+				"-  **[[#1](u1)]** ([]())\n" +
+				"-  **[[#2](u2)]** ([]())\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var buf strings.Builder
+			tc.section.Render(Config{}, tc.list, &buf)
+
+			assert.Equal(tc.expect, buf.String())
+		})
+	}
+}
+
+func TestRenderReferenceLinks(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := Config{Markdown: Markdown{ReferenceLinks: true}}
+
+	var buf strings.Builder
+	Section{Name: "Name"}.Render(cfg, Items{itemPr23, itemPr24}, &buf)
+
+	assert.Equal("\n## Name (2)\n\n"+
+		"- Update Something **[[#23][org/repo#23]]** ([org/repo][org/repo])\n"+
+		"- Update Something **[[#24][org/repo#24]]** ([org/repo][org/repo])\n"+
+		"\n"+
+		"[org/repo#23]: https://github.com/org/repo/pull/23\n"+
+		"[org/repo]: https://github.com/org/repo\n"+
+		"[org/repo#24]: https://github.com/org/repo/pull/24\n",
+		buf.String())
+}
+
+func TestDependenciesExtractAndRender(t *testing.T) {
+	assert := assert.New(t)
+
+	d := Dependencies{
+		Name:        "Deps",
+		ListItems:   true,
+		Collapsible: true,
+		Match: Match{
+			Authors: []string{"dependabot[bot]"},
+		},
+	}
+
+	var buf strings.Builder
+	left := d.ExtractAndRender(Config{}, Items{itemIssue88, itemPr23, itemPr24}, &buf)
+
+	assert.Equal(Items{itemIssue88}, left)
+	assert.Equal("\n## Deps (2)\n\n"+
+		"2 dependency updates across 1 repos.\n\n"+
+		"- [org/repo](https://github.com/org/repo) (2)\n\n"+
+		"<details><summary>2 items</summary>\n\n"+
+		"- Update Something **[[#23](https://github.com/org/repo/pull/23)]** ([org/repo](https://github.com/org/repo))\n"+
+		"- Update Something **[[#24](https://github.com/org/repo/pull/24)]** ([org/repo](https://github.com/org/repo))\n"+
+		"\n</details>\n", buf.String())
+}
+
+func TestApplyPresets(t *testing.T) {
+	presets := map[string]Match{
+		"infra": {
+			Labels:  []string{"infra", "ci"},
+			Authors: []string{"*[bot]"},
+		},
+		"bugs": {
+			Labels: []string{"bug"},
+			Fields: []FieldMatch{{Name: "Type", Value: "Bug"}},
+		},
+		"nested": {
+			Presets: []string{"infra"},
+		},
+	}
+
+	tests := []struct {
+		description string
+		match       Match
+		expect      Match
+		err         error
+		wantErr     bool
+	}{
+		{
+			description: "no presets",
+			match:       Match{Labels: []string{"docs"}},
+			expect:      Match{Labels: []string{"docs"}},
+		}, {
+			description: "presets are added to the criteria",
+			match: Match{
+				Labels:  []string{"docs"},
+				Presets: []string{"infra", "bugs"},
+			},
+			expect: Match{
+				Labels:  []string{"docs", "infra", "ci", "bug"},
+				Authors: []string{"*[bot]"},
+				Fields:  []FieldMatch{{Name: "Type", Value: "Bug"}},
+			},
+		}, {
+			description: "unknown preset",
+			match:       Match{Presets: []string{"missing"}},
+			err:         ErrUnknownPreset,
+		}, {
+			description: "presets including presets",
+			match:       Match{Presets: []string{"nested"}},
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			cfg := Config{
+				MatchPresets: presets,
+				Blocked:      Blocked{Match: Match{Presets: []string{"bugs"}}},
+				Sections:     []Section{{Name: "Section", Match: tc.match}},
+			}
+			err := cfg.ApplyPresets()
+
+			if tc.wantErr || tc.err != nil {
+				require.Error(err)
+				if tc.err != nil {
+					assert.ErrorIs(err, tc.err)
+				}
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tc.expect, cfg.Sections[0].Match)
+			assert.Equal([]string{"bug"}, cfg.Blocked.Match.Labels)
+			assert.Equal([]string{"infra", "ci"}, presets["infra"].Labels)
+		})
+	}
+}
+
+func TestApplySectionDefaults(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := Config{
+		SectionDefaults: SectionDefaults{
+			OmitIfEmpty: true,
+			Collapsible: true,
+			GroupBy:     "Epic",
+			Excerpt:     Excerpt{Enabled: true, Length: 200},
+		},
+		Sections: []Section{
+			{Name: "inherits"},
+			{Name: "overrides", Excerpt: Excerpt{Length: 50}},
+			{Name: "not in the list"},
+		},
+	}
+
+	cfg.ApplySectionDefaults([]map[string]any{
+		{"name": "inherits"},
+		{
+			"name":          "overrides",
+			"omit_if_empty": false,
+			"group_by":      "",
+			"excerpt":       map[string]any{"length": 50},
+		},
+	})
+
+	assert.Equal(Section{
+		Name:        "inherits",
+		OmitIfEmpty: true,
+		Collapsible: true,
+		GroupBy:     "Epic",
+		Excerpt:     Excerpt{Enabled: true, Length: 200},
+	}, cfg.Sections[0])
+	assert.Equal(Section{
+		Name:        "overrides",
+		Collapsible: true,
+		Excerpt:     Excerpt{Enabled: true, Length: 50},
+	}, cfg.Sections[1])
+	assert.Equal(Section{
+		Name:        "not in the list",
+		OmitIfEmpty: true,
+		Collapsible: true,
+		GroupBy:     "Epic",
+		Excerpt:     Excerpt{Enabled: true, Length: 200},
+	}, cfg.Sections[2])
+}
+
+func TestProjectSourceApply(t *testing.T) {
+	cfg := Config{
+		Url:   "https://api.github.com/graphql",
+		Owner: "org",
+		Token: "token",
+		Team:  "team",
+	}
+
+	tests := []struct {
+		description string
+		source      ProjectSource
+		expect      Config
+	}{
+		{
+			description: "inherit everything",
+			expect:      cfg,
+		}, {
+			description: "another host",
+			source: ProjectSource{
+				Url:     "https://github.example.com/api/graphql",
+				Owner:   "internal",
+				Token:   "other",
+				Project: 3,
+			},
+			expect: Config{
+				Url:   "https://github.example.com/api/graphql",
+				Owner: "internal",
+				Token: "other",
+				Team:  "team",
+			},
+		}, {
+			description: "another owner",
+			source:      ProjectSource{Owner: "other-org"},
+			expect: Config{
+				Url:   "https://api.github.com/graphql",
+				Owner: "other-org",
+				Token: "token",
+				Team:  "team",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(tc.expect, tc.source.Apply(cfg))
+		})
+	}
+}
+
+func TestSectionLimit(t *testing.T) {
+	cfg := Config{Priority: Priority{Enabled: true, Field: "Priority", Low: 3, CollapseLow: true}}
+	low := Item{ID: "low", Fields: map[string]Field{"Priority": {Type: FIELD_NUMBER, Number: 3}}}
+	list := Items{low, {ID: "a"}, {ID: "b"}}
+
+	tests := []struct {
+		description string
+		max         int
+		expect      []string
+		more        int
+	}{
+		{description: "no limit", expect: []string{"low", "a", "b"}},
+		{description: "low priority last", max: 2, expect: []string{"a", "b"}, more: 1},
+		{description: "low priority included", max: 3, expect: []string{"low", "a", "b"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			got, more := Section{MaxItems: tc.max}.limit(cfg, list)
+
+			var ids []string
+			for _, item := range got {
+				ids = append(ids, item.ID)
+			}
+			assert.Equal(tc.expect, ids)
+			assert.Equal(tc.more, more)
+		})
+	}
+}
+
+func TestLabelIconsFor(t *testing.T) {
+	icons := LabelIcons{
+		"bug":    "B",
+		"area/*": "A",
+		"crash":  "B",
+		"none":   "",
+	}
+
+	tests := []struct {
+		description string
+		labels      []string
+		expect      string
+	}{
+		{description: "no labels"},
+		{description: "one", labels: []string{"bug"}, expect: "B "},
+		{description: "glob", labels: []string{"area/api"}, expect: "A "},
+		{description: "in glob order", labels: []string{"bug", "area/api"}, expect: "AB "},
+		{description: "distinct", labels: []string{"bug", "crash"}, expect: "B "},
+		{description: "empty icon", labels: []string{"none"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expect, icons.For(Item{Labels: tc.labels}))
+		})
+	}
+}
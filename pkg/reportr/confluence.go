@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	gmhtml "github.com/yuin/goldmark/renderer/html"
+)
+
+// DefaultConfluenceTitle is the page title template used when the confluence
+// delivery does not specify one.
+const DefaultConfluenceTitle = `{{.Team}} {{t "status_report"}}: {{date .Start}} ... {{date .End}}`
+
+// confluence publishes the markdown reports as Confluence Cloud pages.  The
+// page with the same title in the space is updated if it exists, otherwise it
+// is created under the parent page.
+type confluence struct {
+	url    string // The base url of the wiki, like https://example.atlassian.net/wiki
+	space  string
+	parent string
+	title  string
+	user   string
+	token  string
+}
+
+// newConfluenceDeliverer creates a confluence deliverer using the account
+// email and api token in the CONFLUENCE_USER and CONFLUENCE_API_TOKEN
+// environment variables.
+func newConfluenceDeliverer(d Delivery) (Deliverer, error) {
+	if len(d.Endpoint) == 0 || len(d.Space) == 0 {
+		return nil, fmt.Errorf("confluence delivery requires an endpoint and a space")
+	}
+
+	c := confluence{
+		url:    strings.TrimSuffix(d.Endpoint, "/"),
+		space:  d.Space,
+		parent: d.Parent,
+		title:  d.Title,
+		user:   os.Getenv("CONFLUENCE_USER"),
+		token:  os.Getenv("CONFLUENCE_API_TOKEN"),
+	}
+	if len(c.user) == 0 || len(c.token) == 0 {
+		return nil, fmt.Errorf("%w: confluence requires CONFLUENCE_USER and CONFLUENCE_API_TOKEN", ErrMissingCredentials)
+	}
+	if len(strings.TrimSpace(c.title)) == 0 {
+		c.title = DefaultConfluenceTitle
+	}
+
+	return c, nil
+}
+
+// confluencePage is the subset of the confluence content api used.
+type confluencePage struct {
+	ID        string             `json:"id,omitempty"`
+	Type      string             `json:"type"`
+	Title     string             `json:"title"`
+	Space     *confluenceSpace   `json:"space,omitempty"`
+	Ancestors []confluenceID     `json:"ancestors,omitempty"`
+	Version   *confluenceVersion `json:"version,omitempty"`
+	Body      *confluenceBody    `json:"body,omitempty"`
+	Results   []confluencePage   `json:"results,omitempty"` // The pages found by a search.
+}
+
+type confluenceSpace struct {
+	Key string `json:"key"`
+}
+
+type confluenceID struct {
+	ID string `json:"id"`
+}
+
+type confluenceVersion struct {
+	Number int `json:"number"`
+}
+
+type confluenceBody struct {
+	Storage confluenceStorage `json:"storage"`
+}
+
+type confluenceStorage struct {
+	Value          string `json:"value"`
+	Representation string `json:"representation"`
+}
+
+func (c confluence) Deliver(cfg Config, r Report) error {
+	// Only the markdown reports can be converted to the storage format.
+	if r.Format != "markdown" {
+		return nil
+	}
+
+	text, err := executeTemplate(cfg, "title", c.title, r.Week)
+	if err != nil {
+		return fmt.Errorf("confluence title: %w", err)
+	}
+
+	body, err := ConfluenceStorage(r.Data)
+	if err != nil {
+		return err
+	}
+
+	page := confluencePage{
+		Type:  "page",
+		Title: text,
+		Space: &confluenceSpace{Key: c.space},
+		Body: &confluenceBody{
+			Storage: confluenceStorage{
+				Value:          body,
+				Representation: "storage",
+			},
+		},
+	}
+
+	existing, err := c.find(text)
+	if err != nil {
+		return err
+	}
+
+	if existing == nil {
+		if len(c.parent) > 0 {
+			page.Ancestors = []confluenceID{{ID: c.parent}}
+		}
+		err = c.do(http.MethodPost, "/rest/api/content", page, nil)
+		if err == nil {
+			logf("Created confluence page '%s'", text)
+		}
+		return err
+	}
+
+	page.ID = existing.ID
+	page.Version = &confluenceVersion{Number: existing.Version.Number + 1}
+	err = c.do(http.MethodPut, "/rest/api/content/"+existing.ID, page, nil)
+	if err == nil {
+		logf("Updated confluence page '%s'", text)
+	}
+	return err
+}
+
+// find returns the page in the space with the title, or nil if there is none.
+func (c confluence) find(title string) (*confluencePage, error) {
+	q := url.Values{}
+	q.Set("spaceKey", c.space)
+	q.Set("title", title)
+	q.Set("expand", "version")
+
+	var found confluencePage
+	if err := c.do(http.MethodGet, "/rest/api/content?"+q.Encode(), nil, &found); err != nil {
+		return nil, err
+	}
+	if len(found.Results) == 0 || found.Results[0].Version == nil {
+		return nil, nil
+	}
+	return &found.Results[0], nil
+}
+
+// do sends the request to the confluence api and decodes the response into
+// out if it is not nil.
+func (c confluence) do(method, path string, in, out any) error {
+	return doJSON("confluence", method, c.url+path, in, out, func(req *http.Request) {
+		req.SetBasicAuth(c.user, c.token)
+	})
+}
+
+// ConfluenceStorage converts the markdown report into the confluence storage
+// format, which is XHTML.  Raw HTML in the report is left out since confluence
+// rejects the elements it does not know.
+func ConfluenceStorage(markdown []byte) (string, error) {
+	md := goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithRendererOptions(gmhtml.WithXHTML()),
+	)
+
+	var buf bytes.Buffer
+	if err := md.Convert(markdown, &buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
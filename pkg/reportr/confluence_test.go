@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfluenceDeliverer(t *testing.T) {
+	tests := []struct {
+		description string
+		search      string
+		format      string
+		method      string
+		path        string
+		version     int
+		ancestor    string
+	}{
+		{
+			description: "create",
+			search:      `{"results":[]}`,
+			format:      "markdown",
+			method:      http.MethodPost,
+			path:        "/wiki/rest/api/content",
+			ancestor:    "42",
+		}, {
+			description: "update",
+			search:      `{"results":[{"id":"7","type":"page","title":"t","version":{"number":3}}]}`,
+			format:      "markdown",
+			method:      http.MethodPut,
+			path:        "/wiki/rest/api/content/7",
+			version:     4,
+		}, {
+			description: "other formats are ignored",
+			format:      "html",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			t.Setenv("CONFLUENCE_USER", "me@example.com")
+			t.Setenv("CONFLUENCE_API_TOKEN", "token")
+
+			var title, method, path string
+			var page confluencePage
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				user, pass, _ := r.BasicAuth()
+				assert.Equal("me@example.com", user)
+				assert.Equal("token", pass)
+
+				if r.Method == http.MethodGet {
+					title = r.URL.Query().Get("title")
+					assert.Equal("TEAM", r.URL.Query().Get("spaceKey"))
+					_, _ = w.Write([]byte(tc.search))
+					return
+				}
+				method, path = r.Method, r.URL.Path
+				assert.NoError(json.NewDecoder(r.Body).Decode(&page))
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			d, err := NewDeliverer(Delivery{
+				Type:     "confluence",
+				Endpoint: server.URL + "/wiki/",
+				Space:    "TEAM",
+				Parent:   "42",
+			})
+			require.NoError(err)
+
+			err = d.Deliver(Config{Team: "Team", Locale: Locale{DateFormat: "2006-01-02"}}, Report{
+				Week: WeeklyItems{
+					Start: mustParseTime("2022-11-27T00:00:00Z"),
+					End:   mustParseTime("2022-12-04T00:00:00Z"),
+				},
+				Format: tc.format,
+				Path:   "out/report.md",
+				Data:   []byte("# Report\n\n- item<br>\n"),
+			})
+			require.NoError(err)
+
+			assert.Equal(tc.method, method)
+			assert.Equal(tc.path, path)
+			if len(tc.method) == 0 {
+				return
+			}
+
+			assert.Equal("Team Status Report: 2022-11-27 ... 2022-12-03", title)
+			assert.Equal(title, page.Title)
+			assert.Equal("TEAM", page.Space.Key)
+			assert.Equal("storage", page.Body.Storage.Representation)
+			assert.Contains(page.Body.Storage.Value, "<h1>Report</h1>")
+			assert.NotContains(page.Body.Storage.Value, "<br>")
+			if tc.version > 0 {
+				require.NotNil(page.Version)
+				assert.Equal(tc.version, page.Version.Number)
+			}
+			if len(tc.ancestor) > 0 {
+				require.Len(page.Ancestors, 1)
+				assert.Equal(tc.ancestor, page.Ancestors[0].ID)
+			} else {
+				assert.Empty(page.Ancestors)
+			}
+		})
+	}
+}
+
+func TestNewConfluenceDelivererErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Setenv("CONFLUENCE_USER", "")
+	t.Setenv("CONFLUENCE_API_TOKEN", "")
+
+	_, err := NewDeliverer(Delivery{Type: "confluence", Space: "TEAM"})
+	assert.Error(err)
+
+	_, err = NewDeliverer(Delivery{Type: "confluence", Endpoint: "https://example.com/wiki", Space: "TEAM"})
+	assert.True(errors.Is(err, ErrMissingCredentials))
+}
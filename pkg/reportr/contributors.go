@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RecordContributors remembers when the authors of the merged pull requests in
+// the list first had a pull request merged.  Since the archived items are not
+// fetched again, the history is what keeps the earlier contributions known.
+// Bots are not contributors.
+func (h *History) RecordContributors(list Items) {
+	for _, item := range list {
+		if item.ItemType != "PR" || item.Abandoned || item.DoneAt.IsZero() ||
+			len(item.Author) == 0 || item.IsBot() {
+			continue
+		}
+		if h.Contributors == nil {
+			h.Contributors = make(map[string]time.Time)
+		}
+		if first, ok := h.Contributors[item.Author]; !ok || item.DoneAt.Before(first) {
+			h.Contributors[item.Author] = item.DoneAt
+		}
+	}
+}
+
+// MarkNewContributors marks the items of the authors whose first pull request
+// was merged in the window from start to end.
+func (h History) MarkNewContributors(list Items, start, end time.Time) Items {
+	rv := make(Items, 0, len(list))
+	for _, item := range list {
+		first, ok := h.Contributors[item.Author]
+		item.NewContributor = ok && !first.Before(start) && first.Before(end)
+		rv = append(rv, item)
+	}
+	return rv
+}
+
+// Render writes the callout welcoming the authors of the items marked as new
+// contributors, in the order of their first item.  Nothing is written if there
+// are none.
+func (n NewContributors) Render(list Items, w io.Writer) {
+	var names []string
+	counts := make(map[string]int)
+	for _, item := range list {
+		if !item.NewContributor {
+			continue
+		}
+		if counts[item.Author] == 0 {
+			names = append(names, item.Author)
+		}
+		counts[item.Author]++
+	}
+	if len(names) == 0 {
+		return
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		return counts[names[i]] > counts[names[j]]
+	})
+
+	for i, name := range names {
+		names[i] = fmt.Sprintf("%s (%d)", name, counts[name])
+	}
+	fmt.Fprintf(w, "\n> **%s:** %s\n", n.Name, strings.Join(names, ", "))
+}
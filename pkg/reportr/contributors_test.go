@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewContributors(t *testing.T) {
+	pr := func(author, doneAt string) Item {
+		return Item{ItemType: "PR", Author: author, DoneAt: mustParseTime(doneAt)}
+	}
+
+	start := mustParseTime("2022-11-14T00:00:00Z")
+	end := mustParseTime("2022-11-21T00:00:00Z")
+
+	tests := []struct {
+		description string
+		before      Items
+		list        Items
+		expectNew   []bool
+		expect      string
+	}{
+		{
+			description: "no items",
+		}, {
+			description: "first merge in the window",
+			list: Items{
+				pr("alice", "2022-11-15T00:00:00Z"),
+				pr("alice", "2022-11-16T00:00:00Z"),
+				pr("bob", "2022-11-17T00:00:00Z"),
+			},
+			expectNew: []bool{true, true, true},
+			expect:    "\n> **New Contributors:** alice (2), bob (1)\n",
+		}, {
+			description: "earlier merge remembered",
+			before:      Items{pr("alice", "2022-11-01T00:00:00Z")},
+			list: Items{
+				pr("alice", "2022-11-15T00:00:00Z"),
+				pr("bob", "2022-11-17T00:00:00Z"),
+			},
+			expectNew: []bool{false, true},
+			expect:    "\n> **New Contributors:** bob (1)\n",
+		}, {
+			description: "issues, abandoned prs and bots are ignored",
+			list: Items{
+				{ItemType: "ISSUE", Author: "alice", DoneAt: mustParseTime("2022-11-15T00:00:00Z")},
+				{ItemType: "PR", Author: "bob", Abandoned: true, DoneAt: mustParseTime("2022-11-15T00:00:00Z")},
+				pr("dependabot[bot]", "2022-11-15T00:00:00Z"),
+			},
+			expectNew: []bool{false, false, false},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var h History
+			h.RecordContributors(tc.before)
+			h.RecordContributors(tc.list)
+
+			got := h.MarkNewContributors(tc.list, start, end)
+			for i := range got {
+				assert.Equal(tc.expectNew[i], got[i].NewContributor)
+			}
+
+			var buf strings.Builder
+			NewContributors{Enabled: true, Name: "New Contributors"}.Render(got, &buf)
+			assert.Equal(tc.expect, buf.String())
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+var ErrUnknownDelivery = errors.New("unknown delivery type")
+
+// Report is a single rendered report ready for delivery.
+type Report struct {
+	Week   WeeklyItems
+	Format string // The name of the renderer used.
+	Path   string // The path the report was written to.
+	Data   []byte
+}
+
+// Env returns the report metadata as a map of environment variables.
+func (r Report) Env() map[string]string {
+	return map[string]string{
+		"SR_WEEK_START":    r.Week.Start.Format("2006-01-02"),
+		"SR_WEEK_END":      r.Week.End.AddDate(0, 0, -1).Format("2006-01-02"),
+		"SR_REPORT_FILE":   r.Path,
+		"SR_REPORT_NAME":   filepath.Base(r.Path),
+		"SR_REPORT_FORMAT": r.Format,
+	}
+}
+
+// Deliverer delivers a rendered report somewhere.
+type Deliverer interface {
+	Deliver(cfg Config, r Report) error
+}
+
+// Delivery captures the configuration of a single delivery target.
+type Delivery struct {
+	Type    string   `yaml:"type"`    // The type of the delivery target.
+	Formats []string `yaml:"formats"` // The report formats to deliver, empty delivers all.
+
+	Command string `yaml:"command"` // exec: The command to pipe each report to.
+
+	Bucket   string `yaml:"bucket"`   // s3, gcs: The bucket to upload the reports to.
+	Prefix   string `yaml:"prefix"`   // s3, gcs: The prefix added to the report file names.
+	Region   string `yaml:"region"`   // s3: The region of the bucket.
+	Endpoint string `yaml:"endpoint"` // s3, gcs, confluence: The url of the service.
+
+	Space  string `yaml:"space"`  // confluence: The key of the space to publish to.
+	Parent string `yaml:"parent"` // confluence: The id of the parent page of new pages.
+	Title  string `yaml:"title"`  // confluence: The page title template.
+
+	Database string `yaml:"database"` // notion: The id of the database to sync the items to.
+
+	Repo string `yaml:"repo"` // wiki: The owner/name of the repository with the wiki.
+
+	Public bool `yaml:"public"` // gist: If a new gist should be public instead of secret.
+}
+
+// Wants returns if the delivery target wants reports of the format.
+func (d Delivery) Wants(format string) bool {
+	if len(d.Formats) == 0 {
+		return true
+	}
+	for _, f := range d.Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// NewDeliverer creates the deliverer for the delivery target configuration.
+func NewDeliverer(d Delivery) (Deliverer, error) {
+	switch d.Type {
+	case "exec":
+		if len(strings.TrimSpace(d.Command)) == 0 {
+			return nil, fmt.Errorf("exec delivery requires a command")
+		}
+		return execDeliverer{command: d.Command}, nil
+	case "s3", "gcs":
+		if len(d.Bucket) == 0 {
+			return nil, fmt.Errorf("%s delivery requires a bucket", d.Type)
+		}
+		if d.Type == "s3" {
+			return newS3Deliverer(d)
+		}
+		return newGCSDeliverer(d)
+	case "confluence":
+		return newConfluenceDeliverer(d)
+	case "notion":
+		return newNotionDeliverer(d)
+	case "wiki":
+		return newWikiDeliverer(d)
+	case "gist":
+		return newGistDeliverer(d)
+	}
+
+	return nil, fmt.Errorf("%w: '%s'", ErrUnknownDelivery, d.Type)
+}
+
+// execDeliverer pipes each report to an external command.
+type execDeliverer struct {
+	command string
+}
+
+func (e execDeliverer) Deliver(_ Config, r Report) error {
+	cmd := exec.Command("sh", "-c", e.command)
+	cmd.Stdin = bytes.NewReader(r.Data)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	for k, v := range r.Env() {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("delivery command '%s' failed: %w", e.command, err)
+	}
+	return nil
+}
+
+// doJSON sends the json request to the api named and decodes the response into
+// out if it is not nil.  The auth function adds the credentials to the request.
+// The request is bounded by the timeout of HTTPClient.
+func doJSON(name, method, url string, in, out any, auth func(*http.Request)) error {
+	var body io.Reader
+	if in != nil {
+		buf, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(buf)
+	}
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return err
+	}
+	auth(req)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s %s %s failed: %s %s",
+			name, method, req.URL.Path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecDeliverer(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	out := filepath.Join(t.TempDir(), "out.txt")
+
+	d, err := NewDeliverer(Delivery{
+		Type:    "exec",
+		Command: `(echo "$SR_WEEK_START $SR_WEEK_END $SR_REPORT_NAME $SR_REPORT_FORMAT"; cat) > ` + out,
+	})
+	require.NoError(err)
+
+	err = d.Deliver(Config{}, Report{
+		Week: WeeklyItems{
+			Start: mustParseTime("2022-11-27T00:00:00Z"),
+			End:   mustParseTime("2022-12-04T00:00:00Z"),
+		},
+		Format: "markdown",
+		Path:   "out/report.md",
+		Data:   []byte("# Report\n"),
+	})
+	require.NoError(err)
+
+	got, err := os.ReadFile(out)
+	require.NoError(err)
+	assert.Equal("2022-11-27 2022-12-03 report.md markdown\n# Report\n", string(got))
+}
+
+func TestNewExecDelivererErrors(t *testing.T) {
+	for _, command := range []string{"", "  ", "\n\t"} {
+		_, err := NewDeliverer(Delivery{Type: "exec", Command: command})
+		assert.ErrorContains(t, err, "exec delivery requires a command")
+	}
+}
+
+func TestNewDelivererUnknown(t *testing.T) {
+	_, err := NewDeliverer(Delivery{Type: "carrier-pigeon"})
+	assert.True(t, errors.Is(err, ErrUnknownDelivery))
+}
+
+func TestDeliveryWants(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(Delivery{}.Wants("html"))
+	assert.True(Delivery{Formats: []string{"html"}}.Wants("html"))
+	assert.False(Delivery{Formats: []string{"markdown"}}.Wants("html"))
+}
+
+func TestDoJSONTimeout(t *testing.T) {
+	prev := HTTPClient
+	HTTPClient = &http.Client{Timeout: 50 * time.Millisecond}
+	defer func() { HTTPClient = prev }()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	var out map[string]any
+	err := doJSON("notion", http.MethodGet, server.URL, nil, &out, func(*http.Request) {})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "notion request failed")
+	assert.Contains(t, err.Error(), "Timeout")
+}
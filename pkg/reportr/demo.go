@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"time"
+)
+
+// demoItem describes one of the fabricated items.
+type demoItem struct {
+	title    string
+	itemType string
+	repo     string
+	author   string
+	status   string
+	daysAgo  int // When the item was closed, if it is done.
+	labels   []string
+	estimate float64
+	priority float64
+	epic     bool // If the item is a sub-issue of the demo epic.
+	body     string
+}
+
+var demoItems = []demoItem{
+	{title: "Add retries to the upload client", itemType: "PR", repo: "api", author: "octocat", status: "Done", daysAgo: 8,
+		labels: []string{"enhancement"}, estimate: 3, priority: 2, epic: true,
+		body: "Uploads now retry with a backoff.  Transient errors no longer fail the job."},
+	{title: "Fix: the dashboard shows stale data", itemType: "ISSUE", repo: "web", author: "hubot", status: "Done", daysAgo: 9,
+		labels: []string{"bug"}, estimate: 2, priority: 1,
+		body: "The cache was never invalidated when a project changed."},
+	{title: "Bump golang.org/x/net from 0.1.0 to 0.2.0", itemType: "PR", repo: "api", author: "dependabot[bot]", status: "Done", daysAgo: 10,
+		labels: []string{"dependencies"}, estimate: 1},
+	{title: "Document the deployment process", itemType: "ISSUE", repo: "docs", author: "monalisa", status: "Done", daysAgo: 11,
+		labels: []string{"documentation", "deployment"}, estimate: 2, priority: 3},
+	{title: "Migrate the build to the new runners", itemType: "PR", repo: "api", author: "octocat", status: "Done", daysAgo: 12,
+		labels: []string{"infra", "ci"}, estimate: 5, priority: 2, epic: true,
+		body: "The builds run on the new runners.  The old runners can be removed next week."},
+	{title: "Feat: export the reports as csv", itemType: "ISSUE", repo: "web", author: "monalisa", status: "Done", daysAgo: 15,
+		labels: []string{"enhancement"}, estimate: 3, priority: 2},
+	{title: "Fix: race in the scheduler shutdown", itemType: "PR", repo: "scheduler", author: "hubot", status: "Done", daysAgo: 16,
+		labels: []string{"bug"}, estimate: 2, priority: 1},
+	{title: "Bump actions/checkout from 3 to 4", itemType: "PR", repo: "web", author: "dependabot[bot]", status: "Done", daysAgo: 17,
+		labels: []string{"dependencies"}, estimate: 1},
+	{title: "Remove the deprecated v1 endpoints", itemType: "ISSUE", repo: "api", author: "octocat", status: "Done", daysAgo: 22,
+		labels: []string{"breaking"}, estimate: 5, priority: 2, epic: true},
+	{title: "Add the on-call runbook", itemType: "ISSUE", repo: "docs", author: "monalisa", status: "Done", daysAgo: 24,
+		labels: []string{"documentation"}, estimate: 1, priority: 3},
+	{title: "Won't fix: support the legacy exporter", itemType: "ISSUE", repo: "scheduler", author: "hubot", status: "Won't Do", daysAgo: 13,
+		labels: []string{"enhancement"}, estimate: 3, priority: 4},
+	{title: "Rate limit the public api", itemType: "ISSUE", repo: "api", author: "octocat", status: "In Progress",
+		labels: []string{"enhancement"}, estimate: 5, priority: 1, epic: true},
+	{title: "Flaky end to end tests", itemType: "ISSUE", repo: "web", author: "monalisa", status: "Blocked",
+		labels: []string{"bug", "blocked"}, estimate: 3, priority: 2},
+	{title: "Upgrade the database", itemType: "ISSUE", repo: "infra", author: "hubot", status: "Todo",
+		labels: []string{"infra"}, estimate: 8, priority: 3},
+}
+
+// DemoItems returns a fabricated but realistic set of items closed over the
+// last few weeks before now, along with some open items, for trying out the
+// configuration without a token.
+func DemoItems(now time.Time) Items {
+	const org = "demo-org"
+
+	now = now.UTC().Truncate(time.Hour)
+	iteration := Field{
+		Type:        FIELD_ITERATION,
+		Name:        "Iteration",
+		Duration:    21 * 24 * time.Hour,
+		IterationId: "demo-iteration",
+		StartDate:   now.AddDate(0, 0, -14).Truncate(24 * time.Hour),
+		Title:       "Iteration 1",
+	}
+	epic := &Parent{
+		Number: 1,
+		Title:  "Reliability improvements",
+		URL:    fmt.Sprintf("https://github.com/%s/api/issues/1", org),
+	}
+
+	items := make(Items, 0, len(demoItems))
+	for i, d := range demoItems {
+		number := 100 + i
+		kind := "issues"
+		if d.itemType == "PR" {
+			kind = "pull"
+		}
+
+		item := Item{
+			ID:       fmt.Sprintf("demo-%d", number),
+			ItemType: d.itemType,
+			Number:   number,
+			URL:      fmt.Sprintf("https://github.com/%s/%s/%s/%d", org, d.repo, kind, number),
+			Body:     d.body,
+			Author:   d.author,
+			Labels:   d.labels,
+			Fields: map[string]Field{
+				"Title":  {Type: FIELD_TEXT, Name: "Title", Text: d.title},
+				"Status": {Type: FIELD_TEXT, Name: "Status", Text: d.status},
+				"Goal":   {Type: FIELD_DATE, Name: "Goal", Date: now.AddDate(0, 0, 21-d.daysAgo*2).Truncate(24 * time.Hour)},
+			},
+		}
+		item.Repo.Name = d.repo
+		item.Repo.Slug = org + "/" + d.repo
+		item.Repo.URL = fmt.Sprintf("https://github.com/%s/%s", org, d.repo)
+		if d.itemType == "PR" {
+			item.Repo.Branch = "main"
+		}
+		if d.daysAgo > 0 {
+			item.DoneAt = now.AddDate(0, 0, -d.daysAgo)
+		}
+		if d.estimate > 0 {
+			item.Fields["Estimate"] = Field{Type: FIELD_NUMBER, Name: "Estimate", Number: d.estimate}
+		}
+		if d.priority > 0 {
+			item.Fields["Priority"] = Field{Type: FIELD_NUMBER, Name: "Priority", Number: d.priority}
+		}
+		if d.daysAgo < 14 {
+			item.Fields["Iteration"] = iteration
+		}
+		if d.epic {
+			item.Parent = epic
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
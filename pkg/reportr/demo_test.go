@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemoItems(t *testing.T) {
+	assert := assert.New(t)
+
+	now := mustParseTime("2022-11-30T15:04:05Z")
+	items := DemoItems(now)
+
+	assert.Len(items, len(demoItems))
+	ids := make(map[string]bool, len(items))
+	for _, item := range items {
+		assert.False(ids[item.ID], item.ID)
+		ids[item.ID] = true
+		assert.NotEmpty(item.Title())
+		assert.NotEmpty(item.URL)
+		assert.False(item.DoneAt.After(now))
+	}
+
+	assert.Equal(items, DemoItems(now))
+	assert.Len(items.GetNotDone(), 4)
+	assert.Len(SplitByWeeks(items, now, time.Sunday), 3)
+}
@@ -0,0 +1,12 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package reportr provides the status report pipeline used by status-reportr.
+
+The pipeline fetches the items of a Github ProjectV2 board (FetchIssues), splits
+the completed items into weekly or business day windows (SplitByWindow),
+renders a markdown report for each window (Render) and archives the reported
+items (Archive).
+*/
+package reportr
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import "sort"
+
+// DroppedField is a field value from github of a type that is not modeled, so
+// it is left out of the item.
+type DroppedField struct {
+	Name string // The name of the field, if github provides it.
+	Type string // The graphql type of the value.
+}
+
+// FieldDrift is the number of values of a field that were dropped.
+type FieldDrift struct {
+	DroppedField
+	Count int
+}
+
+// SchemaDrift returns the fields with values that were dropped from the items
+// and how many were dropped, sorted by type and name.
+func (list Items) SchemaDrift() []FieldDrift {
+	counts := make(map[DroppedField]int)
+	for _, item := range list {
+		for _, d := range item.Dropped {
+			counts[d]++
+		}
+	}
+
+	rv := make([]FieldDrift, 0, len(counts))
+	for d, count := range counts {
+		rv = append(rv, FieldDrift{DroppedField: d, Count: count})
+	}
+	sort.Slice(rv, func(i, j int) bool {
+		if rv[i].Type != rv[j].Type {
+			return rv[i].Type < rv[j].Type
+		}
+		return rv[i].Name < rv[j].Name
+	})
+
+	return rv
+}
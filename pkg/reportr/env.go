@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EnvPrefix is the prefix of the environment variables that override the
+// configuration values.
+const EnvPrefix = "SR_"
+
+// ApplyEnv overrides the configuration values with the environment variables
+// named by the prefix followed by the yaml keys of the value in upper case
+// joined with '_'.  For example SR_PROJECT_NUMBER sets project_number and
+// SR_TUNING_WORKERS sets tuning.workers.  Lists of strings are comma
+// separated.  Lists of structures and maps can not be overridden.
+func ApplyEnv(cfg *Config, prefix string, lookup func(string) (string, bool)) error {
+	return applyEnv(reflect.ValueOf(cfg).Elem(), prefix, lookup)
+}
+
+func applyEnv(v reflect.Value, prefix string, lookup func(string) (string, bool)) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := yamlKey(t.Field(i))
+		if len(name) == 0 || name == "-" {
+			continue
+		}
+
+		key := prefix + strings.ToUpper(name)
+		field := v.Field(i)
+
+		if field.Kind() == reflect.Struct && field.Type() != reflect.TypeOf(time.Time{}) {
+			if err := applyEnv(field, key+"_", lookup); err != nil {
+				return err
+			}
+			continue
+		}
+
+		val, ok := lookup(key)
+		if !ok {
+			continue
+		}
+
+		if err := setEnvValue(field, val); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// setEnvValue sets the field to the value from the environment.
+func setEnvValue(field reflect.Value, val string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(val)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(val, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(val, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(n)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("lists of %s can not be set", field.Type().Elem())
+		}
+		var list []string
+		for _, s := range strings.Split(val, ",") {
+			if s = strings.TrimSpace(s); len(s) > 0 {
+				list = append(list, s)
+			}
+		}
+		field.Set(reflect.ValueOf(list))
+	default:
+		return fmt.Errorf("%s values can not be set", field.Kind())
+	}
+	return nil
+}
+
+// yamlKey returns the yaml key of the structure field.
+func yamlKey(f reflect.StructField) string {
+	return strings.Split(f.Tag.Get("yaml"), ",")[0]
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyEnv(t *testing.T) {
+	tests := []struct {
+		description string
+		env         map[string]string
+		expect      Config
+		wantErr     bool
+	}{
+		{
+			description: "nothing set",
+			expect:      Config{Owner: "org", Project: 1},
+		}, {
+			description: "top level values",
+			env: map[string]string{
+				"SR_OWNER":          "other",
+				"SR_PROJECT_NUMBER": "5",
+				"SR_TOKEN":          "secret",
+				"SR_FORMATS":        "markdown, html,",
+			},
+			expect: Config{
+				Owner:   "other",
+				Project: 5,
+				Token:   "secret",
+				Formats: []string{"markdown", "html"},
+			},
+		}, {
+			description: "nested values",
+			env: map[string]string{
+				"SR_TUNING_WORKERS":              "4",
+				"SR_CONTRIBUTOR_SECTION_ENABLED": "true",
+				"SR_MARKDOWN_BULLET":             "*",
+				"SR_PRIORITY_LOW":                "2.5",
+			},
+			expect: Config{
+				Owner:        "org",
+				Project:      1,
+				Tuning:       Tuning{Workers: 4},
+				Contributors: Contributors{Enabled: true},
+				Markdown:     Markdown{Bullet: "*"},
+				Priority:     Priority{Low: 2.5},
+			},
+		}, {
+			description: "ignored fields",
+			env: map[string]string{
+				"SR_DEBUG":   "true",
+				"SR_VERSION": "v1",
+			},
+			expect: Config{Owner: "org", Project: 1},
+		}, {
+			description: "invalid number",
+			env:         map[string]string{"SR_PROJECT_NUMBER": "five"},
+			wantErr:     true,
+		}, {
+			description: "invalid bool",
+			env:         map[string]string{"SR_POINTS_ENABLED": "sure"},
+			wantErr:     true,
+		}, {
+			description: "lists of structures",
+			env:         map[string]string{"SR_SECTIONS": "a"},
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			cfg := Config{Owner: "org", Project: 1}
+			err := ApplyEnv(&cfg, EnvPrefix, func(key string) (string, bool) {
+				val, ok := tc.env[key]
+				return val, ok
+			})
+
+			if tc.wantErr {
+				assert.Error(err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tc.expect, cfg)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	gql "github.com/hasura/go-graphql-client"
+)
+
+// APIError is a single error returned by the github graphql api.
+type APIError struct {
+	Type    string   // The github error type, like NOT_FOUND.
+	Path    []string // The path in the query the error applies to.
+	Message string
+	Hint    string // A suggestion for fixing the problem, if one is known.
+}
+
+// Error implements the error interface.
+func (e APIError) Error() string {
+	var buf strings.Builder
+
+	if len(e.Type) > 0 {
+		buf.WriteString(e.Type)
+		if len(e.Path) > 0 {
+			fmt.Fprintf(&buf, " at %s", strings.Join(e.Path, "."))
+		}
+		buf.WriteString(": ")
+	}
+	buf.WriteString(e.Message)
+	if len(e.Hint) > 0 {
+		fmt.Fprintf(&buf, "\n  hint: %s", e.Hint)
+	}
+
+	return buf.String()
+}
+
+// APIErrors are all the errors returned by the github graphql api for a query.
+type APIErrors []APIError
+
+// Error implements the error interface.
+func (e APIErrors) Error() string {
+	list := make([]string, 0, len(e))
+	for _, err := range e {
+		list = append(list, err.Error())
+	}
+	return "github api error: " + strings.Join(list, "\n")
+}
+
+// errorHints are the hints for known problems, matched by the error type or a
+// fragment of the message.
+var errorHints = []struct {
+	match string
+	hint  string
+}{
+	{
+		match: "INSUFFICIENT_SCOPES",
+		hint:  "the token needs the 'read:project' scope, or 'project' to archive items",
+	}, {
+		match: "SAML",
+		hint:  "the token must be authorized for SAML single sign-on with the organization",
+	}, {
+		match: "NOT_FOUND",
+		hint:  "check the owner and project_number settings and that the token can see the project",
+	}, {
+		match: "401 Unauthorized",
+		hint:  "the token is missing, expired or has been revoked",
+	}, {
+		match: "RATE_LIMITED",
+		hint:  "the api rate limit was exceeded, wait before trying again",
+	}, {
+		match: "FORBIDDEN",
+		hint:  "the token does not have permission for this operation",
+	},
+}
+
+// hintFor returns the hint for the error type and message, or an empty string.
+func hintFor(typ, msg string) string {
+	for _, h := range errorHints {
+		if typ == h.match || strings.Contains(msg, h.match) {
+			return h.hint
+		}
+	}
+	return ""
+}
+
+// ExplainError converts an error from the graphql client into APIErrors with
+// the github error type, path and any known hints.  Other errors are returned
+// unchanged.
+func ExplainError(err error) error {
+	var errs gql.Errors
+	if !errors.As(err, &errs) || len(errs) == 0 {
+		return err
+	}
+
+	rv := rawErrors(errs)
+	if len(rv) == 0 {
+		for _, e := range errs {
+			rv = append(rv, APIError{Message: e.Message})
+		}
+	}
+
+	for i := range rv {
+		rv[i].Hint = hintFor(rv[i].Type, rv[i].Message)
+	}
+
+	return rv
+}
+
+// rawErrors extracts the errors from the response body the client keeps when
+// debugging is enabled.  The client drops the github specific type and path
+// otherwise.
+func rawErrors(errs gql.Errors) APIErrors {
+	for _, e := range errs {
+		internal, _ := e.Extensions["internal"].(map[string]any)
+		response, _ := internal["response"].(map[string]any)
+		body, _ := response["body"].(string)
+		if len(body) == 0 {
+			continue
+		}
+
+		var raw struct {
+			Errors []struct {
+				Type    string
+				Path    []any
+				Message string
+			}
+		}
+		if json.Unmarshal([]byte(body), &raw) != nil || len(raw.Errors) == 0 {
+			continue
+		}
+
+		rv := make(APIErrors, 0, len(raw.Errors))
+		for _, r := range raw.Errors {
+			path := make([]string, 0, len(r.Path))
+			for _, p := range r.Path {
+				path = append(path, fmt.Sprint(p))
+			}
+			rv = append(rv, APIError{
+				Type:    r.Type,
+				Path:    path,
+				Message: r.Message,
+			})
+		}
+		return rv
+	}
+
+	return nil
+}
+
+// authErrors are the error types and message fragments of github refusing the
+// token.
+var authErrors = []string{"INSUFFICIENT_SCOPES", "FORBIDDEN", "SAML", "401 Unauthorized", "Bad credentials"}
+
+// IsAPIError returns if the error was returned by the github graphql api.
+func IsAPIError(err error) bool {
+	var errs APIErrors
+	var gerrs gql.Errors
+	return errors.As(err, &errs) || errors.As(err, &gerrs)
+}
+
+// IsAuthError returns if the error is the github graphql api refusing the
+// token, because it is not valid or lacks the permissions.
+func IsAuthError(err error) bool {
+	var errs APIErrors
+	if !errors.As(ExplainError(err), &errs) {
+		return false
+	}
+	for _, e := range errs {
+		for _, match := range authErrors {
+			if e.Type == match || strings.Contains(e.Message, match) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gql "github.com/hasura/go-graphql-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainError(t *testing.T) {
+	const notFound = `{"data": {"organization": {"projectV2": null}}, "errors": [{"type": "NOT_FOUND", "path": ["organization", "projectV2"], "locations": [{"line": 1, "column": 2}], "message": "Could not resolve to a ProjectV2 with the number 9."}]}`
+	const saml = `{"data": null, "errors": [{"type": "FORBIDDEN", "path": ["organization"], "message": "Resource protected by organization SAML enforcement."}]}`
+
+	tests := []struct {
+		description string
+		response    string
+		status      int
+		debug       bool
+		auth        bool
+		expect      APIErrors
+	}{
+		{
+			description: "not found with debug",
+			response:    notFound,
+			debug:       true,
+			expect: APIErrors{{
+				Type:    "NOT_FOUND",
+				Path:    []string{"organization", "projectV2"},
+				Message: "Could not resolve to a ProjectV2 with the number 9.",
+				Hint:    hintFor("NOT_FOUND", ""),
+			}},
+		}, {
+			description: "not found without debug",
+			response:    notFound,
+			expect: APIErrors{{
+				Message: "Could not resolve to a ProjectV2 with the number 9.",
+			}},
+		}, {
+			description: "saml enforcement",
+			response:    saml,
+			debug:       true,
+			auth:        true,
+			expect: APIErrors{{
+				Type:    "FORBIDDEN",
+				Path:    []string{"organization"},
+				Message: "Resource protected by organization SAML enforcement.",
+				Hint:    hintFor("", "SAML"),
+			}},
+		}, {
+			description: "bad token",
+			response:    `{"message": "Bad credentials"}`,
+			status:      http.StatusUnauthorized,
+			debug:       true,
+			auth:        true,
+			expect: APIErrors{{
+				Message: `401 Unauthorized; body: "{\"message\": \"Bad credentials\"}\n"`,
+				Hint:    hintFor("", "401 Unauthorized"),
+			}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				io.Copy(io.Discard, r.Body)
+				r.Body.Close()
+				if tc.status != 0 {
+					w.WriteHeader(tc.status)
+				}
+				fmt.Fprintln(w, tc.response)
+			}))
+			defer ts.Close()
+
+			_, err := FetchProjectInfo("org", 9, gql.NewClient(ts.URL, nil).WithDebug(tc.debug))
+			require.Error(err)
+			assert.True(IsAPIError(err))
+			assert.Equal(tc.auth, IsAuthError(fmt.Errorf("fetching: %w", err)))
+
+			err = ExplainError(err)
+			var got APIErrors
+			require.True(errors.As(err, &got))
+			assert.Equal(tc.expect, got)
+			if len(tc.expect[0].Hint) > 0 {
+				assert.Contains(err.Error(), "hint: "+tc.expect[0].Hint)
+			}
+		})
+	}
+
+	t.Run("other errors", func(t *testing.T) {
+		other := errors.New("other")
+		assert.Equal(t, other, ExplainError(other))
+		assert.Nil(t, ExplainError(nil))
+		assert.False(t, IsAPIError(other))
+		assert.False(t, IsAuthError(other))
+	})
+}
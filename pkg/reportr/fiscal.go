@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultFiscalLabel is the fiscal label used when fiscal.label is not set.
+// The week is zero padded so the labels sort in order.
+const DefaultFiscalLabel = `FY{{.ShortYear}}Q{{.Quarter}}-W{{printf "%02d" .Week}}`
+
+// FiscalPeriod is the fiscal quarter and week a day falls in, available to the
+// fiscal label template.
+type FiscalPeriod struct {
+	Year      int    // The fiscal year, named by the calendar year it ends in.
+	ShortYear string // The last two digits of the fiscal year.
+	Quarter   int    // The quarter of the fiscal year, 1 to 4.
+	Week      int    // The week of the quarter, starting at 1.
+}
+
+// Period returns the fiscal period of the day.
+func (f Fiscal) Period(day time.Time) FiscalPeriod {
+	first := f.StartMonth
+	if first < 1 || first > 12 {
+		first = 1
+	}
+
+	day = day.UTC()
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	months := (int(day.Month()) - first + 12) % 12
+
+	year := day.Year()
+	if first > 1 && int(day.Month()) >= first {
+		year++
+	}
+
+	// The quarter starts on the first of its first month.
+	start := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -(months % 3), 0)
+
+	return FiscalPeriod{
+		Year:      year,
+		ShortYear: fmt.Sprintf("%02d", year%100),
+		Quarter:   months/3 + 1,
+		Week:      int(day.Sub(start)/(24*time.Hour))/7 + 1,
+	}
+}
+
+// Label returns the fiscal label of the day, like FY25Q2-W03.
+func (f Fiscal) Label(cfg Config, day time.Time) (string, error) {
+	text := f.LabelTemplate
+	if len(strings.TrimSpace(text)) == 0 {
+		text = DefaultFiscalLabel
+	}
+	tmpl, err := parseTemplate(cfg, "fiscal.label", text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf strings.Builder
+	if err = tmpl.Execute(&buf, f.Period(day)); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
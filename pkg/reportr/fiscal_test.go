@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFiscal(t *testing.T) {
+	tests := []struct {
+		description string
+		fiscal      Fiscal
+		day         string
+		expect      FiscalPeriod
+		label       string
+	}{
+		{
+			description: "calendar year",
+			fiscal:      Fiscal{Enabled: true, StartMonth: 1},
+			day:         "2024-05-20T12:00:00Z",
+			expect:      FiscalPeriod{Year: 2024, ShortYear: "24", Quarter: 2, Week: 8},
+			label:       "FY24Q2-W08",
+		}, {
+			description: "first day of the fiscal year",
+			fiscal:      Fiscal{Enabled: true, StartMonth: 10},
+			day:         "2024-10-01T00:00:00Z",
+			expect:      FiscalPeriod{Year: 2025, ShortYear: "25", Quarter: 1, Week: 1},
+			label:       "FY25Q1-W01",
+		}, {
+			description: "before the fiscal year start month",
+			fiscal:      Fiscal{Enabled: true, StartMonth: 10},
+			day:         "2025-01-15T00:00:00Z",
+			expect:      FiscalPeriod{Year: 2025, ShortYear: "25", Quarter: 2, Week: 3},
+			label:       "FY25Q2-W03",
+		}, {
+			description: "custom label",
+			fiscal:      Fiscal{Enabled: true, StartMonth: 7, LabelTemplate: "{{.Year}}-Q{{.Quarter}} week {{.Week}}"},
+			day:         "2022-06-30T00:00:00Z",
+			expect:      FiscalPeriod{Year: 2022, ShortYear: "22", Quarter: 4, Week: 13},
+			label:       "2022-Q4 week 13",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			assert.Equal(tc.expect, tc.fiscal.Period(mustParseTime(tc.day)))
+
+			cfg := Config{Fiscal: tc.fiscal}
+			label, err := tc.fiscal.Label(cfg, mustParseTime(tc.day))
+			require.NoError(err)
+			assert.Equal(tc.label, label)
+			assert.Equal(tc.label, ReportBasename(cfg, WeeklyItems{Start: mustParseTime(tc.day)}))
+		})
+	}
+}
+
+func TestFiscalBadLabel(t *testing.T) {
+	cfg := Config{Fiscal: Fiscal{Enabled: true, LabelTemplate: "{{.Missing}}"}}
+	assert.Error(t, cfg.CheckTemplates())
+}
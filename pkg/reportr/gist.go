@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// gist publishes the latest report of the team as a github gist.  The gist is
+// found by its description, so the same gist is updated every week.
+type gist struct {
+	url    string // The github rest api url, if not derived from the config.
+	public bool
+
+	lock   sync.Mutex
+	latest time.Time // The start of the newest week published.
+}
+
+func newGistDeliverer(d Delivery) (Deliverer, error) {
+	return &gist{url: strings.TrimSuffix(d.Endpoint, "/"), public: d.Public}, nil
+}
+
+// restURL returns the github rest api url based on the graphql api url.
+func (g *gist) restURL(cfg Config) string {
+	if len(g.url) > 0 {
+		return g.url
+	}
+	return githubRestURL(cfg)
+}
+
+// githubRestURL returns the github rest api url based on the graphql api url.
+func githubRestURL(cfg Config) string {
+	u, err := url.Parse(cfg.Url)
+	if err != nil || len(u.Host) == 0 || u.Host == "api.github.com" {
+		return "https://api.github.com"
+	}
+	return fmt.Sprintf("%s://%s/api/v3", u.Scheme, u.Host)
+}
+
+type gistFile struct {
+	Content string `json:"content"`
+}
+
+type gistBody struct {
+	ID          string              `json:"id,omitempty"`
+	Description string              `json:"description"`
+	Public      *bool               `json:"public,omitempty"`
+	Files       map[string]gistFile `json:"files,omitempty"`
+}
+
+// Deliver publishes the report if it is for the newest week seen.  The reports
+// of older weeks are skipped so the gist always holds the latest report.
+func (g *gist) Deliver(cfg Config, r Report) error {
+	g.lock.Lock()
+	if r.Week.Start.Before(g.latest) {
+		g.lock.Unlock()
+		return nil
+	}
+	g.latest = r.Week.Start
+	g.lock.Unlock()
+
+	api := g.restURL(cfg)
+	do := func(method, path string, in, out any) error {
+		return doJSON("gist", method, api+path, in, out, func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+cfg.Token)
+		})
+	}
+
+	desc := fmt.Sprintf("%s: %s", cfg.Locale.T("status_report"), cfg.Team)
+	body := gistBody{
+		Description: desc,
+		Files: map[string]gistFile{
+			"status-report" + filepath.Ext(r.Path): {Content: string(r.Data)},
+		},
+	}
+
+	var list []gistBody
+	if err := do(http.MethodGet, "/gists?per_page=100", nil, &list); err != nil {
+		return err
+	}
+	for _, existing := range list {
+		if existing.Description == desc {
+			if err := do(http.MethodPatch, "/gists/"+existing.ID, body, nil); err != nil {
+				return err
+			}
+			logf("Updated the gist '%s'", desc)
+			return nil
+		}
+	}
+
+	body.Public = &g.public
+	if err := do(http.MethodPost, "/gists", body, nil); err != nil {
+		return err
+	}
+	logf("Created the gist '%s'", desc)
+	return nil
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGistDeliverer(t *testing.T) {
+	tests := []struct {
+		description string
+		list        string
+		public      bool
+		expect      string
+	}{
+		{
+			description: "create a secret gist",
+			list:        `[{"id":"1","description":"Something else"}]`,
+			expect:      "POST /gists",
+		}, {
+			description: "create a public gist",
+			list:        `[]`,
+			public:      true,
+			expect:      "POST /gists",
+		}, {
+			description: "update the team gist",
+			list:        `[{"id":"1","description":"Something else"},{"id":"2","description":"Status Report: Team"}]`,
+			expect:      "PATCH /gists/2",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			var requests []string
+			var body gistBody
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal("Bearer token", r.Header.Get("Authorization"))
+				if r.Method == http.MethodGet {
+					_, _ = w.Write([]byte(tc.list))
+					return
+				}
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				assert.NoError(json.NewDecoder(r.Body).Decode(&body))
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			d, err := NewDeliverer(Delivery{Type: "gist", Endpoint: server.URL, Public: tc.public})
+			require.NoError(err)
+
+			cfg := Config{Team: "Team", Token: "token"}
+			newest := WeeklyItems{Start: mustParseTime("2022-11-27T00:00:00Z")}
+			older := WeeklyItems{Start: mustParseTime("2022-11-20T00:00:00Z")}
+
+			require.NoError(d.Deliver(cfg, Report{Week: newest, Path: "out/2022-W48.md", Data: []byte("new")}))
+			require.NoError(d.Deliver(cfg, Report{Week: older, Path: "out/2022-W47.md", Data: []byte("old")}))
+
+			assert.Equal([]string{tc.expect}, requests)
+			assert.Equal("Status Report: Team", body.Description)
+			assert.Equal(map[string]gistFile{"status-report.md": {Content: "new"}}, body.Files)
+			if tc.expect == "POST /gists" {
+				require.NotNil(body.Public)
+				assert.Equal(tc.public, *body.Public)
+			} else {
+				assert.Nil(body.Public)
+			}
+		})
+	}
+}
+
+func TestGistRestURL(t *testing.T) {
+	assert := assert.New(t)
+
+	g := &gist{}
+	assert.Equal("https://api.github.com", g.restURL(Config{Url: "https://api.github.com/graphql"}))
+	assert.Equal("https://ghe.example.com/api/v3", g.restURL(Config{Url: "https://ghe.example.com/api/graphql"}))
+}
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/ryanuber/go-glob"
+)
+
+// LegacyGlobs limits the patterns to the simple globs where only '*' is
+// special, like before the doublestar patterns were supported.  It is set from
+// the configuration.
+var LegacyGlobs bool
+
+// globMatch returns if the value matches the pattern.  The pattern is a simple
+// glob where '*' matches anything, including '/', or a doublestar pattern
+// supporting '?', character classes like [a-z] and alternation like {a,b}.
+// The "[bot]" suffix of the bot logins is never a character class, so patterns
+// like "*[bot]" keep matching only the bots.  Both the pattern and the value
+// are normalized first.
+func globMatch(pattern, value string) bool {
+	pattern = NormalizeText(pattern)
+	value = NormalizeText(value)
+	if glob.Glob(pattern, value) {
+		return true
+	}
+	if LegacyGlobs {
+		return false
+	}
+	pattern = strings.ReplaceAll(pattern, "[bot]", `\[bot\]`)
+	ok, err := doublestar.Match(pattern, value)
+	return err == nil && ok
+}
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		value   string
+		expect  bool
+		legacy  bool
+	}{
+		{pattern: "ci", value: "ci", expect: true, legacy: true},
+		{pattern: "area/*", value: "area/build", expect: true, legacy: true},
+		{pattern: "Fix*", value: "Fix: the a/b path", expect: true, legacy: true},
+		{pattern: "*[bot]", value: "dependabot[bot]", expect: true, legacy: true},
+		{pattern: "dependabot[bot]", value: "dependabot[bot]", expect: true, legacy: true},
+		{pattern: "*[bot]", value: "octocat"},
+		{pattern: "{dependabot,renovate}[bot]", value: "renovate[bot]", expect: true},
+		{pattern: "{ci,build}", value: "build", expect: true},
+		{pattern: "area/{ci,build}", value: "area/docs"},
+		{pattern: "v[0-9]", value: "v2", expect: true},
+		{pattern: "v[!0-9]", value: "v2"},
+		{pattern: "p?", value: "p1", expect: true},
+		{pattern: "org/**", value: "org/repo/pull/1", expect: true, legacy: true},
+		{pattern: "[", value: "x"},
+		{pattern: "bug", value: "bugs"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.pattern+" "+tc.value, func(t *testing.T) {
+			assert := assert.New(t)
+			defer func() { LegacyGlobs = false }()
+
+			assert.Equal(tc.expect, globMatch(tc.pattern, tc.value))
+
+			LegacyGlobs = true
+			assert.Equal(tc.legacy, globMatch(tc.pattern, tc.value))
+		})
+	}
+}
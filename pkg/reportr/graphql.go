@@ -0,0 +1,1248 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gql "github.com/hasura/go-graphql-client"
+	"golang.org/x/oauth2"
+)
+
+// -----------------------------------------------------------------------------
+//
+// All the data structures below this line are Graphql focused & are designed:
+//
+//	- to match the structure of the data & query
+//  - to be short lived and coverted into one of the structures above
+//
+// -----------------------------------------------------------------------------
+
+// FieldCommon is a graphql focused structure for collecting the data.
+type FieldCommon struct {
+	Ignored struct {
+		Name string
+	} `graphql:"... on ProjectV2FieldCommon"`
+}
+
+// FieldTextValue is a graphql focused structure for collecting text field data.
+type FieldTextValue struct {
+	Field *FieldCommon
+	Text  *string
+}
+
+// Get returns a simplified Field struct version of this object.
+func (v FieldTextValue) Get() Field {
+	if v.Field == nil || v.Text == nil {
+		return Field{}
+	}
+	return Field{
+		Type: FIELD_TEXT,
+		Name: v.Field.Ignored.Name,
+		Text: *v.Text,
+	}
+}
+
+// FieldDateValue is a graphql focused structure for collecting date field data.
+type FieldDateValue struct {
+	Field *FieldCommon
+	Date  *time.Time
+}
+
+// Get returns a simplified Field struct version of this object.
+func (v FieldDateValue) Get() Field {
+	if v.Field == nil || v.Date == nil {
+		return Field{}
+	}
+	return Field{
+		Type: FIELD_DATE,
+		Name: v.Field.Ignored.Name,
+		Date: *v.Date,
+	}
+}
+
+// FieldNumberValue is a graphql focused structure for collecting date field data.
+type FieldNumberValue struct {
+	Field  *FieldCommon
+	Number *float64
+}
+
+// Get returns a simplified Field struct version of this object.
+func (v FieldNumberValue) Get() Field {
+	if v.Field == nil || v.Number == nil {
+		return Field{}
+	}
+	return Field{
+		Type:   FIELD_NUMBER,
+		Name:   v.Field.Ignored.Name,
+		Number: *v.Number,
+	}
+}
+
+// FieldSingleSelectValue is a graphql focused structure for collecting date field data.
+type FieldSingleSelectValue struct {
+	Field *FieldCommon
+	Name  *string
+}
+
+// Get returns a simplified Field struct version of this object.
+func (v FieldSingleSelectValue) Get() Field {
+	if v.Field == nil || v.Name == nil {
+		return Field{}
+	}
+	return Field{
+		Type: FIELD_TEXT,
+		Name: v.Field.Ignored.Name,
+		Text: *v.Name,
+	}
+}
+
+// FieldIterationValue is a graphql focused structure for collecting date field data.
+type FieldIterationValue struct {
+	Field       *FieldCommon
+	Duration    int // days
+	IterationId string
+	StartDate   *time.Time
+	Title       string
+}
+
+// Get returns a simplified Field struct version of this object.
+func (v FieldIterationValue) Get() Field {
+	if v.Field == nil || v.StartDate == nil {
+		return Field{}
+	}
+	return Field{
+		Type:        FIELD_ITERATION,
+		Name:        v.Field.Ignored.Name,
+		Duration:    time.Hour * 24 * time.Duration(v.Duration),
+		IterationId: v.IterationId,
+		StartDate:   *v.StartDate,
+		Title:       v.Title,
+	}
+}
+
+// FieldUserValue is a graphql focused structure for collecting people field
+// data.  The users are fetched with the same count as labels.
+type FieldUserValue struct {
+	Field *FieldCommon
+	Users *struct {
+		Nodes []struct {
+			Login string
+		}
+		PageInfo struct {
+			HasNextPage bool
+		}
+	} `graphql:"users(first: $labelCount)"`
+}
+
+// Get returns a simplified Field struct version of this object.
+func (v FieldUserValue) Get() Field {
+	if v.Field == nil || v.Users == nil {
+		return Field{}
+	}
+	users := make([]string, 0, len(v.Users.Nodes))
+	for _, u := range v.Users.Nodes {
+		users = append(users, u.Login)
+	}
+	return Field{
+		Type:  FIELD_USERS,
+		Name:  v.Field.Ignored.Name,
+		Users: users,
+	}
+}
+
+// FieldRepositoryValue is a graphql focused structure for collecting
+// repository field data.
+type FieldRepositoryValue struct {
+	Field      *FieldCommon
+	Repository *struct {
+		NameWithOwner string
+		URL           string
+	}
+}
+
+// Get returns a simplified Field struct version of this object.
+func (v FieldRepositoryValue) Get() Field {
+	if v.Field == nil || v.Repository == nil {
+		return Field{}
+	}
+	return Field{
+		Type: FIELD_REPOSITORY,
+		Name: v.Field.Ignored.Name,
+		Text: v.Repository.NameWithOwner,
+		URL:  v.Repository.URL,
+	}
+}
+
+// FieldPullRequestValue is a graphql focused structure for collecting linked
+// pull request field data.  The pull requests are fetched with the same count
+// as labels.
+type FieldPullRequestValue struct {
+	Field        *FieldCommon
+	PullRequests *struct {
+		Nodes []struct {
+			URL string
+		}
+		PageInfo struct {
+			HasNextPage bool
+		}
+	} `graphql:"pullRequests(first: $labelCount)"`
+}
+
+// Get returns a simplified Field struct version of this object.
+func (v FieldPullRequestValue) Get() Field {
+	if v.Field == nil || v.PullRequests == nil {
+		return Field{}
+	}
+	urls := make([]string, 0, len(v.PullRequests.Nodes))
+	for _, pr := range v.PullRequests.Nodes {
+		urls = append(urls, pr.URL)
+	}
+	return Field{
+		Type:         FIELD_PULL_REQUESTS,
+		Name:         v.Field.Ignored.Name,
+		PullRequests: urls,
+	}
+}
+
+// Label is a graphql focused structure for collecting date field data.
+type Label struct {
+	Name string
+}
+
+// FieldLabelValue is a graphql focused structure for collecting date field data.
+type FieldLabelValue struct {
+	Labels struct {
+		Nodes    []Label
+		PageInfo struct {
+			HasNextPage bool
+		}
+	} `graphql:"labels(first: $labelCount)"`
+}
+
+// FieldValueCommon is a graphql focused structure for collecting the field name
+// of any value, including the types that are not modeled.
+type FieldValueCommon struct {
+	Field *FieldCommon
+}
+
+// modeledFieldValues are the graphql types of the field values converted into
+// Fields or labels.
+var modeledFieldValues = map[string]bool{
+	"ProjectV2ItemFieldDateValue":         true,
+	"ProjectV2ItemFieldIterationValue":    true,
+	"ProjectV2ItemFieldLabelValue":        true,
+	"ProjectV2ItemFieldNumberValue":       true,
+	"ProjectV2ItemFieldSingleSelectValue": true,
+	"ProjectV2ItemFieldTextValue":         true,
+	"ProjectV2ItemFieldUserValue":         true,
+	"ProjectV2ItemFieldRepositoryValue":   true,
+	"ProjectV2ItemFieldPullRequestValue":  true,
+}
+
+// Author is a graphql focused structure for collecting the author data.
+type Author struct {
+	Login    string
+	Typename string `graphql:"__typename"`
+}
+
+// Get returns the login of the author.  Bot logins are suffixed with "[bot]"
+// to match how they are presented elsewhere in github.
+func (a Author) Get() string {
+	if a.Typename == "Bot" && len(a.Login) > 0 {
+		return a.Login + "[bot]"
+	}
+	return a.Login
+}
+
+// IssueRef is a graphql focused structure for collecting a reference to
+// another issue.
+type IssueRef struct {
+	Number int
+	Title  string
+	URL    string
+}
+
+// Issue is a graphql focused structure for collecting date field data.
+type Issue struct {
+	Issue struct {
+		ClosedAt        *time.Time
+		Number          int
+		URL             string
+		BodyText        string
+		Author          Author
+		Parent          *IssueRef
+		TrackedInIssues struct {
+			Nodes []IssueRef
+		} `graphql:"trackedInIssues(first: 1)"`
+		Repository struct {
+			Name          string
+			NameWithOwner string
+			URL           string
+			IsPrivate     bool
+		}
+	} `graphql:"... on Issue"`
+}
+
+// GetParent returns the parent of the issue, preferring the sub-issue parent
+// over the issue it is tracked in.  Nil is returned if there is no parent.
+func (i Issue) GetParent() *Parent {
+	ref := i.Issue.Parent
+	if ref == nil && len(i.Issue.TrackedInIssues.Nodes) > 0 {
+		ref = &i.Issue.TrackedInIssues.Nodes[0]
+	}
+	if ref == nil || len(ref.URL) == 0 {
+		return nil
+	}
+	return &Parent{
+		Number: ref.Number,
+		Title:  ref.Title,
+		URL:    ref.URL,
+	}
+}
+
+// PullReview is a graphql focused structure for collecting the reviews of a
+// pull request.  The reviews are fetched with the same count as labels.
+type PullReview struct {
+	Nodes []struct {
+		Author      Author
+		State       string
+		SubmittedAt *time.Time
+	}
+	PageInfo struct {
+		HasNextPage bool
+	}
+}
+
+// Get returns the submitted reviews.
+func (r PullReview) Get() []Review {
+	var rv []Review
+	for _, n := range r.Nodes {
+		if n.SubmittedAt == nil {
+			continue
+		}
+		rv = append(rv, Review{
+			Author:      n.Author.Get(),
+			State:       n.State,
+			SubmittedAt: *n.SubmittedAt,
+		})
+	}
+	return rv
+}
+
+// PullRequest is a graphql focused structure for collecting date field data.
+type PullRequest struct {
+	PullRequest struct {
+		CreatedAt   *time.Time
+		ClosedAt    *time.Time
+		MergedAt    *time.Time
+		IsDraft     bool
+		Number      int
+		URL         string
+		BaseRefName string
+		BodyText    string
+		Author      Author
+		Repository  struct {
+			Name          string
+			NameWithOwner string
+			URL           string
+			IsPrivate     bool
+		}
+		Reviews PullReview `graphql:"reviews(first: $labelCount)"`
+	} `graphql:"... on PullRequest"`
+}
+
+// GqlItem is a graphql focused structure for collecting date field data.
+type GqlItem struct {
+	ID          string
+	IsArchived  bool
+	FieldValues struct {
+		Nodes []struct {
+			Typename       string                 `graphql:"__typename"`
+			Common         FieldValueCommon       `graphql:"... on ProjectV2ItemFieldValueCommon"`
+			DateValue      FieldDateValue         `graphql:"... on ProjectV2ItemFieldDateValue"`
+			IterationValue FieldIterationValue    `graphql:"... on ProjectV2ItemFieldIterationValue"`
+			Labels         FieldLabelValue        `graphql:"... on ProjectV2ItemFieldLabelValue"`
+			NumberValue    FieldNumberValue       `graphql:"... on ProjectV2ItemFieldNumberValue"`
+			SelectValue    FieldSingleSelectValue `graphql:"... on ProjectV2ItemFieldSingleSelectValue"`
+			TextValue      FieldTextValue         `graphql:"... on ProjectV2ItemFieldTextValue"`
+			UserValue      FieldUserValue         `graphql:"... on ProjectV2ItemFieldUserValue"`
+			RepoValue      FieldRepositoryValue   `graphql:"... on ProjectV2ItemFieldRepositoryValue"`
+			PRValue        FieldPullRequestValue  `graphql:"... on ProjectV2ItemFieldPullRequestValue"`
+		}
+		PageInfo struct {
+			HasNextPage bool
+		}
+	} `graphql:"fieldValues(first: $fieldValuesCount)"`
+	Issue Issue       `graphql:"iss:content"`
+	PR    PullRequest `graphql:"pr:content"`
+}
+
+// ErrMalformedItem is returned when an item from github is missing data that
+// is required to convert it.
+var ErrMalformedItem = errors.New("malformed item")
+
+// SkipFunc is called with the id of an item that was skipped instead of failing
+// the fetch and the reason it was skipped.
+type SkipFunc func(id string, err error)
+
+// Validate checks that the item from github has the data needed to convert it.
+func (g GqlItem) Validate() error {
+	if len(g.ID) == 0 {
+		return fmt.Errorf("%w: missing id", ErrMalformedItem)
+	}
+	if g.Issue.Issue.ClosedAt != nil && len(g.Issue.Issue.URL) == 0 {
+		return fmt.Errorf("%w: issue without a url", ErrMalformedItem)
+	}
+	if (g.PR.PullRequest.MergedAt != nil || g.PR.PullRequest.ClosedAt != nil) &&
+		len(g.PR.PullRequest.URL) == 0 {
+		return fmt.Errorf("%w: pull request without a url", ErrMalformedItem)
+	}
+	for _, n := range g.FieldValues.Nodes {
+		for _, f := range []*FieldCommon{
+			n.DateValue.Field,
+			n.IterationValue.Field,
+			n.NumberValue.Field,
+			n.SelectValue.Field,
+			n.TextValue.Field,
+			n.UserValue.Field,
+			n.RepoValue.Field,
+			n.PRValue.Field,
+		} {
+			if f != nil && len(f.Ignored.Name) == 0 {
+				return fmt.Errorf("%w: field value without a field name", ErrMalformedItem)
+			}
+		}
+	}
+	return nil
+}
+
+// Truncated returns true if the item has more field values, labels or reviews
+// than were fetched.
+func (g GqlItem) Truncated() bool {
+	if g.FieldValues.PageInfo.HasNextPage || g.PR.PullRequest.Reviews.PageInfo.HasNextPage {
+		return true
+	}
+	for _, n := range g.FieldValues.Nodes {
+		if n.Labels.Labels.PageInfo.HasNextPage ||
+			(n.UserValue.Users != nil && n.UserValue.Users.PageInfo.HasNextPage) ||
+			(n.PRValue.PullRequests != nil && n.PRValue.PullRequests.PageInfo.HasNextPage) {
+			return true
+		}
+	}
+	return false
+}
+
+// ToClean takes an item from github and normalizes it into the simplified Item
+// structure.
+func (g GqlItem) ToClean() Item {
+	rv := Item{
+		ID:       g.ID,
+		Archived: g.IsArchived,
+		Fields:   make(map[string]Field, len(g.FieldValues.Nodes)),
+	}
+
+	if g.Issue.Issue.ClosedAt != nil {
+		rv.DoneAt = *g.Issue.Issue.ClosedAt
+		rv.ItemType = "ISSUE"
+		rv.Number = g.Issue.Issue.Number
+		rv.URL = g.Issue.Issue.URL
+		rv.Body = g.Issue.Issue.BodyText
+		rv.Author = g.Issue.Issue.Author.Get()
+		rv.Parent = g.Issue.GetParent()
+		rv.Repo.Name = g.Issue.Issue.Repository.Name
+		rv.Repo.Slug = g.Issue.Issue.Repository.NameWithOwner
+		rv.Repo.URL = g.Issue.Issue.Repository.URL
+		rv.Private = g.Issue.Issue.Repository.IsPrivate
+	}
+	if g.PR.PullRequest.MergedAt != nil || g.PR.PullRequest.ClosedAt != nil {
+		if g.PR.PullRequest.MergedAt != nil {
+			rv.DoneAt = *g.PR.PullRequest.MergedAt
+		} else {
+			rv.DoneAt = *g.PR.PullRequest.ClosedAt
+			rv.Abandoned = true
+		}
+		rv.ItemType = "PR"
+		rv.Draft = g.PR.PullRequest.IsDraft
+		rv.Number = g.PR.PullRequest.Number
+		rv.URL = g.PR.PullRequest.URL
+		rv.Body = g.PR.PullRequest.BodyText
+		rv.Author = g.PR.PullRequest.Author.Get()
+		rv.Repo.Name = g.PR.PullRequest.Repository.Name
+		rv.Repo.Slug = g.PR.PullRequest.Repository.NameWithOwner
+		rv.Repo.URL = g.PR.PullRequest.Repository.URL
+		rv.Private = g.PR.PullRequest.Repository.IsPrivate
+		rv.Repo.Branch = g.PR.PullRequest.BaseRefName
+		if g.PR.PullRequest.CreatedAt != nil {
+			rv.CreatedAt = *g.PR.PullRequest.CreatedAt
+		}
+		rv.Reviews = g.PR.PullRequest.Reviews.Get()
+	}
+
+	for _, n := range g.FieldValues.Nodes {
+		f := n.DateValue.Get()
+		if f.Type != FIELD_EMPTY {
+			rv.Fields[f.Name] = f
+		}
+		f = n.IterationValue.Get()
+		if f.Type != FIELD_EMPTY {
+			rv.Fields[f.Name] = f
+		}
+		f = n.NumberValue.Get()
+		if f.Type != FIELD_EMPTY {
+			rv.Fields[f.Name] = f
+		}
+		f = n.SelectValue.Get()
+		if f.Type != FIELD_EMPTY {
+			rv.Fields[f.Name] = f
+		}
+		f = n.TextValue.Get()
+		if f.Type != FIELD_EMPTY {
+			rv.Fields[f.Name] = f
+		}
+		f = n.UserValue.Get()
+		if f.Type != FIELD_EMPTY {
+			rv.Fields[f.Name] = f
+		}
+		f = n.RepoValue.Get()
+		if f.Type != FIELD_EMPTY {
+			rv.Fields[f.Name] = f
+		}
+		f = n.PRValue.Get()
+		if f.Type != FIELD_EMPTY {
+			rv.Fields[f.Name] = f
+		}
+
+		for _, l := range n.Labels.Labels.Nodes {
+			rv.Labels = append(rv.Labels, l.Name)
+		}
+
+		// The values github added since the query was written are kept track
+		// of so the data being dropped can be reported.
+		if len(n.Typename) > 0 && !modeledFieldValues[n.Typename] {
+			d := DroppedField{Type: n.Typename}
+			if n.Common.Field != nil {
+				d.Name = n.Common.Field.Ignored.Name
+			}
+			rv.Dropped = append(rv.Dropped, d)
+		}
+	}
+
+	return rv
+}
+
+// Login creates a graphql client for the configured github url using the
+// configured token.  The responses are recorded to or replayed from the
+// configured directories, and every request is traced to the configured file.
+func Login(cfg Config) *gql.Client {
+	if len(cfg.Replay) > 0 {
+		client := &http.Client{Transport: replayer{dir: cfg.Replay}}
+		if len(cfg.Trace) > 0 {
+			client.Transport = tracer{file: cfg.Trace, token: cfg.Token, next: client.Transport}
+		}
+		return gql.NewClient(cfg.Url, client)
+	}
+
+	src := oauth2.StaticTokenSource(
+		&oauth2.Token{AccessToken: cfg.Token},
+	)
+	client := oauth2.NewClient(context.Background(), src)
+	if len(cfg.Record) > 0 {
+		client.Transport = recorder{dir: cfg.Record, next: client.Transport}
+	}
+	if len(cfg.Trace) > 0 {
+		client.Transport = tracer{file: cfg.Trace, token: cfg.Token, next: client.Transport}
+	}
+
+	return gql.NewClient(cfg.Url, client)
+}
+
+// FetchProjectInfo uses the configuration provided owner/org and project number
+// and gets the id to use.
+func FetchProjectInfo(owner string, project int, client *gql.Client) (string, error) {
+	vars := map[string]any{
+		"owner":  owner,
+		"number": project,
+	}
+	var query struct {
+		Organization struct {
+			ProjectV2 struct {
+				Id string
+			} `graphql:"projectV2(number: $number)"`
+		} `graphql:"organization(login: $owner)"`
+	}
+
+	if err := client.Query(context.Background(), &query, vars); err != nil {
+		return "", err
+	}
+
+	return query.Organization.ProjectV2.Id, nil
+}
+
+// FetchProjectWritable returns if the token can update the project, which is
+// needed to archive the items.
+func FetchProjectWritable(owner string, project int, client *gql.Client) (bool, error) {
+	vars := map[string]any{
+		"owner":  owner,
+		"number": project,
+	}
+	var query struct {
+		Organization struct {
+			ProjectV2 struct {
+				ViewerCanUpdate bool
+			} `graphql:"projectV2(number: $number)"`
+		} `graphql:"organization(login: $owner)"`
+	}
+
+	if err := client.Query(context.Background(), &query, vars); err != nil {
+		return false, err
+	}
+
+	return query.Organization.ProjectV2.ViewerCanUpdate, nil
+}
+
+// FetchProjects fetches all the open projects owned by the org.
+func FetchProjects(owner string, client *gql.Client, count int) ([]ProjectInfo, error) {
+	var projects []ProjectInfo
+
+	vars := map[string]any{
+		"owner": owner,
+		"count": count,
+		"after": (*string)(nil),
+	}
+
+	more := true
+	for more {
+		var query struct {
+			Organization struct {
+				ProjectsV2 struct {
+					Nodes []struct {
+						Id     string
+						Number int
+						Title  string
+						Closed bool
+					}
+					PageInfo struct {
+						HasNextPage bool
+						EndCursor   string
+					}
+				} `graphql:"projectsV2(first: $count, after: $after)"`
+			} `graphql:"organization(login: $owner)"`
+		}
+
+		if err := client.Query(context.Background(), &query, vars); err != nil {
+			return nil, err
+		}
+
+		for _, n := range query.Organization.ProjectsV2.Nodes {
+			if n.Closed {
+				continue
+			}
+			projects = append(projects, ProjectInfo{
+				ID:     n.Id,
+				Number: n.Number,
+				Title:  n.Title,
+			})
+		}
+
+		more = query.Organization.ProjectsV2.PageInfo.HasNextPage
+		vars["after"] = query.Organization.ProjectsV2.PageInfo.EndCursor
+	}
+
+	return projects, nil
+}
+
+// ProjectDetails are the descriptive details of a github project.
+type ProjectDetails struct {
+	Title            string
+	ShortDescription string
+	Readme           string
+}
+
+// FetchProjectDetails fetches the title, short description and readme of the
+// project.
+func FetchProjectDetails(owner string, project int, client *gql.Client) (ProjectDetails, error) {
+	vars := map[string]any{
+		"owner":  owner,
+		"number": project,
+	}
+	var query struct {
+		Organization struct {
+			ProjectV2 ProjectDetails `graphql:"projectV2(number: $number)"`
+		} `graphql:"organization(login: $owner)"`
+	}
+
+	if err := client.Query(context.Background(), &query, vars); err != nil {
+		return ProjectDetails{}, err
+	}
+
+	return query.Organization.ProjectV2, nil
+}
+
+// FetchStatusUpdates fetches the newest status updates posted on the project,
+// newest first.
+func FetchStatusUpdates(owner string, project int, client *gql.Client, count int) ([]StatusUpdate, error) {
+	vars := map[string]any{
+		"owner":  owner,
+		"number": project,
+		"count":  count,
+	}
+	var query struct {
+		Organization struct {
+			ProjectV2 struct {
+				StatusUpdates struct {
+					Nodes []struct {
+						Body       string
+						Status     string
+						StartDate  string
+						TargetDate string
+						CreatedAt  time.Time
+						Creator    Author
+					}
+				} `graphql:"statusUpdates(first: $count, orderBy: {field: CREATED_AT, direction: DESC})"`
+			} `graphql:"projectV2(number: $number)"`
+		} `graphql:"organization(login: $owner)"`
+	}
+
+	if err := client.Query(context.Background(), &query, vars); err != nil {
+		return nil, err
+	}
+
+	nodes := query.Organization.ProjectV2.StatusUpdates.Nodes
+	rv := make([]StatusUpdate, 0, len(nodes))
+	for _, n := range nodes {
+		rv = append(rv, StatusUpdate{
+			Body:       n.Body,
+			Status:     n.Status,
+			StartDate:  n.StartDate,
+			TargetDate: n.TargetDate,
+			Created:    n.CreatedAt,
+			Author:     n.Creator.Get(),
+		})
+	}
+
+	return rv, nil
+}
+
+// FetchBotRequests fetches the open issues of the bot repository that ask for a
+// report and were not answered yet.  The most recently updated issues are
+// checked.
+func FetchBotRequests(b Bot, client *gql.Client) ([]BotRequest, error) {
+	owner, name, ok := strings.Cut(b.Repo, "/")
+	if !ok {
+		return nil, fmt.Errorf("the bot repo '%s' is not owner/name", b.Repo)
+	}
+
+	vars := map[string]any{
+		"owner": owner,
+		"name":  name,
+	}
+	var query struct {
+		Repository struct {
+			Issues struct {
+				Nodes []struct {
+					ID     string
+					Number int
+					URL    string
+					Labels struct {
+						Nodes []struct {
+							Name string
+						}
+					} `graphql:"labels(first: 20)"`
+					Comments struct {
+						Nodes []struct {
+							Body      string
+							CreatedAt time.Time
+						}
+					} `graphql:"comments(last: 20)"`
+				}
+			} `graphql:"issues(first: 50, states: OPEN, orderBy: {field: UPDATED_AT, direction: DESC})"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	if err := client.Query(context.Background(), &query, vars); err != nil {
+		return nil, err
+	}
+
+	var rv []BotRequest
+	for _, n := range query.Repository.Issues.Nodes {
+		labels := make([]string, 0, len(n.Labels.Nodes))
+		for _, l := range n.Labels.Nodes {
+			labels = append(labels, l.Name)
+		}
+		comments := make([]botComment, 0, len(n.Comments.Nodes))
+		for _, c := range n.Comments.Nodes {
+			comments = append(comments, botComment{Body: c.Body, Created: c.CreatedAt})
+		}
+
+		if b.pending(labels, comments) {
+			rv = append(rv, BotRequest{ID: n.ID, Number: n.Number, URL: n.URL})
+		}
+	}
+
+	return rv, nil
+}
+
+// ReplyToBotRequest comments on the issue with the report.
+func ReplyToBotRequest(req BotRequest, report string, client *gql.Client) error {
+	vars := map[string]any{
+		"id":   gql.ID(req.ID),
+		"body": botReply(report),
+	}
+	var mutation struct {
+		AddComment struct {
+			ClientMutationId string
+		} `graphql:"addComment(input: {subjectId: $id, body: $body})"`
+	}
+	return client.Mutate(context.Background(), &mutation, vars)
+}
+
+// itemsPage is a graphql focused structure for collecting a page of items.
+type itemsPage struct {
+	Nodes      []GqlItem
+	TotalCount int
+	PageInfo   struct {
+		HasNextPage bool
+		EndCursor   string
+	}
+}
+
+// endCursor returns a copy of the page's end cursor that is not affected by
+// the page being reused.
+func (p itemsPage) endCursor() *string {
+	c := p.PageInfo.EndCursor
+	return &c
+}
+
+// fetchItemsPage fetches a single page of items after the cursor.
+func fetchItemsPage(client *gql.Client, vars map[string]any, after *string) (itemsPage, error) {
+	v := make(map[string]any, len(vars)+1)
+	for key, val := range vars {
+		v[key] = val
+	}
+	v["after"] = after
+
+	var query struct {
+		Node struct {
+			ProjectV2 struct {
+				Items itemsPage `graphql:"items(first: $count, after: $after)"`
+			} `graphql:"... on ProjectV2"`
+		} `graphql:"node(id: $projectId)"`
+	}
+
+	if err := client.Query(context.Background(), &query, v); err != nil {
+		return itemsPage{}, observeGraphQL(err)
+	}
+
+	return query.Node.ProjectV2.Items, nil
+}
+
+// expensiveQueryErrors are the error message fragments github uses when a
+// query is rejected for being too expensive or timing out.
+var expensiveQueryErrors = []string{
+	"MAX_NODE_LIMIT_EXCEEDED",
+	"RESOURCE_LIMITS_EXCEEDED",
+	"exceeds the maximum",
+	"This may be the result of a timeout",
+}
+
+// isTooExpensive returns if the error is github rejecting the query for being
+// too expensive.
+func isTooExpensive(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	for _, e := range expensiveQueryErrors {
+		if strings.Contains(msg, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// shrinkQuery halves the number of items per page, and once that reaches 1 the
+// number of field values per item.  It returns false if nothing can be made
+// smaller.
+func shrinkQuery(vars map[string]any) bool {
+	for _, key := range []string{"count", "fieldValuesCount"} {
+		if n, ok := vars[key].(int); ok && n > 1 {
+			vars[key] = n / 2
+			logf("Query too expensive, reducing %s to %d", key, n/2)
+			return true
+		}
+	}
+	return false
+}
+
+// fetchItemsPageAdaptive fetches a single page of items after the cursor,
+// shrinking the query in vars until github accepts it.
+func fetchItemsPageAdaptive(client *gql.Client, vars map[string]any, after *string) (itemsPage, error) {
+	for {
+		page, err := fetchItemsPage(client, vars, after)
+		if !isTooExpensive(err) || !shrinkQuery(vars) {
+			return page, err
+		}
+	}
+}
+
+// offsetCursor returns the cursor for the item at the offset.
+//
+// Github does not document the format of the project item cursors; they have
+// been observed to be the base64 encoded offset of the item.  Only the
+// concurrent fetching relies on this, and it checks that every cursor github
+// returns matches the one this produces before trusting the pages.  If the
+// format ever changes the items are fetched one page at a time instead.
+func offsetCursor(offset int) string {
+	return base64.StdEncoding.WithPadding(base64.NoPadding).EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+// FetchProgress captures how far fetching the items of a project got, so a
+// failed fetch can be resumed instead of restarting from the first page.
+type FetchProgress struct {
+	ProjectID string
+	Cursor    string // The cursor of the last item fetched.
+	Items     Items  // The items fetched so far.
+}
+
+// FetchIssues fetches all the items of the project, paging through them as
+// needed.  If github rejects a query as too expensive the page size is reduced
+// and the query is retried.  If workers is more than 1 and the first page shows
+// there are more pages, the remaining pages are fetched concurrently by up to
+// workers goroutines.  The concurrent pages are only used up to the first one
+// that fails, does not follow on from the one before it, or has a cursor that
+// does not match its offset (see offsetCursor); the rest are fetched serially.
+//
+// If progress is not nil and is for the same project, the fetch resumes from
+// it.  If the fetch fails, progress is updated with the items fetched so far.
+//
+// Unless includeArchived is set, the archived items are dropped as soon as each
+// page arrives, before any of their truncated values are completed.
+//
+// If skip is not nil, malformed items are passed to it and left out instead of
+// failing the fetch.
+func FetchIssues(id string, client *gql.Client, issueCount, labelCount, fvCount, workers int, includeArchived bool, progress *FetchProgress, skip SkipFunc) (Items, error) {
+	var items Items
+	var cursor *string
+
+	if progress != nil && progress.ProjectID == id && len(progress.Cursor) > 0 {
+		items = progress.Items
+		c := progress.Cursor
+		cursor = &c
+	}
+
+	fail := func(err error) (Items, error) {
+		if progress != nil {
+			progress.ProjectID = id
+			progress.Items = items
+			progress.Cursor = ""
+			if cursor != nil {
+				progress.Cursor = *cursor
+			}
+		}
+		return nil, err
+	}
+
+	vars := map[string]any{
+		"count":            issueCount,
+		"labelCount":       labelCount,
+		"fieldValuesCount": fvCount,
+		"projectId":        gql.ID(id),
+	}
+
+	// The number of items fetched may be more than the items kept if some
+	// are skipped.
+	fetched := len(items)
+
+	add := func(p itemsPage) error {
+		// The cursors count every item, including the archived ones.
+		count := len(p.Nodes)
+		if !includeArchived {
+			p.Nodes = unarchived(p.Nodes)
+		}
+
+		err := completeItems(client, p.Nodes, vars["labelCount"].(int), vars["fieldValuesCount"].(int))
+		if err != nil {
+			return err
+		}
+		clean, err := p.toClean(skip)
+		if err != nil {
+			return err
+		}
+		items = append(items, clean...)
+		fetched += count
+		cursor = p.endCursor()
+		return nil
+	}
+
+	page, err := fetchItemsPageAdaptive(client, vars, cursor)
+	if err == nil {
+		err = add(page)
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	// The remaining pages can only be fetched concurrently if the cursors are
+	// the expected offsets.
+	if workers > 1 && page.PageInfo.HasNextPage &&
+		page.PageInfo.EndCursor == offsetCursor(fetched) {
+		offsets, pages, err := fetchPagesConcurrently(client, vars, fetched, page.TotalCount, vars["count"].(int), workers)
+		for i, p := range pages {
+			if offsets[i] != fetched || p.PageInfo.EndCursor != offsetCursor(fetched+len(p.Nodes)) {
+				logf("Concurrent pages did not line up at item %d, fetching the rest serially.", fetched)
+				break
+			}
+			if err := add(p); err != nil {
+				return fail(err)
+			}
+			page = p
+		}
+		if err != nil {
+			logf("Fetching pages concurrently failed, fetching the rest serially: %s", err)
+		}
+	}
+
+	// Anything left (or everything when not concurrent) is fetched serially.
+	for page.PageInfo.HasNextPage {
+		page, err = fetchItemsPageAdaptive(client, vars, cursor)
+		if err == nil {
+			err = add(page)
+		}
+		if err != nil {
+			return fail(err)
+		}
+	}
+
+	return items, nil
+}
+
+// unarchived returns the items that are not archived.
+func unarchived(nodes []GqlItem) []GqlItem {
+	rv := make([]GqlItem, 0, len(nodes))
+	for _, n := range nodes {
+		if !n.IsArchived {
+			rv = append(rv, n)
+		}
+	}
+	return rv
+}
+
+// fetchPagesConcurrently fetches the pages of items starting at the offset up
+// to the total using a bounded pool of workers.  The offsets requested and the
+// pages are returned in order.  Each page shrinks its own copy of the query if
+// it is too expensive, so a page may hold fewer items than count.  If there is
+// an error, the pages fetched before the first failed page are returned with
+// the error.
+func fetchPagesConcurrently(client *gql.Client, vars map[string]any, offset, total, count, workers int) ([]int, []itemsPage, error) {
+	var offsets []int
+	for o := offset; o < total; o += count {
+		offsets = append(offsets, o)
+	}
+
+	pages := make([]itemsPage, len(offsets))
+	errs := make([]error, len(offsets))
+
+	var wg sync.WaitGroup
+	work := make(chan int)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range work {
+				v := make(map[string]any, len(vars))
+				for key, val := range vars {
+					v[key] = val
+				}
+				after := offsetCursor(offsets[j])
+				pages[j], errs[j] = fetchItemsPageAdaptive(client, v, &after)
+			}
+		}()
+	}
+	for j := range offsets {
+		work <- j
+	}
+	close(work)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return offsets[:i], pages[:i], err
+		}
+	}
+
+	return offsets, pages, nil
+}
+
+// toClean converts all the items in the page.  Malformed items are passed to
+// skip if it is not nil, otherwise the first malformed item is an error.
+func (p itemsPage) toClean(skip SkipFunc) (Items, error) {
+	items := make(Items, 0, len(p.Nodes))
+	for _, n := range p.Nodes {
+		if err := n.Validate(); err != nil {
+			if skip == nil {
+				return nil, fmt.Errorf("item '%s': %w", n.ID, err)
+			}
+			skip(n.ID, err)
+			continue
+		}
+		items = append(items, n.ToClean())
+	}
+	return items, nil
+}
+
+// FetchItemsByID fetches the items with the specified ids one at a time.  If
+// skip is not nil, items that fail to be fetched or are malformed are passed to
+// it and left out instead of failing the fetch.
+func FetchItemsByID(itemIds []string, client *gql.Client, issueCount, labelCount, fvCount int, skip SkipFunc) (Items, error) {
+	var items Items
+
+	done := 0
+	for _, itemId := range itemIds {
+		item, err := fetchItem(client, itemId, labelCount, fvCount)
+		if err == nil {
+			err = completeItems(client, []GqlItem{item}, labelCount, fvCount)
+		}
+		if err == nil {
+			err = item.Validate()
+		}
+		if err != nil {
+			if skip == nil {
+				return nil, err
+			}
+			skip(itemId, err)
+			continue
+		}
+
+		items = append(items, item.ToClean())
+		done++
+		if done%10 == 0 {
+			logf("Done: %d/%d", done, len(itemIds))
+		}
+	}
+
+	return items, nil
+}
+
+// fetchItem fetches a single item by id.
+func fetchItem(client *gql.Client, id string, labelCount, fvCount int) (GqlItem, error) {
+	vars := map[string]any{
+		"labelCount":       labelCount,
+		"fieldValuesCount": fvCount,
+		"id":               gql.ID(id),
+	}
+
+	var query struct {
+		Node struct {
+			ProjectV2Item struct {
+				GqlItem
+			} `graphql:"... on ProjectV2Item"`
+		} `graphql:"node(id: $id)"`
+	}
+
+	if err := client.Query(context.Background(), &query, vars); err != nil {
+		return GqlItem{}, observeGraphQL(err)
+	}
+
+	return query.Node.ProjectV2Item.GqlItem, nil
+}
+
+// maxConnectionCount is the largest page github allows for a connection.
+const maxConnectionCount = 100
+
+// completeItems re-fetches any of the items that have more labels or field
+// values than were fetched, doubling the counts until everything fits or the
+// github limit is reached.  The items are replaced in place.
+func completeItems(client *gql.Client, items []GqlItem, labelCount, fvCount int) error {
+	for i := range items {
+		lc, fc := labelCount, fvCount
+		for items[i].Truncated() {
+			if lc >= maxConnectionCount && fc >= maxConnectionCount {
+				logf("Item %s has more than %d labels or field values, the rest are ignored.",
+					items[i].ID, maxConnectionCount)
+				break
+			}
+			lc = doubleCount(lc)
+			fc = doubleCount(fc)
+
+			item, err := fetchItem(client, items[i].ID, lc, fc)
+			if err != nil {
+				return err
+			}
+			items[i] = item
+		}
+	}
+	return nil
+}
+
+// doubleCount doubles the count without going past the github limit.
+func doubleCount(count int) int {
+	if 2*count > maxConnectionCount {
+		return maxConnectionCount
+	}
+	return 2 * count
+}
+
+// ArchiveItem archives the item in the project.
+func ArchiveItem(projectId, itemId string, client *gql.Client) error {
+	vars := map[string]any{
+		"projectId": gql.ID(projectId),
+		"id":        gql.ID(itemId),
+	}
+	var mutation struct {
+		ArchiveProjectV2ItemPayload struct {
+			ClientMutationId string
+		} `graphql:"archiveProjectV2Item(input: {projectId: $projectId, itemId: $id})"`
+	}
+	return observeGraphQL(client.Mutate(context.Background(), &mutation, vars))
+}
+
+// ArchiveItems archives the items in the project with a single request, using
+// an aliased mutation for each item.  The ids of the items that were not
+// archived are returned along with the error github gave.
+func ArchiveItems(projectId string, itemIds []string, client *gql.Client) ([]string, error) {
+	if len(itemIds) == 0 {
+		return nil, nil
+	}
+
+	vars := map[string]any{
+		"projectId": gql.ID(projectId),
+	}
+	var params, fields strings.Builder
+	params.WriteString("$projectId:ID!")
+	for i, id := range itemIds {
+		vars["i"+strconv.Itoa(i)] = gql.ID(id)
+		fmt.Fprintf(&params, ",$i%d:ID!", i)
+		fmt.Fprintf(&fields, "i%d:%s(input:{projectId:$projectId,itemId:$i%d}){clientMutationId}", i, ArchiveMutation, i)
+	}
+	mutation := fmt.Sprintf("mutation(%s){%s}", params.String(), fields.String())
+
+	data, err := client.ExecRaw(context.Background(), mutation, vars)
+	if err == nil {
+		return nil, nil
+	}
+	observeGraphQL(err)
+
+	// The mutations that worked have a payload, even when others failed.
+	var payloads map[string]json.RawMessage
+	_ = json.Unmarshal(data, &payloads)
+
+	var failed []string
+	for i, id := range itemIds {
+		payload := payloads["i"+strconv.Itoa(i)]
+		if len(payload) == 0 || string(payload) == "null" {
+			failed = append(failed, id)
+		}
+	}
+	return failed, err
+}
@@ -0,0 +1,1238 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	gql "github.com/hasura/go-graphql-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	issue88 = `
+{
+  "data": {
+    "node": {
+      "items": {
+        "nodes": [
+          {
+            "id": "some-id",
+            "isArchived": false,
+            "fieldValues": {
+              "nodes": [
+                {},
+                {},
+                {
+                  "labels": {
+                    "nodes": [
+                      {
+                        "name": "deployment"
+                      }
+                    ]
+                  }
+                },
+				{
+                  "field": {
+                    "name": "Title"
+                  },
+                  "text": "An example item title."
+                },
+				{
+                  "field": {
+                    "name": "Status"
+                  },
+                  "name": "Todo"
+                }
+              ]
+            },
+            "iss": {
+              "closedAt": "2022-08-04T22:16:25Z",
+              "number": 88,
+              "url": "https://github.com/org/repo/issues/88",
+              "bodyText": "The body of the issue.",
+              "author": {
+                "login": "octocat",
+                "__typename": "User"
+              },
+              "repository": {
+                "name": "repo",
+                "nameWithOwner": "org/repo",
+                "url": "https://github.com/org/repo"
+              }
+            },
+            "pr": {}
+          }
+        ],
+        "pageInfo": {
+          "hasNextPage": false,
+          "endCursor": "MQ"
+        }
+      }
+    }
+  }
+}`
+
+	issue89 = `
+{
+  "data": {
+    "node": {
+      "items": {
+        "nodes": [
+          {
+            "id": "some-id",
+            "isArchived": false,
+            "fieldValues": {
+              "nodes": [
+				{
+                  "field": {
+                    "name": "Goal"
+                  },
+				  "date": "2022-08-05T20:19:08Z"
+                },
+				{
+                  "field": {
+                    "name": "Iteration"
+                  },
+				  "duration": 14,
+				  "iterationId": "random id",
+				  "startDate": "2022-08-05T20:19:08Z",
+				  "title": "title"
+                },
+                {
+                  "labels": {
+                    "nodes": [
+                      {
+                        "name": "deployment"
+                      }
+                    ]
+                  }
+                },
+				{
+                  "field": {
+                    "name": "Title"
+                  },
+                  "text": "An example item title."
+                },
+				{
+                  "field": {
+                    "name": "Priority"
+                  },
+				  "number": 123.456
+                },
+				{
+                  "field": {
+                    "name": "Status"
+                  },
+                  "name": "Todo"
+                },
+                {
+                  "field": {
+                    "name": "Reviewer"
+                  },
+                  "users": {
+                    "nodes": [
+                      {
+                        "login": "octocat"
+                      },
+                      {
+                        "login": "hubot"
+                      }
+                    ]
+                  }
+                },
+                {
+                  "field": {
+                    "name": "Service"
+                  },
+                  "repository": {
+                    "nameWithOwner": "org/service",
+                    "url": "https://github.com/org/service"
+                  }
+                },
+                {
+                  "field": {
+                    "name": "Fix"
+                  },
+                  "pullRequests": {
+                    "nodes": [
+                      {
+                        "url": "https://github.com/org/service/pull/5"
+                      }
+                    ]
+                  }
+                }
+              ]
+            },
+            "iss": {
+              "closedAt": "2022-08-04T22:16:25Z",
+              "number": 89,
+              "url": "https://github.com/org/repo/issues/89",
+              "trackedInIssues": {
+                "nodes": [
+                  {
+                    "number": 80,
+                    "title": "The epic",
+                    "url": "https://github.com/org/repo/issues/80"
+                  }
+                ]
+              },
+              "repository": {
+                "name": "repo",
+                "nameWithOwner": "org/repo",
+                "url": "https://github.com/org/repo"
+              }
+            },
+            "pr": {}
+          }
+        ],
+        "pageInfo": {
+          "hasNextPage": false,
+          "endCursor": "MQ"
+        }
+      }
+    }
+  }
+}`
+
+	pr23 = `
+{
+  "data": {
+    "node": {
+      "items": {
+        "nodes": [
+          {
+            "id": "id123",
+            "isArchived": false,
+            "fieldValues": {
+              "nodes": [
+                {},
+                {
+                  "field": {
+                    "name": "Title"
+                  },
+                  "text": "Update Something"
+                },
+                {
+                  "field": {
+                    "name": "Status"
+                  },
+                  "name": "Todo"
+                }
+              ]
+            },
+            "iss": {},
+            "pr": {
+              "closedAt": "2022-12-01T09:01:53Z",
+              "number": 23,
+              "url": "https://github.com/org/repo/pull/23",
+              "baseRefName": "main",
+              "author": {
+                "login": "dependabot",
+                "__typename": "Bot"
+              },
+              "repository": {
+                "name": "repo",
+                "nameWithOwner": "org/repo",
+                "url": "https://github.com/org/repo"
+              }
+            }
+          }
+        ]
+      }
+    }
+  }
+}`
+
+	pr24 = `
+{
+  "data": {
+    "node": {
+      "items": {
+        "nodes": [
+          {
+            "id": "id124",
+            "isArchived": false,
+            "fieldValues": {
+              "nodes": [
+                {},
+                {
+                  "field": {
+                    "name": "Title"
+                  },
+                  "text": "Update Something"
+                },
+                {
+                  "field": {
+                    "name": "Status"
+                  },
+                  "name": "Todo"
+                }
+              ]
+            },
+            "iss": {},
+            "pr": {
+              "createdAt": "2022-11-30T10:00:00Z",
+              "mergedAt": "2022-12-01T09:01:53Z",
+              "number": 24,
+              "url": "https://github.com/org/repo/pull/24",
+              "baseRefName": "main",
+              "author": {
+                "login": "dependabot",
+                "__typename": "Bot"
+              },
+              "repository": {
+                "name": "repo",
+                "nameWithOwner": "org/repo",
+                "url": "https://github.com/org/repo"
+              },
+              "reviews": {
+                "nodes": [
+                  {
+                    "author": {
+                      "login": "octocat",
+                      "__typename": "User"
+                    },
+                    "state": "APPROVED",
+                    "submittedAt": "2022-12-01T08:00:00Z"
+                  },
+                  {
+                    "author": {
+                      "login": "octocat",
+                      "__typename": "User"
+                    },
+                    "state": "PENDING"
+                  }
+                ]
+              }
+            }
+          }
+        ]
+      }
+    }
+  }
+}`
+)
+
+var itemIssue88 = Item{
+	ID: "some-id",
+	Fields: map[string]Field{
+		"Title": Field{
+			Type: FIELD_TEXT,
+			Name: "Title",
+			Text: "An example item title.",
+		},
+		"Status": Field{
+			Type: FIELD_TEXT,
+			Name: "Status",
+			Text: "Todo",
+		},
+	},
+	Labels:   []string{"deployment"},
+	DoneAt:   mustParseTime("2022-08-04T22:16:25Z"),
+	ItemType: "ISSUE",
+	Number:   88,
+	URL:      "https://github.com/org/repo/issues/88",
+	Body:     "The body of the issue.",
+	Author:   "octocat",
+	Repo: struct {
+		Name   string
+		Slug   string
+		URL    string
+		Branch string
+	}{
+		Name: "repo",
+		Slug: "org/repo",
+		URL:  "https://github.com/org/repo",
+	},
+}
+
+var itemIssue89 = Item{
+	ID: "some-id",
+	Fields: map[string]Field{
+		"Title": Field{
+			Type: FIELD_TEXT,
+			Name: "Title",
+			Text: "An example item title.",
+		},
+		"Iteration": Field{
+			Type:        FIELD_ITERATION,
+			Name:        "Iteration",
+			Title:       "title",
+			Duration:    time.Hour * 24 * 14,
+			IterationId: "random id",
+			StartDate:   mustParseTime("2022-08-05T20:19:08Z"),
+		},
+		"Goal": Field{
+			Type: FIELD_DATE,
+			Name: "Goal",
+			Date: mustParseTime("2022-08-05T20:19:08Z"),
+		},
+		"Priority": Field{
+			Type:   FIELD_NUMBER,
+			Name:   "Priority",
+			Number: 123.456,
+		},
+		"Status": Field{
+			Type: FIELD_TEXT,
+			Name: "Status",
+			Text: "Todo",
+		},
+		"Reviewer": Field{
+			Type:  FIELD_USERS,
+			Name:  "Reviewer",
+			Users: []string{"octocat", "hubot"},
+		},
+		"Service": Field{
+			Type: FIELD_REPOSITORY,
+			Name: "Service",
+			Text: "org/service",
+			URL:  "https://github.com/org/service",
+		},
+		"Fix": Field{
+			Type:         FIELD_PULL_REQUESTS,
+			Name:         "Fix",
+			PullRequests: []string{"https://github.com/org/service/pull/5"},
+		},
+	},
+	Labels:   []string{"deployment"},
+	DoneAt:   mustParseTime("2022-08-04T22:16:25Z"),
+	ItemType: "ISSUE",
+	Number:   89,
+	URL:      "https://github.com/org/repo/issues/89",
+	Parent: &Parent{
+		Number: 80,
+		Title:  "The epic",
+		URL:    "https://github.com/org/repo/issues/80",
+	},
+	Repo: struct {
+		Name   string
+		Slug   string
+		URL    string
+		Branch string
+	}{
+		Name: "repo",
+		Slug: "org/repo",
+		URL:  "https://github.com/org/repo",
+	},
+}
+
+var itemPr23 = Item{
+	ID: "id123",
+	Fields: map[string]Field{
+		"Title": Field{
+			Type: FIELD_TEXT,
+			Name: "Title",
+			Text: "Update Something",
+		},
+		"Status": Field{
+			Type: FIELD_TEXT,
+			Name: "Status",
+			Text: "Todo",
+		},
+	},
+	DoneAt:    mustParseTime("2022-12-01T09:01:53Z"),
+	ItemType:  "PR",
+	Abandoned: true,
+	Author:    "dependabot[bot]",
+	Number:    23,
+	URL:       "https://github.com/org/repo/pull/23",
+	Repo: struct {
+		Name   string
+		Slug   string
+		URL    string
+		Branch string
+	}{
+		Name:   "repo",
+		Slug:   "org/repo",
+		URL:    "https://github.com/org/repo",
+		Branch: "main",
+	},
+}
+
+var itemPr24 = Item{
+	ID: "id124",
+	Fields: map[string]Field{
+		"Title": Field{
+			Type: FIELD_TEXT,
+			Name: "Title",
+			Text: "Update Something",
+		},
+		"Status": Field{
+			Type: FIELD_TEXT,
+			Name: "Status",
+			Text: "Todo",
+		},
+	},
+	DoneAt:   mustParseTime("2022-12-01T09:01:53Z"),
+	ItemType: "PR",
+	Author:   "dependabot[bot]",
+	Number:   24,
+	URL:      "https://github.com/org/repo/pull/24",
+	Repo: struct {
+		Name   string
+		Slug   string
+		URL    string
+		Branch string
+	}{
+		Name:   "repo",
+		Slug:   "org/repo",
+		URL:    "https://github.com/org/repo",
+		Branch: "main",
+	},
+	CreatedAt: mustParseTime("2022-11-30T10:00:00Z"),
+	Reviews: []Review{
+		{
+			Author:      "octocat",
+			State:       "APPROVED",
+			SubmittedAt: mustParseTime("2022-12-01T08:00:00Z"),
+		},
+	},
+}
+
+func mustParseTime(timeString string) time.Time {
+	t, err := time.Parse(time.RFC3339, timeString)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestFetchIssues(t *testing.T) {
+	unknown := errors.New("unknown")
+	tests := []struct {
+		description string
+		responses   []string
+		expect      Items
+		expectErr   error
+	}{
+		{
+			description: "basic test issue",
+			responses:   []string{issue88},
+			expect:      Items{itemIssue88},
+		}, {
+			description: "basic test issue with more types",
+			responses:   []string{issue89},
+			expect:      Items{itemIssue89},
+		}, {
+			description: "basic test pr",
+			responses:   []string{pr23},
+			expect:      Items{itemPr23},
+		}, {
+			description: "basic test pr alt date",
+			responses:   []string{pr24},
+			expect:      Items{itemPr24},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			var i int
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				//body, _ := io.ReadAll(r.Body)
+				//fmt.Println(string(body))
+				io.Copy(io.Discard, r.Body)
+				r.Body.Close()
+
+				require.True(i < len(tc.responses))
+				fmt.Fprintln(w, tc.responses[i])
+				i++
+			}))
+			defer ts.Close()
+
+			items, err := FetchIssues("id", gql.NewClient(ts.URL, nil), 10, 10, 10, 1, false, nil, nil)
+
+			if errors.Is(tc.expectErr, unknown) {
+				assert.Nil(items)
+				assert.Error(err)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Empty(cmp.Diff(tc.expect, items))
+		})
+	}
+}
+
+func TestFetchIssuesSkip(t *testing.T) {
+	const page = `{"data": {"node": {"items": {"nodes": [
+		{"id": "good"},
+		{"id": "bad", "iss": {"closedAt": "2022-10-10T00:00:00Z", "number": 7}},
+		{"id": "worse", "fieldValues": {"nodes": [{"text": "x", "field": {"name": ""}}]}}
+	], "pageInfo": {"hasNextPage": false}}}}}`
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		r.Body.Close()
+		fmt.Fprintln(w, page)
+	}))
+	defer ts.Close()
+
+	t.Run("fail", func(t *testing.T) {
+		assert := assert.New(t)
+
+		items, err := FetchIssues("id", gql.NewClient(ts.URL, nil), 10, 10, 10, 1, false, nil, nil)
+		assert.Nil(items)
+		assert.ErrorIs(err, ErrMalformedItem)
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		var skipped []string
+		items, err := FetchIssues("id", gql.NewClient(ts.URL, nil), 10, 10, 10, 1, false, nil,
+			func(id string, err error) {
+				assert.ErrorIs(err, ErrMalformedItem)
+				skipped = append(skipped, id)
+			})
+		require.NoError(err)
+		require.Len(items, 1)
+		assert.Equal("good", items[0].ID)
+		assert.Equal([]string{"bad", "worse"}, skipped)
+	})
+}
+
+func TestFetchIssuesArchived(t *testing.T) {
+	const page = `{"data": {"node": {"items": {"nodes": [
+		{"id": "open"},
+		{"id": "archived", "isArchived": true, "fieldValues": {"pageInfo": {"hasNextPage": true}}}
+	], "pageInfo": {"hasNextPage": false}}}}}`
+
+	tests := []struct {
+		description     string
+		includeArchived bool
+		expect          []string
+		requests        int
+	}{
+		{
+			description: "archived items are dropped before being completed",
+			expect:      []string{"open"},
+			requests:    1,
+		}, {
+			description:     "archived items are included",
+			includeArchived: true,
+			expect:          []string{"open", "archived"},
+			requests:        2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			var requests int
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				io.Copy(io.Discard, r.Body)
+				r.Body.Close()
+				requests++
+				if requests == 1 {
+					fmt.Fprintln(w, page)
+					return
+				}
+				fmt.Fprintln(w, `{"data": {"node": {"id": "archived", "isArchived": true}}}`)
+			}))
+			defer ts.Close()
+
+			items, err := FetchIssues("id", gql.NewClient(ts.URL, nil), 10, 10, 10, 1, tc.includeArchived, nil, nil)
+			require.NoError(err)
+
+			var ids []string
+			for _, item := range items {
+				ids = append(ids, item.ID)
+			}
+			assert.Equal(tc.expect, ids)
+			assert.Equal(tc.requests, requests)
+		})
+	}
+}
+
+func TestFetchIssuesSchemaDrift(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	const page = `{"data": {"node": {"items": {"nodes": [
+		{"id": "one", "fieldValues": {"nodes": [
+			{"__typename": "ProjectV2ItemFieldTextValue", "text": "x", "field": {"name": "Title"}},
+			{"__typename": "ProjectV2ItemFieldMilestoneValue"},
+			{"__typename": "ProjectV2ItemFieldRatingValue", "field": {"name": "Rating"}}
+		]}},
+		{"id": "two", "fieldValues": {"nodes": [
+			{"__typename": "ProjectV2ItemFieldRatingValue", "field": {"name": "Rating"}},
+			{}
+		]}}
+	], "pageInfo": {"hasNextPage": false}}}}}`
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		r.Body.Close()
+		fmt.Fprintln(w, page)
+	}))
+	defer ts.Close()
+
+	items, err := FetchIssues("id", gql.NewClient(ts.URL, nil), 10, 10, 10, 1, false, nil, nil)
+	require.NoError(err)
+	require.Len(items, 2)
+
+	assert.Equal("x", items[0].Title())
+	assert.Equal([]FieldDrift{
+		{DroppedField: DroppedField{Type: "ProjectV2ItemFieldMilestoneValue"}, Count: 1},
+		{DroppedField: DroppedField{Name: "Rating", Type: "ProjectV2ItemFieldRatingValue"}, Count: 2},
+	}, items.SchemaDrift())
+}
+
+func TestFetchIssuesTruncated(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var labelCounts []int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables struct {
+				ID         string
+				LabelCount int
+			}
+		}
+		body, _ := io.ReadAll(r.Body)
+		r.Body.Close()
+		require.NoError(json.Unmarshal(body, &req))
+
+		if req.Variables.ID == "" {
+			fmt.Fprintln(w, `{"data": {"node": {"items": {"nodes": [
+				{"id": "few", "fieldValues": {"nodes": [{"labels": {"nodes": [{"name": "a"}]}}]}},
+				{"id": "many", "fieldValues": {"nodes": [{"labels": {"nodes": [{"name": "a"}], "pageInfo": {"hasNextPage": true}}}]}}
+			], "pageInfo": {"hasNextPage": false}}}}}`)
+			return
+		}
+
+		require.Equal("many", req.Variables.ID)
+		labelCounts = append(labelCounts, req.Variables.LabelCount)
+		labels := `[{"name": "a"}, {"name": "b"}]`
+		more := "true"
+		if req.Variables.LabelCount >= 4 {
+			labels = `[{"name": "a"}, {"name": "b"}, {"name": "c"}]`
+			more = "false"
+		}
+		fmt.Fprintf(w, `{"data": {"node": {"id": "many", "fieldValues": {"nodes": [{"labels": {"nodes": %s, "pageInfo": {"hasNextPage": %s}}}]}}}}`, labels, more)
+	}))
+	defer ts.Close()
+
+	items, err := FetchIssues("id", gql.NewClient(ts.URL, nil), 10, 1, 10, 1, false, nil, nil)
+	require.NoError(err)
+	require.Len(items, 2)
+	assert.Equal([]string{"a"}, items[0].Labels)
+	assert.Equal([]string{"a", "b", "c"}, items[1].Labels)
+	assert.Equal([]int{2, 4}, labelCounts)
+}
+
+func TestFetchIssuesConcurrently(t *testing.T) {
+	const total = 23
+
+	for _, workers := range []int{1, 4} {
+		t.Run(fmt.Sprintf("workers %d", workers), func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			var lock sync.Mutex
+			var calls int
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					Variables struct {
+						Count int
+						After *string
+					}
+				}
+				body, _ := io.ReadAll(r.Body)
+				r.Body.Close()
+				require.NoError(json.Unmarshal(body, &req))
+
+				lock.Lock()
+				calls++
+				lock.Unlock()
+
+				start := 0
+				if req.Variables.After != nil {
+					buf, err := base64.RawStdEncoding.DecodeString(*req.Variables.After)
+					require.NoError(err)
+					start, err = strconv.Atoi(string(buf))
+					require.NoError(err)
+				}
+				end := start + req.Variables.Count
+				if end > total {
+					end = total
+				}
+
+				nodes := make([]string, 0, end-start)
+				for i := start; i < end; i++ {
+					nodes = append(nodes, fmt.Sprintf(`{"id": "id%d"}`, i))
+				}
+				fmt.Fprintf(w, `{"data": {"node": {"items": {"nodes": [%s], "totalCount": %d, "pageInfo": {"hasNextPage": %t, "endCursor": "%s"}}}}}`,
+					strings.Join(nodes, ","), total, end < total, offsetCursor(end))
+			}))
+			defer ts.Close()
+
+			items, err := FetchIssues("id", gql.NewClient(ts.URL, nil), 5, 10, 10, workers, false, nil, nil)
+			require.NoError(err)
+			require.Len(items, total)
+			for i, item := range items {
+				assert.Equal(fmt.Sprintf("id%d", i), item.ID)
+			}
+			assert.Equal(5, calls)
+		})
+	}
+}
+
+func TestFetchIssuesConcurrentFallback(t *testing.T) {
+	const total = 23
+
+	tests := []struct {
+		description string
+		cursor      func(end int) string
+		fail        func(start, count, attempt int) string
+		expectCalls int
+	}{
+		{
+			description: "cursor does not round trip",
+			cursor: func(end int) string {
+				if end == 15 {
+					return "opaque"
+				}
+				return offsetCursor(end)
+			},
+			expectCalls: 8,
+		}, {
+			description: "page too expensive",
+			fail: func(start, count, _ int) string {
+				if start == 10 && count > 2 {
+					return `{"errors": [{"type": "MAX_NODE_LIMIT_EXCEEDED", "message": "exceeds the maximum limit"}]}`
+				}
+				return ""
+			},
+			expectCalls: 9,
+		}, {
+			description: "page fails",
+			fail: func(start, _, attempt int) string {
+				if start == 15 && attempt == 1 {
+					return `{"errors": [{"message": "Something went wrong"}]}`
+				}
+				return ""
+			},
+			expectCalls: 7,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			cursor := tc.cursor
+			if cursor == nil {
+				cursor = offsetCursor
+			}
+
+			var lock sync.Mutex
+			var calls int
+			attempts := map[int]int{}
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					Variables struct {
+						Count int
+						After *string
+					}
+				}
+				body, _ := io.ReadAll(r.Body)
+				r.Body.Close()
+				require.NoError(json.Unmarshal(body, &req))
+
+				start := 0
+				if req.Variables.After != nil {
+					for end := 0; end <= total; end++ {
+						if cursor(end) == *req.Variables.After || offsetCursor(end) == *req.Variables.After {
+							start = end
+							break
+						}
+					}
+				}
+
+				lock.Lock()
+				calls++
+				attempts[start]++
+				attempt := attempts[start]
+				lock.Unlock()
+
+				if tc.fail != nil {
+					if msg := tc.fail(start, req.Variables.Count, attempt); len(msg) > 0 {
+						fmt.Fprintln(w, msg)
+						return
+					}
+				}
+
+				end := start + req.Variables.Count
+				if end > total {
+					end = total
+				}
+
+				nodes := make([]string, 0, end-start)
+				for i := start; i < end; i++ {
+					nodes = append(nodes, fmt.Sprintf(`{"id": "id%d"}`, i))
+				}
+				fmt.Fprintf(w, `{"data": {"node": {"items": {"nodes": [%s], "totalCount": %d, "pageInfo": {"hasNextPage": %t, "endCursor": "%s"}}}}}`,
+					strings.Join(nodes, ","), total, end < total, cursor(end))
+			}))
+			defer ts.Close()
+
+			items, err := FetchIssues("id", gql.NewClient(ts.URL, nil), 5, 10, 10, 4, false, nil, nil)
+			require.NoError(err)
+			require.Len(items, total)
+			for i, item := range items {
+				assert.Equal(fmt.Sprintf("id%d", i), item.ID)
+			}
+			assert.Equal(tc.expectCalls, calls)
+		})
+	}
+}
+
+func TestFetchIssuesTooExpensive(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var counts []int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables struct {
+				Count int
+			}
+		}
+		body, _ := io.ReadAll(r.Body)
+		r.Body.Close()
+		require.NoError(json.Unmarshal(body, &req))
+
+		counts = append(counts, req.Variables.Count)
+		if req.Variables.Count > 25 {
+			fmt.Fprintln(w, `{"errors": [{"type": "MAX_NODE_LIMIT_EXCEEDED", "message": "This query requests up to 1,000,000 possible nodes which exceeds the maximum limit of 500,000."}]}`)
+			return
+		}
+		fmt.Fprintln(w, pr23)
+	}))
+	defer ts.Close()
+
+	items, err := FetchIssues("id", gql.NewClient(ts.URL, nil), 100, 10, 10, 1, false, nil, nil)
+	require.NoError(err)
+	assert.Len(items, 1)
+	assert.Equal([]int{100, 50, 25}, counts)
+}
+
+func TestFetchIssuesResume(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	fail := true
+	var afters []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables struct {
+				After *string
+			}
+		}
+		body, _ := io.ReadAll(r.Body)
+		r.Body.Close()
+		require.NoError(json.Unmarshal(body, &req))
+
+		if req.Variables.After == nil {
+			fmt.Fprintln(w, `{"data": {"node": {"items": {"nodes": [{"id": "id0"}], "pageInfo": {"hasNextPage": true, "endCursor": "first"}}}}}`)
+			return
+		}
+
+		afters = append(afters, *req.Variables.After)
+		if fail {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprintln(w, `{"data": {"node": {"items": {"nodes": [{"id": "id1"}], "pageInfo": {"hasNextPage": false, "endCursor": "second"}}}}}`)
+	}))
+	defer ts.Close()
+
+	var progress FetchProgress
+	items, err := FetchIssues("id", gql.NewClient(ts.URL, nil), 1, 10, 10, 1, false, &progress, nil)
+	require.Error(err)
+	assert.Nil(items)
+	assert.Equal("id", progress.ProjectID)
+	assert.Equal("first", progress.Cursor)
+	require.Len(progress.Items, 1)
+
+	filename := filepath.Join(t.TempDir(), ProgressFilename)
+	require.NoError(progress.Save(filename))
+	progress, err = LoadProgress(filename)
+	require.NoError(err)
+
+	fail = false
+	items, err = FetchIssues("id", gql.NewClient(ts.URL, nil), 1, 10, 10, 1, false, &progress, nil)
+	require.NoError(err)
+	require.Len(items, 2)
+	assert.Equal("id0", items[0].ID)
+	assert.Equal("id1", items[1].ID)
+	assert.Equal([]string{"first", "first"}, afters)
+}
+
+func TestFetchProjectInfo(t *testing.T) {
+	unknown := errors.New("unknown")
+	tests := []struct {
+		description string
+		owner       string
+		project     int
+		responses   []string
+		expect      string
+		expectErr   error
+	}{
+		{
+			description: "basic test issue",
+			owner:       "example",
+			project:     55,
+			responses: []string{`
+{
+  "data": {
+    "organization": {
+      "projectV2": {
+        "id": "projectId"
+      }
+    }
+  }
+}`,
+			},
+			expect: "projectId",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			var i int
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				//body, _ := io.ReadAll(r.Body)
+				//fmt.Println(string(body))
+				io.Copy(io.Discard, r.Body)
+				r.Body.Close()
+
+				require.True(i < len(tc.responses))
+				fmt.Fprintln(w, tc.responses[i])
+				i++
+			}))
+			defer ts.Close()
+
+			got, err := FetchProjectInfo(tc.owner, tc.project, gql.NewClient(ts.URL, nil))
+
+			if errors.Is(tc.expectErr, unknown) {
+				assert.Equal("", got)
+				assert.Error(err)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tc.expect, got)
+		})
+	}
+}
+
+func TestFetchProjectWritable(t *testing.T) {
+	tests := []struct {
+		description string
+		response    string
+		expect      bool
+		expectErr   bool
+	}{
+		{
+			description: "writable",
+			response:    `{"data": {"organization": {"projectV2": {"viewerCanUpdate": true}}}}`,
+			expect:      true,
+		}, {
+			description: "read only",
+			response:    `{"data": {"organization": {"projectV2": {"viewerCanUpdate": false}}}}`,
+		}, {
+			description: "not found",
+			response:    `{"data": null, "errors": [{"type": "NOT_FOUND", "message": "Could not resolve to a ProjectV2"}]}`,
+			expectErr:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				buf, _ := io.ReadAll(r.Body)
+				r.Body.Close()
+				assert.Contains(string(buf), "viewerCanUpdate")
+				fmt.Fprintln(w, tc.response)
+			}))
+			defer ts.Close()
+
+			got, err := FetchProjectWritable("org", 5, gql.NewClient(ts.URL, nil))
+			if tc.expectErr {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tc.expect, got)
+		})
+	}
+}
+
+func TestFetchProjectDetails(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		r.Body.Close()
+
+		fmt.Fprintln(w, `
+{
+  "data": {
+    "organization": {
+      "projectV2": {
+        "title": "Platform",
+        "shortDescription": "The platform work.",
+        "readme": "# Platform"
+      }
+    }
+  }
+}`)
+	}))
+	defer ts.Close()
+
+	got, err := FetchProjectDetails("org", 5, gql.NewClient(ts.URL, nil))
+	require.NoError(err)
+	assert.Equal(ProjectDetails{
+		Title:            "Platform",
+		ShortDescription: "The platform work.",
+		Readme:           "# Platform",
+	}, got)
+}
+
+func TestFetchProjects(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	responses := []string{`
+{
+  "data": {
+    "organization": {
+      "projectsV2": {
+        "nodes": [
+          { "id": "p1", "number": 1, "title": "One", "closed": false },
+          { "id": "p2", "number": 2, "title": "Two", "closed": true }
+        ],
+        "pageInfo": { "hasNextPage": true, "endCursor": "MQ" }
+      }
+    }
+  }
+}`, `
+{
+  "data": {
+    "organization": {
+      "projectsV2": {
+        "nodes": [
+          { "id": "p3", "number": 3, "title": "Three", "closed": false }
+        ],
+        "pageInfo": { "hasNextPage": false, "endCursor": "Mg" }
+      }
+    }
+  }
+}`,
+	}
+
+	var i int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		r.Body.Close()
+
+		require.True(i < len(responses))
+		fmt.Fprintln(w, responses[i])
+		i++
+	}))
+	defer ts.Close()
+
+	got, err := FetchProjects("org", gql.NewClient(ts.URL, nil), 10)
+	require.NoError(err)
+	assert.Equal([]ProjectInfo{
+		{ID: "p1", Number: 1, Title: "One"},
+		{ID: "p3", Number: 3, Title: "Three"},
+	}, got)
+}
+
+func TestArchiveItem(t *testing.T) {
+	unknown := errors.New("unknown")
+	tests := []struct {
+		description string
+		project     string
+		item        string
+		responses   []string
+		expectErr   error
+	}{
+		{
+			description: "basic test issue",
+			project:     "id-55",
+			item:        "item3",
+			responses: []string{`
+{
+	"data": {
+		"archiveProjectV2Item": {
+			"clientMutationId":null
+		}
+	}
+}`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			var i int
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				//body, _ := io.ReadAll(r.Body)
+				//fmt.Println(string(body))
+				io.Copy(io.Discard, r.Body)
+				r.Body.Close()
+
+				require.True(i < len(tc.responses))
+				fmt.Fprintln(w, tc.responses[i])
+				i++
+			}))
+			defer ts.Close()
+
+			err := ArchiveItem(tc.project, tc.item, gql.NewClient(ts.URL, nil))
+
+			if errors.Is(tc.expectErr, unknown) {
+				assert.Error(err)
+				return
+			}
+
+			assert.NoError(err)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import "strings"
+
+// Hide defines the items left out of the reports based on their repository or
+// a label.  The hidden items are still archived, unless they have the label and
+// KeepLabeled is set.
+//
+// The repositories are denied by Repos and, if AllowRepos is set, only the
+// repositories matching it are shown.  The deny list wins.  Items without a
+// repository, like drafts, are never hidden by either list.
+type Hide struct {
+	Private     bool     `yaml:"private"`      // Hide the items in private repositories.
+	Repos       []string `yaml:"repos"`        // The org/repo globs of the repositories to hide.
+	AllowRepos  []string `yaml:"allow_repos"`  // The org/repo globs of the only repositories to show.
+	Label       string   `yaml:"label"`        // The label (glob) of the items to hide.
+	KeepLabeled bool     `yaml:"keep_labeled"` // Leave the items with the label on the board.
+}
+
+// IsHidden returns if the item is hidden.
+func (h Hide) IsHidden(item Item) bool {
+	if h.Private && item.Private {
+		return true
+	}
+	if len(item.Repo.Slug) > 0 {
+		if matchRepo(h.Repos, item.Repo.Slug) {
+			return true
+		}
+		if len(h.AllowRepos) > 0 && !matchRepo(h.AllowRepos, item.Repo.Slug) {
+			return true
+		}
+	}
+	return h.isLabeled(item)
+}
+
+// matchRepo returns if the org/repo slug matches any of the globs.
+func matchRepo(globs []string, slug string) bool {
+	for _, repo := range globs {
+		if globMatch(strings.TrimSpace(repo), slug) {
+			return true
+		}
+	}
+	return false
+}
+
+// isLabeled returns if the item has the hide label.
+func (h Hide) isLabeled(item Item) bool {
+	return len(h.Label) > 0 && item.HasLabel(h.Label)
+}
+
+// Items returns the items in the list that are not hidden.
+func (h Hide) Items(list Items) Items {
+	if !h.Private && len(h.Repos) == 0 && len(h.AllowRepos) == 0 && len(h.Label) == 0 {
+		return list
+	}
+
+	var rv Items
+	for _, item := range list {
+		if !h.IsHidden(item) {
+			rv = append(rv, item)
+		}
+	}
+	return rv
+}
+
+// Week returns a copy of the week without the hidden items.
+func (h Hide) Week(week WeeklyItems) WeeklyItems {
+	week.Items = h.Items(week.Items)
+	week.Open = h.Items(week.Open)
+	week.Planned = h.Items(week.Planned)
+	return week
+}
+
+// Archivable returns a copy of the weeks without the items that are kept on the
+// board because they have the label.
+func (h Hide) Archivable(weeks []WeeklyItems) []WeeklyItems {
+	if !h.KeepLabeled || len(h.Label) == 0 {
+		return weeks
+	}
+
+	rv := make([]WeeklyItems, len(weeks))
+	for i, week := range weeks {
+		var items Items
+		for _, item := range week.Items {
+			if !h.isLabeled(item) {
+				items = append(items, item)
+			}
+		}
+		week.Items = items
+		rv[i] = week
+	}
+	return rv
+}
@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHide(t *testing.T) {
+	private := itemIssue89
+	private.Private = true
+	other := itemPr24
+	other.Repo.Slug = "org/secret-stuff"
+	labeled := itemIssue88
+	labeled.Labels = []string{"report:skip"}
+
+	list := Items{itemIssue88, private, other, labeled}
+
+	tests := []struct {
+		description string
+		hide        Hide
+		expect      Items
+	}{
+		{
+			description: "nothing hidden",
+			expect:      list,
+		}, {
+			description: "label",
+			hide:        Hide{Label: "report:*"},
+			expect:      Items{itemIssue88, private, other},
+		}, {
+			description: "private",
+			hide:        Hide{Private: true},
+			expect:      Items{itemIssue88, other, labeled},
+		}, {
+			description: "deny list",
+			hide:        Hide{Repos: []string{"org/secret-*"}},
+			expect:      Items{itemIssue88, private, labeled},
+		}, {
+			description: "both",
+			hide:        Hide{Private: true, Repos: []string{" org/secret-* "}},
+			expect:      Items{itemIssue88, labeled},
+		}, {
+			description: "allow list",
+			hide:        Hide{AllowRepos: []string{"org/secret-*"}},
+			expect:      Items{other},
+		}, {
+			description: "deny list wins over the allow list",
+			hide:        Hide{AllowRepos: []string{"org/*"}, Repos: []string{"org/secret-*"}},
+			expect:      Items{itemIssue88, private, labeled},
+		}, {
+			description: "allow list matching nothing",
+			hide:        Hide{AllowRepos: []string{"nope/*"}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			week := tc.hide.Week(WeeklyItems{Items: list, Open: list})
+			assert.Equal(tc.expect, week.Items)
+			assert.Equal(tc.expect, week.Open)
+			assert.Len(list, 4)
+		})
+	}
+
+	// The drafts have no repository to match.
+	assert.False(t, Hide{AllowRepos: []string{"nope/*"}}.IsHidden(Item{ID: "draft"}))
+}
+
+func TestHideArchivable(t *testing.T) {
+	labeled := itemIssue88
+	labeled.Labels = []string{"report:skip"}
+
+	weeks := []WeeklyItems{
+		{Items: Items{itemIssue88, labeled}},
+		{Items: Items{labeled}},
+	}
+
+	tests := []struct {
+		description string
+		hide        Hide
+		expect      []WeeklyItems
+	}{
+		{
+			description: "no label",
+			hide:        Hide{KeepLabeled: true},
+			expect:      weeks,
+		}, {
+			description: "labeled items are archived",
+			hide:        Hide{Label: "report:skip"},
+			expect:      weeks,
+		}, {
+			description: "labeled items are kept",
+			hide:        Hide{Label: "report:skip", KeepLabeled: true},
+			expect: []WeeklyItems{
+				{Items: Items{itemIssue88}},
+				{},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			assert.Equal(tc.expect, tc.hide.Archivable(weeks))
+			assert.Len(weeks[0].Items, 2)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"io"
+	"sort"
+)
+
+// Score returns the highlight score of the item, the sum of the weighted
+// priority, points and labels of the item.
+func (h Highlights) Score(cfg Config, item Item) float64 {
+	var score float64
+	if n, ok := item.FieldNumber(cfg.Priority.Field); ok && n >= 0 {
+		score += h.Weights.Priority / (1 + n)
+	}
+	if n, ok := item.FieldNumber(cfg.Points.Field); ok {
+		score += h.Weights.Points * n
+	}
+	for glob, weight := range h.Weights.Labels {
+		if item.HasLabel(glob) {
+			score += weight
+		}
+	}
+	return score
+}
+
+// Select returns the pinned items followed by the items with the highest
+// scores, up to the count.  The pinned items are all returned, even past the
+// count.  The items with the same score keep their order and the items scoring
+// 0 or less are left out.
+func (h Highlights) Select(cfg Config, list Items) Items {
+	type scored struct {
+		item  Item
+		score float64
+	}
+
+	var rv Items
+	all := make([]scored, 0, len(list))
+	for _, item := range list {
+		if len(h.PinLabel) > 0 && item.HasLabel(h.PinLabel) {
+			rv = append(rv, item)
+			continue
+		}
+		if score := h.Score(cfg, item); score > 0 {
+			all = append(all, scored{item: item, score: score})
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].score > all[j].score
+	})
+
+	for i := 0; i < len(all) && len(rv) < h.Count; i++ {
+		rv = append(rv, all[i].item)
+	}
+	return rv
+}
+
+// Render renders the highlights of the list as a section.
+func (h Highlights) Render(cfg Config, list Items, w io.Writer) {
+	Section{
+		Name:        h.Name,
+		RenderOrder: h.RenderOrder,
+		OmitIfEmpty: h.OmitIfEmpty,
+	}.Render(cfg, h.Select(cfg, list), w)
+}
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlightsSelect(t *testing.T) {
+	cfg := Config{
+		Priority: Priority{Field: "Priority"},
+		Points:   Points{Field: "Estimate"},
+	}
+	number := func(n float64) Field {
+		return Field{Type: FIELD_NUMBER, Number: n}
+	}
+	list := Items{
+		{ID: "plain"},
+		{ID: "p0", Fields: map[string]Field{"Priority": number(0)}},
+		{ID: "p1", Fields: map[string]Field{"Priority": number(1)}},
+		{ID: "big", Fields: map[string]Field{"Estimate": number(8)}},
+		{ID: "release", Labels: []string{"release"}},
+		{ID: "customer", Labels: []string{"customer/acme", "release"}},
+		{ID: "pinned", Labels: []string{"report:highlight"}},
+		{ID: "pinned too", Labels: []string{"report:pin"}},
+	}
+
+	tests := []struct {
+		description string
+		highlights  Highlights
+		expect      []string
+	}{
+		{
+			description: "no weights",
+			highlights:  Highlights{Count: 3},
+		}, {
+			description: "priority",
+			highlights:  Highlights{Count: 3, Weights: HighlightWeights{Priority: 10}},
+			expect:      []string{"p0", "p1"},
+		}, {
+			description: "points",
+			highlights:  Highlights{Count: 3, Weights: HighlightWeights{Points: 1}},
+			expect:      []string{"big"},
+		}, {
+			description: "labels",
+			highlights: Highlights{Count: 3, Weights: HighlightWeights{
+				Labels: map[string]float64{"release": 2, "customer/*": 5},
+			}},
+			expect: []string{"customer", "release"},
+		}, {
+			description: "combined and limited",
+			highlights: Highlights{Count: 3, Weights: HighlightWeights{
+				Priority: 10,
+				Points:   1,
+				Labels:   map[string]float64{"release": 6},
+			}},
+			expect: []string{"p0", "big", "release"},
+		}, {
+			description: "negative weights",
+			highlights: Highlights{Count: 3, Weights: HighlightWeights{
+				Priority: 10,
+				Labels:   map[string]float64{"customer/*": 1, "release": -20},
+			}},
+			expect: []string{"p0", "p1"},
+		}, {
+			description: "pinned first",
+			highlights: Highlights{Count: 2, PinLabel: "report:highlight", Weights: HighlightWeights{
+				Priority: 10,
+			}},
+			expect: []string{"pinned", "p0"},
+		}, {
+			description: "pinned past the count",
+			highlights:  Highlights{Count: 1, PinLabel: "report:*"},
+			expect:      []string{"pinned", "pinned too"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var ids []string
+			for _, item := range tc.highlights.Select(cfg, list) {
+				ids = append(ids, item.ID)
+			}
+			assert.Equal(tc.expect, ids)
+		})
+	}
+}
+
+func TestHighlightsRender(t *testing.T) {
+	assert := assert.New(t)
+
+	h := Highlights{
+		Name:        "Highlights",
+		OmitIfEmpty: true,
+		Count:       1,
+		Weights:     HighlightWeights{Labels: map[string]float64{"release": 1}},
+	}
+
+	var buf strings.Builder
+	h.Render(Config{}, Items{{Number: 1, URL: "u1"}}, &buf)
+	assert.Empty(buf.String())
+
+	buf.Reset()
+	h.Render(Config{}, Items{{Number: 1, URL: "u1"}, {Number: 2, URL: "u2", Labels: []string{"release"}}}, &buf)
+	assert.Equal("\n## Highlights (1)\n\n-  **[[#2](u2)]** ([]())\n", buf.String())
+}
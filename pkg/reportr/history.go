@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sort"
+	"time"
+)
+
+// HistoryFilename is the name of the file in the output directory that records
+// the reports that have been generated.
+const HistoryFilename = ".status-reportr-history.json"
+
+// History is the record of the reports that have been generated.
+type History struct {
+	Reports    []ReportRecord
+	Iterations []Burndown `json:",omitempty"` // The burndown of each iteration.
+
+	// Contributors is when the first pull request of each author was merged.
+	Contributors map[string]time.Time `json:",omitempty"`
+}
+
+// ReportRecord captures the details of a single generated report.
+type ReportRecord struct {
+	Filename  string
+	Start     time.Time
+	End       time.Time
+	Generated time.Time
+	ItemIDs   []string
+	Archived  bool // If the items in the report have been archived.
+	Hidden    int  `json:",omitempty"` // The number of the items not shown in the report.
+
+	// The number of items done, in each section and with each label, used to
+	// compare the next report to.
+	Done     int            `json:",omitempty"`
+	Sections map[string]int `json:",omitempty"`
+	Labels   map[string]int `json:",omitempty"`
+
+	Items Items `json:"-"` // The items shown in the report, only while generating it.
+}
+
+// LoadHistory reads the history file.  A missing file results in an empty
+// history.
+func LoadHistory(filename string) (History, error) {
+	var h History
+
+	buf, err := os.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return h, nil
+		}
+		return h, err
+	}
+
+	err = json.Unmarshal(buf, &h)
+	return h, err
+}
+
+// Save writes the history file.
+func (h History) Save(filename string) error {
+	buf, err := json.MarshalIndent(h, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, buf, 0644)
+}
+
+// Record adds or replaces the record for the report with the same window.  If
+// the replaced record was archived the new record remains archived.  The
+// records are kept sorted by start time, newest first.
+func (h *History) Record(r ReportRecord) {
+	replaced := false
+	for i := range h.Reports {
+		if h.Reports[i].Start.Equal(r.Start) && h.Reports[i].End.Equal(r.End) {
+			r.Archived = r.Archived || h.Reports[i].Archived
+			h.Reports[i] = r
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		h.Reports = append(h.Reports, r)
+	}
+
+	sort.SliceStable(h.Reports, func(i, j int) bool {
+		return h.Reports[i].Start.After(h.Reports[j].Start)
+	})
+}
+
+// Find returns the record for the report with the same window if present.
+func (h History) Find(start, end time.Time) (ReportRecord, bool) {
+	for _, r := range h.Reports {
+		if r.Start.Equal(start) && r.End.Equal(end) {
+			return r, true
+		}
+	}
+	return ReportRecord{}, false
+}
+
+// Previous returns the record of the report ending when the window starting at
+// start begins, if present.
+func (h History) Previous(start time.Time) (ReportRecord, bool) {
+	for _, r := range h.Reports {
+		if r.End.Equal(start) {
+			return r, true
+		}
+	}
+	return ReportRecord{}, false
+}
+
+// MarkArchived marks the record for the report with the same window as
+// archived.
+func (h *History) MarkArchived(start, end time.Time) {
+	for i := range h.Reports {
+		if h.Reports[i].Start.Equal(start) && h.Reports[i].End.Equal(end) {
+			h.Reports[i].Archived = true
+		}
+	}
+}
+
+// Shown returns the number of items shown in the report.
+func (r ReportRecord) Shown() int {
+	return len(r.ItemIDs) - r.Hidden
+}
+
+// NewReportRecord creates a record for the report file and week.
+func NewReportRecord(filename string, week WeeklyItems, now time.Time) ReportRecord {
+	ids := make([]string, 0, len(week.Items))
+	for _, item := range week.Items {
+		ids = append(ids, item.ID)
+	}
+
+	return ReportRecord{
+		Filename:  filename,
+		Start:     week.Start,
+		End:       week.End,
+		Generated: now,
+		ItemIDs:   ids,
+	}
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	filename := filepath.Join(t.TempDir(), HistoryFilename)
+
+	h, err := LoadHistory(filename)
+	require.NoError(err)
+	assert.Empty(h.Reports)
+
+	older := mustParseTime("2022-11-20T00:00:00Z")
+	newer := mustParseTime("2022-11-27T00:00:00Z")
+	now := time.Now().UTC().Round(time.Second)
+
+	h.Record(NewReportRecord("a.md", WeeklyItems{Start: older, End: newer, Items: Items{itemIssue88}}, now))
+	h.Record(NewReportRecord("b.md", WeeklyItems{Start: newer, End: newer.AddDate(0, 0, 7)}, now))
+	h.Record(NewReportRecord("c.md", WeeklyItems{Start: older, End: newer, Items: Items{itemPr23, itemPr24}}, now))
+
+	require.NoError(h.Save(filename))
+
+	got, err := LoadHistory(filename)
+	require.NoError(err)
+	require.Len(got.Reports, 2)
+	assert.Equal("b.md", got.Reports[0].Filename)
+	assert.Equal("c.md", got.Reports[1].Filename)
+	assert.Equal([]string{"id123", "id124"}, got.Reports[1].ItemIDs)
+
+	index := RenderIndex(Config{Team: "Team"}, got)
+	assert.Contains(index, "- [Nov 20, 2022 ... Nov 26, 2022](c.md) (2 items)\n")
+}
+
+func TestHistoryMarkArchived(t *testing.T) {
+	assert := assert.New(t)
+
+	start := mustParseTime("2022-11-20T00:00:00Z")
+	end := mustParseTime("2022-11-27T00:00:00Z")
+	now := time.Now()
+
+	var h History
+	h.Record(NewReportRecord("a.md", WeeklyItems{Start: start, End: end}, now))
+
+	_, found := h.Find(end, end)
+	assert.False(found)
+
+	got, found := h.Find(start, end)
+	assert.True(found)
+	assert.False(got.Archived)
+
+	h.MarkArchived(start, end)
+	got, _ = h.Find(start, end)
+	assert.True(got.Archived)
+
+	// Regenerating the report does not lose the archived state.
+	h.Record(NewReportRecord("a.md", WeeklyItems{Start: start, End: end}, now))
+	got, _ = h.Find(start, end)
+	assert.True(got.Archived)
+}
+
+func TestRecordBurndown(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	start := mustParseTime("2022-08-01T00:00:00Z")
+	item := func(id string, done time.Time) Item {
+		status := "Todo"
+		if !done.IsZero() {
+			status = "Done"
+		}
+		return Item{
+			ID:     id,
+			DoneAt: done,
+			Fields: map[string]Field{
+				"Status": {Type: FIELD_TEXT, Text: status},
+				"Iteration": {
+					Type:        FIELD_ITERATION,
+					IterationId: "it5",
+					Title:       "Sprint 5",
+					StartDate:   start,
+					Duration:    14 * 24 * time.Hour,
+				},
+			},
+		}
+	}
+	planned := Items{
+		item("a", start.Add(26*time.Hour)),
+		item("b", start.Add(50*time.Hour)),
+		item("c", time.Time{}),
+	}
+
+	var h History
+	_, ok := h.RecordBurndown("Iteration", nil, start)
+	assert.False(ok)
+
+	// The previous days are filled in from when the items were completed.
+	b, ok := h.RecordBurndown("Iteration", planned, start.Add(3*24*time.Hour+time.Hour))
+	require.True(ok)
+	assert.Equal("Sprint 5", b.Title)
+	assert.Equal(map[string]int{
+		"2022-08-01": 3,
+		"2022-08-02": 2,
+		"2022-08-03": 1,
+		"2022-08-04": 1,
+	}, b.Remaining)
+
+	// Recorded days are kept and today is replaced.
+	planned = append(planned, item("d", time.Time{}))
+	b, ok = h.RecordBurndown("Iteration", planned, start.Add(3*24*time.Hour+2*time.Hour))
+	require.True(ok)
+	assert.Equal(1, b.Remaining["2022-08-03"])
+	assert.Equal(2, b.Remaining["2022-08-04"])
+	assert.Len(h.Iterations, 1)
+
+	var buf strings.Builder
+	b.Render(Config{}, "mermaid", &buf)
+	assert.Contains(buf.String(), "## Burndown: Sprint 5")
+	assert.Contains(buf.String(), "x-axis [08-01, 08-02, 08-03, 08-04]")
+	assert.Contains(buf.String(), "line [3, 2, 1, 2]")
+
+	buf.Reset()
+	b.Render(Config{}, "table", &buf)
+	assert.Contains(buf.String(), "| 08-04 | 2 |")
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RunSummary describes the results of a run for hooks and delivery targets.
+type RunSummary struct {
+	OutputDirectory string
+	Reports         []string // The paths of the reports written.
+	Items           int      // The number of items reported.
+	Archived        int      // The number of items archived.
+	Skipped         []string // The ids of the items skipped because of errors.
+	DryRun          bool
+}
+
+// Env returns the summary as a map of environment variables.
+func (s RunSummary) Env() map[string]string {
+	return map[string]string{
+		"SR_OUTPUT_DIRECTORY": s.OutputDirectory,
+		"SR_REPORTS":          strings.Join(s.Reports, "\n"),
+		"SR_REPORT_COUNT":     strconv.Itoa(len(s.Reports)),
+		"SR_ITEM_COUNT":       strconv.Itoa(s.Items),
+		"SR_ARCHIVED_COUNT":   strconv.Itoa(s.Archived),
+		"SR_SKIPPED":          strings.Join(s.Skipped, "\n"),
+		"SR_SKIPPED_COUNT":    strconv.Itoa(len(s.Skipped)),
+		"SR_DRY_RUN":          strconv.FormatBool(s.DryRun),
+	}
+}
+
+// RunCommand runs the command using the shell with the environment variables
+// added to the current environment.  The output of the command is passed
+// through to stdout/stderr.  An empty command does nothing.
+func RunCommand(command string, env map[string]string) error {
+	if len(strings.TrimSpace(command)) == 0 {
+		return nil
+	}
+
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		cmd.Env = append(cmd.Env, k+"="+env[k])
+	}
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("command '%s' failed: %w", command, err)
+	}
+	return nil
+}
+
+// RunHook runs the named hook command with the run summary in the environment.
+func RunHook(name, command string, summary RunSummary) error {
+	env := summary.Env()
+	env["SR_HOOK"] = name
+	return RunCommand(command, env)
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunHook(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	out := filepath.Join(t.TempDir(), "out.txt")
+	summary := RunSummary{
+		Reports: []string{"a.md", "b.md"},
+		Items:   3,
+		Skipped: []string{"id9"},
+	}
+
+	require.NoError(RunHook("post_render", `echo "$SR_HOOK $SR_REPORT_COUNT $SR_ITEM_COUNT $SR_SKIPPED_COUNT $SR_DRY_RUN" > `+out, summary))
+
+	got, err := os.ReadFile(out)
+	require.NoError(err)
+	assert.Equal("post_render 2 3 1 false\n", string(got))
+
+	assert.NoError(RunHook("empty", "", summary))
+	assert.Error(RunHook("fails", "exit 1", summary))
+}
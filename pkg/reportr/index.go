@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RenderIndex converts the history into a markdown document listing all the
+// reports, newest first, with the number of items shown in each.
+func RenderIndex(cfg Config, h History) string {
+	var buf strings.Builder
+
+	fmt.Fprintf(&buf, "# %s: %s\n\n", cfg.Locale.T("status_reports"), cfg.Team)
+	for _, r := range h.Reports {
+		fmt.Fprintf(&buf, "- [%s ... %s](%s) (%d %s)\n",
+			cfg.Locale.Date(r.Start),
+			cfg.Locale.Date(r.End.AddDate(0, 0, -1)),
+			r.Filename,
+			r.Shown(),
+			cfg.Locale.T("items"),
+		)
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderIndex(t *testing.T) {
+	first := mustParseTime("2022-11-13T00:00:00Z")
+	second := first.AddDate(0, 0, 7)
+	third := second.AddDate(0, 0, 7)
+	now := time.Now()
+
+	record := func(name string, start time.Time, ids []string, hidden int) ReportRecord {
+		var items Items
+		for _, id := range ids {
+			items = append(items, Item{ID: id})
+		}
+		r := NewReportRecord(name, WeeklyItems{Start: start, End: start.AddDate(0, 0, 7), Items: items}, now)
+		r.Hidden = hidden
+		return r
+	}
+
+	tests := []struct {
+		description string
+		records     []ReportRecord
+		expect      string
+	}{
+		{
+			description: "no reports",
+			expect:      "# Status Reports: Team\n\n",
+		}, {
+			description: "newest first",
+			records: []ReportRecord{
+				record("second.md", second, []string{"a", "b"}, 0),
+				record("first.md", first, []string{"c"}, 0),
+				record("third.md", third, nil, 0),
+			},
+			expect: "# Status Reports: Team\n\n" +
+				"- [Nov 27, 2022 ... Dec 3, 2022](third.md) (0 items)\n" +
+				"- [Nov 20, 2022 ... Nov 26, 2022](second.md) (2 items)\n" +
+				"- [Nov 13, 2022 ... Nov 19, 2022](first.md) (1 items)\n",
+		}, {
+			description: "hidden items are not counted",
+			records: []ReportRecord{
+				record("first.md", first, []string{"a", "b", "c"}, 1),
+				record("second.md", second, []string{"d", "e"}, 2),
+			},
+			expect: "# Status Reports: Team\n\n" +
+				"- [Nov 20, 2022 ... Nov 26, 2022](second.md) (0 items)\n" +
+				"- [Nov 13, 2022 ... Nov 19, 2022](first.md) (2 items)\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var h History
+			for _, r := range tc.records {
+				h.Record(r)
+			}
+
+			assert.Equal(tc.expect, RenderIndex(Config{Team: "Team"}, h))
+		})
+	}
+}
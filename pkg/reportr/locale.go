@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"strings"
+	"time"
+)
+
+// defaultDateFormat is the date format used when the locale does not specify
+// one.
+const defaultDateFormat = "Jan 2, 2006"
+
+// defaultStrings are the built-in english strings used when the locale does
+// not specify a replacement.
+var defaultStrings = map[string]string{
+	"status_report":        "Status Report",
+	"status_reports":       "Status Reports",
+	"by_label":             "By Label",
+	"by_repository":        "By Repository",
+	"by_contributor":       "By Contributor",
+	"items":                "items",
+	"dependency_summary":   "%d dependency updates across %d repos.",
+	"ungrouped":            "Other",
+	"generated":            "Generated %s by status-reportr %s from project %d with %d items, %d open.",
+	"also_in":              "also in %s",
+	"overdue":              "%d overdue",
+	"low_priority":         "low priority items",
+	"section":              "Section",
+	"planned":              "Planned",
+	"completed":            "Done",
+	"complete":             "Complete",
+	"total":                "Total",
+	"burndown":             "Burndown",
+	"day":                  "Day",
+	"remaining":            "Remaining",
+	"board_snapshot":       "Board Snapshot",
+	"status":               "Status",
+	"no_status":            "No Status",
+	"count":                "Items",
+	"on_track":             "On Track",
+	"at_risk":              "At Risk",
+	"off_track":            "Off Track",
+	"inactive":             "Inactive",
+	"tracked_in_several":   "Tracked in Several Projects",
+	"also_tracked_in":      "also tracked in %s",
+	"this_week":            "This Week",
+	"last_week":            "Last Week",
+	"change":               "Change",
+	"label":                "Label",
+	"reviewer":             "Reviewer",
+	"reviews":              "Reviews",
+	"approvals":            "Approvals",
+	"average_first_review": "Average time to first review: %s (%d pull requests)",
+	"time_to_merge":        "Time to Merge",
+	"repository":           "Repository",
+	"pull_requests":        "Pull Requests",
+	"median":               "Median",
+	"p90":                  "90th Percentile",
+	"reported_done":        "reported done the week of %s",
+	"release_notes":        "Release Notes",
+	"retrospective":        "Retrospective",
+	"what_shipped":         "What Shipped",
+	"carried_over":         "Carried Over",
+	"metrics":              "Metrics",
+	"metric":               "Metric",
+	"value":                "Value",
+	"items_done":           "Items done",
+	"prs_merged":           "Pull requests merged",
+	"issues_closed":        "Issues closed",
+	"planned_done":         "Planned items done",
+	"median_time_to_merge": "Median time to merge",
+	"went_well":            "What Went Well",
+	"needs_improvement":    "What Needs Improvement",
+	"action_items":         "Action Items",
+	"and_more":             "\u2026 and %d more",
+	"appendix":             "Appendix",
+	"item_type":            "Type",
+	"author":               "Author",
+	"created":              "Created",
+	"done_on":              "Done",
+	"labels":               "Labels",
+	"parent":               "Parent",
+}
+
+var (
+	englishMonths = []string{
+		"January", "February", "March", "April", "May", "June", "July",
+		"August", "September", "October", "November", "December",
+	}
+	englishWeekdays = []string{
+		"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday",
+	}
+)
+
+// Locale defines the date formatting and built-in strings to use in the
+// reports.  The month and weekday names replace the english names produced by
+// the January, Jan, Monday and Mon elements of the date format.
+type Locale struct {
+	DateFormat string            `yaml:"date_format"` // The golang time format to use for dates.
+	Months     []string          `yaml:"months"`      // The 12 month names, January first.
+	Weekdays   []string          `yaml:"weekdays"`    // The 7 weekday names, Sunday first.
+	Strings    map[string]string `yaml:"strings"`     // Replacements for the built-in strings.
+}
+
+// T returns the localized string for the key.
+func (l Locale) T(key string) string {
+	if s, ok := l.Strings[key]; ok && len(s) > 0 {
+		return s
+	}
+	return defaultStrings[key]
+}
+
+// Date returns the localized date.  The month and weekday names in the
+// format are looked up from the time instead of being substituted in the
+// formatted text, so literal text in the format is never altered.
+func (l Locale) Date(t time.Time) string {
+	format := l.DateFormat
+	if len(format) == 0 {
+		format = defaultDateFormat
+	}
+
+	var rv strings.Builder
+	for len(format) > 0 {
+		prefix, name, suffix := nextName(format)
+		rv.WriteString(t.Format(prefix))
+
+		switch name {
+		case "January":
+			rv.WriteString(l.month(t.Month()))
+		case "Jan":
+			rv.WriteString(short(l.month(t.Month())))
+		case "Monday":
+			rv.WriteString(l.weekday(t.Weekday()))
+		case "Mon":
+			rv.WriteString(short(l.weekday(t.Weekday())))
+		}
+		format = suffix
+	}
+
+	return rv.String()
+}
+
+// month returns the localized name of the month.
+func (l Locale) month(m time.Month) string {
+	if len(l.Months) == len(englishMonths) {
+		return l.Months[m-1]
+	}
+	return englishMonths[m-1]
+}
+
+// weekday returns the localized name of the weekday.
+func (l Locale) weekday(d time.Weekday) string {
+	if len(l.Weekdays) == len(englishWeekdays) {
+		return l.Weekdays[d]
+	}
+	return englishWeekdays[d]
+}
+
+// nextName splits the format around the first month or weekday name element
+// using the same rules as the time package: "Jan" and "Mon" are only elements
+// when they are not followed by a lower case letter.  If there is no name
+// element the whole format is returned as the prefix.
+func nextName(format string) (prefix, name, suffix string) {
+	for i := 0; i < len(format); i++ {
+		rest := format[i:]
+		for _, full := range []string{"January", "Monday"} {
+			if strings.HasPrefix(rest, full) {
+				return format[:i], full, rest[len(full):]
+			}
+			abbr := full[:3]
+			if strings.HasPrefix(rest, abbr) && !startsWithLower(rest[3:]) {
+				return format[:i], abbr, rest[3:]
+			}
+		}
+	}
+	return format, "", ""
+}
+
+// startsWithLower reports if the string starts with a lower case letter.
+func startsWithLower(s string) bool {
+	return len(s) > 0 && 'a' <= s[0] && s[0] <= 'z'
+}
+
+// short returns the abbreviated (3 letter) version of the name.
+func short(name string) string {
+	r := []rune(name)
+	if len(r) > 3 {
+		r = r[:3]
+	}
+	return string(r)
+}
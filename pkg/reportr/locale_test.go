@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocaleDate(t *testing.T) {
+	german := Locale{
+		Months: []string{
+			"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli",
+			"August", "September", "Oktober", "November", "Dezember",
+		},
+		Weekdays: []string{
+			"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag",
+		},
+	}
+
+	tests := []struct {
+		description string
+		locale      Locale
+		format      string
+		when        string
+		expect      string
+	}{
+		{
+			description: "default",
+			expect:      "Mar 4, 2022",
+		}, {
+			description: "custom format",
+			format:      "2006-01-02",
+			expect:      "2022-03-04",
+		}, {
+			description: "localized abbreviated",
+			locale:      german,
+			format:      "2. Jan 2006",
+			expect:      "4. Mär 2022",
+		}, {
+			description: "localized full",
+			locale:      german,
+			format:      "Monday, 2. January 2006",
+			expect:      "Freitag, 4. März 2022",
+		}, {
+			description: "literal text is not localized",
+			locale:      german,
+			format:      "Marketing: Jan 2",
+			expect:      "Marketing: Mär 4",
+		}, {
+			description: "literal weekday text is not localized",
+			locale:      german,
+			format:      "Mon 2 (Sunrise)",
+			when:        "2022-03-06T10:00:00Z",
+			expect:      "Son 6 (Sunrise)",
+		}, {
+			description: "lower case suffix is not a name",
+			locale:      german,
+			format:      "Janet, Monday",
+			expect:      "Janet, Freitag",
+		}, {
+			description: "english names with partial locale",
+			locale:      Locale{Months: []string{"Januar"}},
+			format:      "Mon Jan 2",
+			expect:      "Fri Mar 4",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			when := tc.when
+			if len(when) == 0 {
+				when = "2022-03-04T10:00:00Z"
+			}
+
+			tc.locale.DateFormat = tc.format
+			assert.Equal(tc.expect, tc.locale.Date(mustParseTime(when)))
+		})
+	}
+}
+
+func TestLocaleT(t *testing.T) {
+	assert := assert.New(t)
+
+	l := Locale{
+		Strings: map[string]string{
+			"by_label": "Nach Label",
+			"items":    "",
+		},
+	}
+
+	assert.Equal("Nach Label", l.T("by_label"))
+	assert.Equal("items", l.T("items"))
+	assert.Equal("Status Report", l.T("status_report"))
+	assert.Equal("", l.T("unknown"))
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LockFilename is the name of the file in the output directory that is present
+// while a run is writing reports or archiving items.
+const LockFilename = ".status-reportr.lock"
+
+var ErrLocked = errors.New("another run is in progress")
+
+// lockInfo is the content of the lock file, describing the run holding it.
+type lockInfo struct {
+	Host    string
+	PID     int
+	Started time.Time
+}
+
+// Lock creates the lock file in the directory so a second run fails fast
+// instead of archiving the same items and writing the same report files.  The
+// returned function removes the lock.  A lock left by a run that crashed has
+// to be removed by hand.
+func Lock(dir string) (func() error, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	file := filepath.Join(dir, LockFilename)
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, err
+		}
+
+		var held lockInfo
+		if buf, rerr := os.ReadFile(file); rerr == nil && json.Unmarshal(buf, &held) == nil {
+			return nil, fmt.Errorf("%w: pid %d on %s started %s holds %s, remove it if that run is gone",
+				ErrLocked, held.PID, held.Host, held.Started.Format(time.RFC3339), file)
+		}
+		return nil, fmt.Errorf("%w: %s is present, remove it if no run is in progress", ErrLocked, file)
+	}
+
+	host, _ := os.Hostname()
+	buf, _ := json.Marshal(lockInfo{Host: host, PID: os.Getpid(), Started: time.Now()})
+	_, err = f.Write(buf)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(file)
+		return nil, err
+	}
+
+	return func() error {
+		return os.Remove(file)
+	}, nil
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLock(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir := filepath.Join(t.TempDir(), "reports")
+
+	unlock, err := Lock(dir)
+	require.NoError(err)
+	assert.FileExists(filepath.Join(dir, LockFilename))
+
+	_, err = Lock(dir)
+	assert.ErrorIs(err, ErrLocked)
+	assert.ErrorContains(err, "pid ")
+
+	require.NoError(unlock())
+	assert.NoFileExists(filepath.Join(dir, LockFilename))
+
+	// A lock file that can not be read is still a lock.
+	require.NoError(os.WriteFile(filepath.Join(dir, LockFilename), []byte("junk"), 0644))
+	_, err = Lock(dir)
+	assert.ErrorIs(err, ErrLocked)
+	assert.ErrorContains(err, "is present")
+	require.NoError(os.Remove(filepath.Join(dir, LockFilename)))
+
+	unlock, err = Lock(dir)
+	require.NoError(err)
+	require.NoError(unlock())
+}
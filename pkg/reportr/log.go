@@ -0,0 +1,20 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import "fmt"
+
+// Logger receives the progress messages of the long running operations, like
+// fetching the items.  It defaults to printing to stdout.  Set it to nil to
+// discard the messages.
+var Logger = func(format string, args ...any) {
+	fmt.Printf(format+"\n", args...)
+}
+
+// logf sends the message to the Logger if there is one.
+func logf(format string, args ...any) {
+	if Logger != nil {
+		Logger(format, args...)
+	}
+}
@@ -0,0 +1,219 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Markdown defines how the items are written in the markdown reports.
+type Markdown struct {
+	EscapeTitles bool `yaml:"escape_titles"` // Escape the markdown characters in item titles.
+	TitleLength  int  `yaml:"title_length"`  // The maximum title length, 0 is unlimited.
+	Wrap         int  `yaml:"wrap"`          // The column to wrap lines at, 0 does not wrap.
+
+	// Use reference-style links with the definitions at the end of each
+	// section instead of inline links.
+	ReferenceLinks bool `yaml:"reference_links"`
+
+	HeadingLevel int    `yaml:"heading_level"`                  // The heading level of the report title.
+	Bullet       string `yaml:"bullet" validate:"one_of=-,*,+"` // The list item marker.
+}
+
+// Heading returns the heading marker for the depth below the report title,
+// with 0 being the title itself.
+func (m Markdown) Heading(depth int) string {
+	level := m.HeadingLevel
+	if level < 1 {
+		level = 1
+	}
+	level += depth
+	if level > 6 {
+		level = 6
+	}
+	return strings.Repeat("#", level)
+}
+
+// ListMarker returns the list item marker.
+func (m Markdown) ListMarker() string {
+	if len(m.Bullet) == 0 {
+		return "-"
+	}
+	return m.Bullet
+}
+
+// markdownEscaper escapes the characters that change the meaning of inline
+// markdown text.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	`*`, `\*`,
+	`_`, `\_`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`|`, `\|`,
+	`<`, `\<`,
+	`>`, `\>`,
+)
+
+// EscapeMarkdown escapes the text so it is rendered as-is in markdown.
+func EscapeMarkdown(text string) string {
+	text = markdownEscaper.Replace(text)
+	if strings.HasPrefix(text, "#") {
+		text = `\` + text
+	}
+	return text
+}
+
+// Title returns the item title ready to be placed in the markdown.
+func (m Markdown) Title(title string) string {
+	if m.TitleLength > 0 {
+		runes := []rune(title)
+		if len(runes) > m.TitleLength {
+			title = strings.TrimSpace(string(runes[:m.TitleLength])) + "..."
+		}
+	}
+	if m.EscapeTitles {
+		return EscapeMarkdown(title)
+	}
+	return title
+}
+
+// WrapMarkdown hard wraps the lines of the markdown text at the width.  List
+// items and quotes are continued with the matching indentation.  Headings,
+// tables, html lines and fenced code blocks are never wrapped, nor are words
+// longer than the width, like links.
+func WrapMarkdown(text string, width int) string {
+	if width <= 0 {
+		return text
+	}
+
+	lines := strings.Split(text, "\n")
+	rv := make([]string, 0, len(lines))
+	var fenced bool
+	for _, line := range lines {
+		fence := strings.HasPrefix(strings.TrimSpace(line), "```")
+		if fence {
+			fenced = !fenced
+		}
+		if fenced || fence {
+			rv = append(rv, line)
+			continue
+		}
+		rv = append(rv, wrapLine(line, width)...)
+	}
+	return strings.Join(rv, "\n")
+}
+
+// wrapLine wraps a single line of markdown.
+func wrapLine(line string, width int) []string {
+	body := strings.TrimLeft(line, " ")
+	if len([]rune(line)) <= width ||
+		strings.HasPrefix(body, "#") ||
+		strings.HasPrefix(body, "|") ||
+		strings.HasPrefix(body, "<") {
+		return []string{line}
+	}
+
+	// The first line keeps the list marker, the rest are indented to match
+	// it.  Quotes repeat the marker instead.
+	indent := line[:len(line)-len(body)]
+	first, cont := indent, indent
+	for _, marker := range []string{"- ", "* ", "+ ", "> "} {
+		if strings.HasPrefix(body, marker) {
+			first += marker
+			cont += "  "
+			if marker == "> " {
+				cont = first
+			}
+			body = body[len(marker):]
+			break
+		}
+	}
+
+	var rv []string
+	cur := first
+	empty := true
+	for _, word := range strings.Fields(body) {
+		if !empty && len([]rune(cur))+1+len([]rune(word)) > width {
+			rv = append(rv, cur)
+			cur = cont
+			empty = true
+		}
+		if !empty {
+			cur += " "
+		}
+		cur += word
+		empty = false
+	}
+	return append(rv, cur)
+}
+
+// links writes markdown links, either inline or as reference-style links with
+// the definitions collected to be written at the end of the section.  Plain
+// links are only the text.
+type links struct {
+	plain     bool
+	reference bool
+	labels    []string
+	urls      map[string]string
+}
+
+// newLinks creates the links for a section.
+func newLinks(cfg Config) *links {
+	return &links{
+		plain:     cfg.Redact.Enabled && cfg.Redact.URLs,
+		reference: cfg.Markdown.ReferenceLinks,
+		urls:      make(map[string]string),
+	}
+}
+
+// link returns the markdown link for the text and url.
+func (l *links) link(text, url string) string {
+	if l.plain {
+		return text
+	}
+	if !l.reference || len(url) == 0 {
+		return fmt.Sprintf("[%s](%s)", text, url)
+	}
+
+	label := refLabel(url)
+	if _, ok := l.urls[label]; !ok {
+		l.labels = append(l.labels, label)
+		l.urls[label] = url
+	}
+	return fmt.Sprintf("[%s][%s]", text, label)
+}
+
+// write writes the collected reference definitions, if any, and resets the
+// collection.
+func (l *links) write(w io.Writer) {
+	if len(l.labels) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+	for _, label := range l.labels {
+		fmt.Fprintf(w, "[%s]: %s\n", label, l.urls[label])
+	}
+	l.labels = nil
+	l.urls = make(map[string]string)
+}
+
+// refLabel returns a short, readable reference label for the github url, like
+// "org/repo#12" for an issue or pull request and "org/repo" for a repository.
+func refLabel(url string) string {
+	label := url
+	if i := strings.Index(label, "://"); i >= 0 {
+		label = label[i+3:]
+		if j := strings.Index(label, "/"); j >= 0 {
+			label = label[j+1:]
+		}
+	}
+	label = strings.Replace(label, "/issues/", "#", 1)
+	label = strings.Replace(label, "/pull/", "#", 1)
+	return strings.NewReplacer("[", "", "]", "").Replace(label)
+}
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownTitle(t *testing.T) {
+	tests := []struct {
+		description string
+		markdown    Markdown
+		title       string
+		expect      string
+	}{
+		{
+			description: "plain",
+			markdown:    Markdown{EscapeTitles: true},
+			title:       "Fix the thing",
+			expect:      "Fix the thing",
+		}, {
+			description: "special characters",
+			markdown:    Markdown{EscapeTitles: true},
+			title:       "Fix *bold* _under_ [link](x) a|b `code` <br> c:\\dir",
+			expect:      "Fix \\*bold\\* \\_under\\_ \\[link\\](x) a\\|b \\`code\\` \\<br\\> c:\\\\dir",
+		}, {
+			description: "leading hash",
+			markdown:    Markdown{EscapeTitles: true},
+			title:       "# not a heading",
+			expect:      "\\# not a heading",
+		}, {
+			description: "truncated before escaping",
+			markdown:    Markdown{EscapeTitles: true, TitleLength: 9},
+			title:       "Fix *the* long title",
+			expect:      "Fix \\*the\\*...",
+		}, {
+			description: "short enough",
+			markdown:    Markdown{TitleLength: 20},
+			title:       "Fix the long title",
+			expect:      "Fix the long title",
+		}, {
+			description: "opted out",
+			title:       "Fix *bold*",
+			expect:      "Fix *bold*",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expect, tc.markdown.Title(tc.title))
+		})
+	}
+}
+
+func TestMarkdownHeadingAndListMarker(t *testing.T) {
+	tests := []struct {
+		description string
+		markdown    Markdown
+		depth       int
+		heading     string
+		marker      string
+	}{
+		{
+			description: "defaults",
+			heading:     "#",
+			marker:      "-",
+		}, {
+			description: "section below the default title",
+			depth:       1,
+			heading:     "##",
+			marker:      "-",
+		}, {
+			description: "embedded fragment",
+			markdown:    Markdown{HeadingLevel: 3, Bullet: "*"},
+			depth:       1,
+			heading:     "####",
+			marker:      "*",
+		}, {
+			description: "capped at the deepest heading",
+			markdown:    Markdown{HeadingLevel: 5, Bullet: "+"},
+			depth:       2,
+			heading:     "######",
+			marker:      "+",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			assert.Equal(tc.heading, tc.markdown.Heading(tc.depth))
+			assert.Equal(tc.marker, tc.markdown.ListMarker())
+		})
+	}
+}
+
+func TestWrapMarkdown(t *testing.T) {
+	tests := []struct {
+		description string
+		text        string
+		width       int
+		expect      string
+	}{
+		{
+			description: "no wrapping",
+			text:        "a long line of text",
+			expect:      "a long line of text",
+		}, {
+			description: "paragraph",
+			text:        "a long line of text",
+			width:       10,
+			expect:      "a long\nline of\ntext",
+		}, {
+			description: "list item",
+			text:        "- a long line of text\n  - nested long line",
+			width:       12,
+			expect:      "- a long\n  line of\n  text\n  - nested\n    long\n    line",
+		}, {
+			description: "quote",
+			text:        "  > quoted long text",
+			width:       13,
+			expect:      "  > quoted\n  > long text",
+		}, {
+			description: "fenced code",
+			text:        "```mermaid\nline [1, 2, 3, 4]\n```\na long line",
+			width:       8,
+			expect:      "```mermaid\nline [1, 2, 3, 4]\n```\na long\nline",
+		}, {
+			description: "long words are kept",
+			text:        "- [#1](https://github.com/org/repo/issues/1) done",
+			width:       10,
+			expect:      "- [#1](https://github.com/org/repo/issues/1)\n  done",
+		}, {
+			description: "headings, tables and html are kept",
+			text:        "## a long heading line\n| a | long | table |\n<details><summary>x</summary>",
+			width:       10,
+			expect:      "## a long heading line\n| a | long | table |\n<details><summary>x</summary>",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expect, WrapMarkdown(tc.text, tc.width))
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// mergeTimes returns the time from opening to merging each of the merged pull
+// requests in the list, by repository slug.
+func mergeTimes(r ReportWindow, list Items) map[string][]time.Duration {
+	rv := make(map[string][]time.Duration)
+	for _, item := range list {
+		if item.ItemType != "PR" || item.Abandoned || item.CreatedAt.IsZero() || item.DoneAt.IsZero() {
+			continue
+		}
+		rv[item.Repo.Slug] = append(rv[item.Repo.Slug], r.Duration(item.CreatedAt, item.DoneAt))
+	}
+	return rv
+}
+
+// percentile returns the nearest rank percentile of the sorted durations.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// Render writes the metrics section with the median and 90th percentile time
+// to merge the pull requests of each repository, and of all of them.  Nothing
+// is written if no pull requests were merged.
+func (m Metrics) Render(cfg Config, list Items, w io.Writer) {
+	byRepo := mergeTimes(cfg.ReportWindow, list)
+	if len(byRepo) == 0 {
+		return
+	}
+
+	repos := make([]string, 0, len(byRepo))
+	var all []time.Duration
+	for repo, times := range byRepo {
+		repos = append(repos, repo)
+		all = append(all, times...)
+	}
+	sort.Strings(repos)
+
+	fmt.Fprintf(w, "\n%s %s\n\n", cfg.Markdown.Heading(1), m.Name)
+	fmt.Fprintf(w, "%s %s\n\n", cfg.Markdown.Heading(2), cfg.Locale.T("time_to_merge"))
+	fmt.Fprintf(w, "| %s | %s | %s | %s |\n", cfg.Locale.T("repository"), cfg.Locale.T("pull_requests"),
+		cfg.Locale.T("median"), cfg.Locale.T("p90"))
+	fmt.Fprintf(w, "| --- | ---: | ---: | ---: |\n")
+
+	row := func(name string, times []time.Duration) {
+		sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+		fmt.Fprintf(w, "| %s | %d | %s | %s |\n", name, len(times),
+			formatDuration(percentile(times, 50)), formatDuration(percentile(times, 90)))
+	}
+	for _, repo := range repos {
+		row(repo, byRepo[repo])
+	}
+	if len(repos) > 1 {
+		row("**"+cfg.Locale.T("total")+"**", all)
+	}
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	start := mustParseTime("2022-11-14T08:00:00Z")
+	pr := func(slug string, hours int) Item {
+		it := Item{ItemType: "PR", CreatedAt: start, DoneAt: start.Add(time.Duration(hours) * time.Hour)}
+		it.Repo.Slug = slug
+		return it
+	}
+
+	tests := []struct {
+		description string
+		list        Items
+		expect      string
+	}{
+		{
+			description: "no pull requests",
+			list:        Items{{ItemType: "ISSUE"}},
+		}, {
+			description: "one repository",
+			list:        Items{pr("org/api", 2), pr("org/api", 4), pr("org/api", 30)},
+			expect: `
+## Metrics
+
+### Time to Merge
+
+| Repository | Pull Requests | Median | 90th Percentile |
+| --- | ---: | ---: | ---: |
+| org/api | 3 | 4h 0m | 1d 6h |
+`,
+		}, {
+			description: "several repositories",
+			list: Items{
+				pr("org/web", 1), pr("org/api", 2), pr("org/api", 4),
+				{ItemType: "PR", Abandoned: true, CreatedAt: start, DoneAt: start.Add(time.Hour)},
+			},
+			expect: `
+## Metrics
+
+### Time to Merge
+
+| Repository | Pull Requests | Median | 90th Percentile |
+| --- | ---: | ---: | ---: |
+| org/api | 2 | 2h 0m | 4h 0m |
+| org/web | 1 | 1h 0m | 1h 0m |
+| **Total** | 3 | 2h 0m | 4h 0m |
+`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var buf strings.Builder
+			Metrics{Enabled: true, Name: "Metrics"}.Render(Config{}, tc.list, &buf)
+			assert.Equal(tc.expect, buf.String())
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	assert := assert.New(t)
+
+	list := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	assert.Equal(time.Duration(0), percentile(nil, 50))
+	assert.Equal(time.Duration(5), percentile(list, 50))
+	assert.Equal(time.Duration(9), percentile(list, 90))
+	assert.Equal(time.Duration(10), percentile(list, 100))
+}
@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// notionVersion is the version of the notion api used.
+const notionVersion = "2022-06-28"
+
+// notion upserts each reported item as a row in a notion database.  The rows
+// are matched by the URL property, so the same item is only present once.
+// The database must have these properties:
+//
+//	Name       - title
+//	Repository - text
+//	Section    - select
+//	Week       - date
+//	URL        - url
+type notion struct {
+	url      string
+	database string
+	token    string
+	formats  []string // The report formats delivered, empty delivers all.
+}
+
+// newNotionDeliverer creates a notion deliverer using the integration token in
+// the NOTION_TOKEN environment variable.
+func newNotionDeliverer(d Delivery) (Deliverer, error) {
+	if len(d.Database) == 0 {
+		return nil, fmt.Errorf("notion delivery requires a database")
+	}
+
+	n := notion{
+		url:      "https://api.notion.com",
+		database: d.Database,
+		token:    os.Getenv("NOTION_TOKEN"),
+		formats:  d.Formats,
+	}
+	if len(n.token) == 0 {
+		return nil, fmt.Errorf("%w: notion requires NOTION_TOKEN", ErrMissingCredentials)
+	}
+	if len(d.Endpoint) > 0 {
+		n.url = strings.TrimSuffix(d.Endpoint, "/")
+	}
+
+	return &n, nil
+}
+
+// Deliver syncs the items of the report's week.  The items are the same for
+// every format of a report, so they are only synced for the first configured
+// format delivered to the database.
+func (n *notion) Deliver(cfg Config, r Report) error {
+	if first := n.firstFormat(cfg); len(first) > 0 && r.Format != first {
+		return nil
+	}
+	week := r.Week.Start.Format("2006-01-02")
+
+	var count int
+	for _, c := range Classify(cfg, r.Week.Items) {
+		for _, item := range c.Items {
+			if err := n.upsert(item, c.Section.Name, r.Week); err != nil {
+				return err
+			}
+			count++
+		}
+	}
+
+	logf("Synced %d items to notion for the week of %s", count, week)
+	return nil
+}
+
+// firstFormat returns the first of the report formats that is delivered to the
+// database, or an empty string if none of them are.
+func (n *notion) firstFormat(cfg Config) string {
+	for _, f := range cfg.Formats {
+		if (Delivery{Formats: n.formats}).Wants(f) {
+			return f
+		}
+	}
+	return ""
+}
+
+// upsert creates or updates the row for the item.
+func (n *notion) upsert(item Item, section string, week WeeklyItems) error {
+	props := map[string]any{
+		"Name": map[string]any{
+			"title": []any{notionText(item.Title())},
+		},
+		"Repository": map[string]any{
+			"rich_text": []any{notionText(item.Repo.Slug)},
+		},
+		"Section": map[string]any{
+			"select": map[string]string{"name": notionSelect(section)},
+		},
+		"Week": map[string]any{
+			"date": map[string]string{
+				"start": week.Start.Format("2006-01-02"),
+				"end":   week.End.AddDate(0, 0, -1).Format("2006-01-02"),
+			},
+		},
+		"URL": map[string]any{
+			"url": item.URL,
+		},
+	}
+
+	var found struct {
+		Results []struct {
+			ID string `json:"id"`
+		} `json:"results"`
+	}
+	query := map[string]any{
+		"filter": map[string]any{
+			"property": "URL",
+			"url":      map[string]string{"equals": item.URL},
+		},
+	}
+	err := n.do(http.MethodPost, "/v1/databases/"+n.database+"/query", query, &found)
+	if err != nil {
+		return err
+	}
+
+	if len(found.Results) == 0 {
+		return n.do(http.MethodPost, "/v1/pages", map[string]any{
+			"parent":     map[string]string{"database_id": n.database},
+			"properties": props,
+		}, nil)
+	}
+
+	return n.do(http.MethodPatch, "/v1/pages/"+found.Results[0].ID, map[string]any{
+		"properties": props,
+	}, nil)
+}
+
+// do sends the request to the notion api and decodes the response into out if
+// it is not nil.
+func (n *notion) do(method, path string, in, out any) error {
+	return doJSON("notion", method, n.url+path, in, out, func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer "+n.token)
+		req.Header.Set("Notion-Version", notionVersion)
+	})
+}
+
+// notionText returns the rich text object for the plain text.
+func notionText(s string) map[string]any {
+	return map[string]any{
+		"text": map[string]string{"content": s},
+	}
+}
+
+// notionSelect returns the select option name, which can not contain commas.
+func notionSelect(s string) string {
+	return strings.ReplaceAll(s, ",", " ")
+}
@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotionDeliverer(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	t.Setenv("NOTION_TOKEN", "secret")
+
+	var lock sync.Mutex
+	var requests []string
+	pages := map[string]map[string]any{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(notionVersion, r.Header.Get("Notion-Version"))
+
+		var body map[string]any
+		assert.NoError(json.NewDecoder(r.Body).Decode(&body))
+
+		lock.Lock()
+		defer lock.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		if r.URL.Path == "/v1/databases/db/query" {
+			// Pretend the first item is already present.
+			url := body["filter"].(map[string]any)["url"].(map[string]any)["equals"]
+			if url == itemIssue88.URL {
+				_, _ = w.Write([]byte(`{"results":[{"id":"row88"}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"results":[]}`))
+			return
+		}
+		pages[r.Method+" "+r.URL.Path] = body["properties"].(map[string]any)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	d, err := NewDeliverer(Delivery{Type: "notion", Database: "db", Endpoint: server.URL})
+	require.NoError(err)
+
+	cfg := Config{
+		Formats:  []string{"markdown", "html"},
+		Sections: []Section{{Name: "Labeled", Match: Match{Labels: []string{"*"}}}},
+		Unclassified: Unclassified{
+			Name: "Other",
+		},
+	}
+	week := WeeklyItems{
+		Items: Items{itemIssue88, itemPr23},
+		Start: mustParseTime("2022-11-27T00:00:00Z"),
+		End:   mustParseTime("2022-12-04T00:00:00Z"),
+	}
+
+	require.NoError(d.Deliver(cfg, Report{Week: week, Format: "markdown"}))
+	require.NoError(d.Deliver(cfg, Report{Week: week, Format: "html"}))
+
+	assert.Equal([]string{
+		"POST /v1/databases/db/query",
+		"PATCH /v1/pages/row88",
+		"POST /v1/databases/db/query",
+		"POST /v1/pages",
+	}, requests)
+
+	updated := pages["PATCH /v1/pages/row88"]
+	require.NotNil(updated)
+	assert.Equal("Labeled", updated["Section"].(map[string]any)["select"].(map[string]any)["name"])
+	assert.Equal("2022-12-03", updated["Week"].(map[string]any)["date"].(map[string]any)["end"])
+
+	created := pages["POST /v1/pages"]
+	require.NotNil(created)
+	assert.Equal(itemPr23.URL, created["URL"].(map[string]any)["url"])
+	assert.Equal("Other", created["Section"].(map[string]any)["select"].(map[string]any)["name"])
+}
+
+func TestNotionDelivererWeeks(t *testing.T) {
+	tests := []struct {
+		description string
+		formats     []string
+		reports     []Report
+		expect      int
+	}{
+		{
+			description: "one sync per report",
+			reports: []Report{
+				{Format: "markdown"},
+				{Format: "html"},
+			},
+			expect: 1,
+		}, {
+			description: "two projects with the same week",
+			reports: []Report{
+				{Format: "markdown", Path: "a/2022.11.27-2022.12.03.md"},
+				{Format: "html", Path: "a/2022.11.27-2022.12.03.html"},
+				{Format: "markdown", Path: "b/2022.11.27-2022.12.03.md"},
+				{Format: "html", Path: "b/2022.11.27-2022.12.03.html"},
+			},
+			expect: 2,
+		}, {
+			description: "later runs of the same week",
+			reports: []Report{
+				{Format: "markdown"},
+				{Format: "markdown"},
+				{Format: "markdown"},
+			},
+			expect: 3,
+		}, {
+			description: "only some formats delivered",
+			formats:     []string{"html"},
+			reports: []Report{
+				{Format: "markdown"},
+				{Format: "html"},
+			},
+			expect: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			t.Setenv("NOTION_TOKEN", "secret")
+
+			var queries int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/v1/databases/db/query" {
+					queries++
+				}
+				_, _ = w.Write([]byte(`{"results":[]}`))
+			}))
+			defer server.Close()
+
+			d, err := NewDeliverer(Delivery{Type: "notion", Database: "db", Endpoint: server.URL, Formats: tc.formats})
+			require.NoError(err)
+
+			cfg := Config{Formats: []string{"markdown", "html"}}
+			week := WeeklyItems{
+				Items: Items{itemPr23},
+				Start: mustParseTime("2022-11-27T00:00:00Z"),
+				End:   mustParseTime("2022-12-04T00:00:00Z"),
+			}
+			for _, r := range tc.reports {
+				r.Week = week
+				require.NoError(d.Deliver(cfg, r))
+			}
+
+			assert.Equal(tc.expect, queries)
+		})
+	}
+}
+
+func TestNewNotionDelivererErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Setenv("NOTION_TOKEN", "")
+
+	_, err := NewDeliverer(Delivery{Type: "notion"})
+	assert.Error(err)
+
+	_, err = NewDeliverer(Delivery{Type: "notion", Database: "db"})
+	assert.True(errors.Is(err, ErrMissingCredentials))
+}
@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ArchiveMutation is the name of the graphql mutation that archives an item.
+const ArchiveMutation = "archiveProjectV2Item"
+
+var (
+	ErrPlanSignature = errors.New("the plan signature does not match")
+	ErrPlanInvalid   = errors.New("invalid plan")
+)
+
+// Plan is what a run does, so a dry run can show exactly what the real run
+// would change: the files written, the items archived and the github
+// mutations that archive them.
+//
+// A plan written by the plan command also has the contents of the files, by
+// path relative to the output directory, so applying it writes exactly the
+// reports that were reviewed.
+type Plan struct {
+	Owner      string            `json:"owner"`
+	Project    int               `json:"project"`
+	Files      []string          `json:"files"`
+	Archive    []PlanItem        `json:"archive"`
+	Mutations  []PlanMutation    `json:"mutations"`
+	Windows    []PlanWindow      `json:"windows,omitempty"`
+	Deliveries []PlanDelivery    `json:"deliveries,omitempty"`
+	Contents   map[string][]byte `json:"contents,omitempty"`
+}
+
+// PlanWindow is a report window that is marked archived once its items are.
+type PlanWindow struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// PlanDelivery is a report that would be sent to a delivery target.
+type PlanDelivery struct {
+	Type string `json:"type"`
+	Path string `json:"path"`
+}
+
+// PlanItem is an item that would be archived.
+type PlanItem struct {
+	ID    string `json:"id"`
+	URL   string `json:"url,omitempty"`
+	Title string `json:"title,omitempty"`
+}
+
+// PlanMutation is a github mutation that would run.
+type PlanMutation struct {
+	Mutation string `json:"mutation"`
+	ItemID   string `json:"item_id"`
+}
+
+// NewPlan returns the plan of a run that writes the files and archives the
+// items of the weeks.  The files are sorted and listed once, even if the run
+// writes one more than once.
+func NewPlan(cfg Config, files []string, weeks []WeeklyItems) Plan {
+	p := Plan{
+		Owner:     cfg.Owner,
+		Project:   cfg.Project,
+		Files:     []string{},
+		Archive:   []PlanItem{},
+		Mutations: []PlanMutation{},
+	}
+
+	for _, file := range files {
+		if !contains(p.Files, file) {
+			p.Files = append(p.Files, file)
+		}
+	}
+	sort.Strings(p.Files)
+
+	for _, week := range weeks {
+		p.Windows = append(p.Windows, PlanWindow{Start: week.Start, End: week.End})
+		for _, item := range week.Items {
+			p.Archive = append(p.Archive, PlanItem{
+				ID:    item.ID,
+				URL:   item.URL,
+				Title: item.Title(),
+			})
+			p.Mutations = append(p.Mutations, PlanMutation{
+				Mutation: ArchiveMutation,
+				ItemID:   item.ID,
+			})
+		}
+	}
+
+	return p
+}
+
+// Text returns the plan in a form for people to read.
+func (p Plan) Text() string {
+	var buf strings.Builder
+
+	fmt.Fprintf(&buf, "Plan for project %d of %s\n", p.Project, p.Owner)
+
+	fmt.Fprintf(&buf, "\nFiles written (%d):\n", len(p.Files))
+	for _, file := range p.Files {
+		fmt.Fprintf(&buf, "  %s\n", file)
+	}
+
+	fmt.Fprintf(&buf, "\nItems to archive (%d):\n", len(p.Archive))
+	for _, item := range p.Archive {
+		line := item.ID
+		if len(item.URL) > 0 {
+			line += "  " + item.URL
+		}
+		if len(item.Title) > 0 {
+			line += "  " + item.Title
+		}
+		fmt.Fprintf(&buf, "  %s\n", line)
+	}
+
+	fmt.Fprintf(&buf, "\nMutations to run (%d):\n", len(p.Mutations))
+	for _, m := range p.Mutations {
+		fmt.Fprintf(&buf, "  %s(itemId: %s)\n", m.Mutation, m.ItemID)
+	}
+
+	if len(p.Deliveries) > 0 {
+		fmt.Fprintf(&buf, "\nDeliveries to make (%d):\n", len(p.Deliveries))
+		for _, d := range p.Deliveries {
+			fmt.Fprintf(&buf, "  %s  %s\n", d.Type, d.Path)
+		}
+	}
+
+	return buf.String()
+}
+
+// signedPlan is the plan file, the plan and its signature.
+type signedPlan struct {
+	Plan      json.RawMessage `json:"plan"`
+	Signature string          `json:"signature"`
+}
+
+// signature returns the hmac-sha256 of the compact json of the plan.
+func signature(plan []byte, key string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(plan)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignPlan returns the plan file of the plan signed with the key.
+func SignPlan(p Plan, key string) ([]byte, error) {
+	buf, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(signedPlan{Plan: buf, Signature: signature(buf, key)}, "", "  ")
+}
+
+// OpenPlan returns the plan of the plan file after checking it was signed with
+// the key and only writes files inside the output directory.
+func OpenPlan(file []byte, key string) (Plan, error) {
+	var signed signedPlan
+	if err := json.Unmarshal(file, &signed); err != nil {
+		return Plan{}, fmt.Errorf("%w: %v", ErrPlanInvalid, err)
+	}
+
+	// The plan is indented in the file, the signature is of the compact form.
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, signed.Plan); err != nil {
+		return Plan{}, fmt.Errorf("%w: %v", ErrPlanInvalid, err)
+	}
+	if !hmac.Equal([]byte(signature(buf.Bytes(), key)), []byte(signed.Signature)) {
+		return Plan{}, ErrPlanSignature
+	}
+
+	var p Plan
+	if err := json.Unmarshal(buf.Bytes(), &p); err != nil {
+		return Plan{}, fmt.Errorf("%w: %v", ErrPlanInvalid, err)
+	}
+	for _, file := range p.Files {
+		clean := filepath.Clean(file)
+		if filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+			return Plan{}, fmt.Errorf("%w: the file '%s' is outside the output directory", ErrPlanInvalid, file)
+		}
+		if _, ok := p.Contents[file]; !ok {
+			return Plan{}, fmt.Errorf("%w: the file '%s' has no contents", ErrPlanInvalid, file)
+		}
+	}
+
+	return p, nil
+}
@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlan(t *testing.T) {
+	cfg := Config{Owner: "org", Project: 7}
+	weeks := []WeeklyItems{
+		{Items: Items{
+			{ID: "a", URL: "https://github.com/org/repo/pull/1", Fields: map[string]Field{"Title": {Type: FIELD_TEXT, Text: "Add a thing"}}},
+		}},
+		{Items: Items{
+			{ID: "b"},
+		}},
+	}
+
+	tests := []struct {
+		description string
+		files       []string
+		weeks       []WeeklyItems
+		deliveries  []PlanDelivery
+		expect      Plan
+		text        string
+	}{
+		{
+			description: "nothing to do",
+			expect: Plan{
+				Owner:     "org",
+				Project:   7,
+				Files:     []string{},
+				Archive:   []PlanItem{},
+				Mutations: []PlanMutation{},
+			},
+			text: "Plan for project 7 of org\n\n" +
+				"Files written (0):\n\n" +
+				"Items to archive (0):\n\n" +
+				"Mutations to run (0):\n",
+		}, {
+			description: "files and items",
+			files:       []string{"out/b.md", "out/a.md", "out/b.md"},
+			weeks:       weeks,
+			expect: Plan{
+				Owner:   "org",
+				Project: 7,
+				Files:   []string{"out/a.md", "out/b.md"},
+				Archive: []PlanItem{
+					{ID: "a", URL: "https://github.com/org/repo/pull/1", Title: "Add a thing"},
+					{ID: "b"},
+				},
+				Mutations: []PlanMutation{
+					{Mutation: ArchiveMutation, ItemID: "a"},
+					{Mutation: ArchiveMutation, ItemID: "b"},
+				},
+				Windows: []PlanWindow{{}, {}},
+			},
+			text: "Plan for project 7 of org\n\n" +
+				"Files written (2):\n  out/a.md\n  out/b.md\n\n" +
+				"Items to archive (2):\n  a  https://github.com/org/repo/pull/1  Add a thing\n  b\n\n" +
+				"Mutations to run (2):\n  archiveProjectV2Item(itemId: a)\n  archiveProjectV2Item(itemId: b)\n",
+		}, {
+			description: "deliveries",
+			files:       []string{"out/a.md"},
+			deliveries: []PlanDelivery{
+				{Type: "s3", Path: "out/a.md"},
+				{Type: "exec", Path: "out/a.md"},
+			},
+			expect: Plan{
+				Owner:     "org",
+				Project:   7,
+				Files:     []string{"out/a.md"},
+				Archive:   []PlanItem{},
+				Mutations: []PlanMutation{},
+				Deliveries: []PlanDelivery{
+					{Type: "s3", Path: "out/a.md"},
+					{Type: "exec", Path: "out/a.md"},
+				},
+			},
+			text: "Plan for project 7 of org\n\n" +
+				"Files written (1):\n  out/a.md\n\n" +
+				"Items to archive (0):\n\n" +
+				"Mutations to run (0):\n\n" +
+				"Deliveries to make (2):\n  s3  out/a.md\n  exec  out/a.md\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			got := NewPlan(cfg, tc.files, tc.weeks)
+			got.Deliveries = tc.deliveries
+			assert.Equal(tc.expect, got)
+			assert.Equal(tc.text, got.Text())
+
+			buf, err := json.Marshal(got)
+			require.NoError(err)
+			var back Plan
+			require.NoError(json.Unmarshal(buf, &back))
+			assert.Equal(tc.expect, back)
+		})
+	}
+}
+
+func TestSignPlan(t *testing.T) {
+	plan := Plan{
+		Owner:     "org",
+		Project:   7,
+		Files:     []string{"2022-W48.md"},
+		Archive:   []PlanItem{{ID: "a"}},
+		Mutations: []PlanMutation{{Mutation: ArchiveMutation, ItemID: "a"}},
+		Contents:  map[string][]byte{"2022-W48.md": []byte("# Report\n")},
+	}
+
+	tests := []struct {
+		description string
+		plan        Plan
+		key         string
+		edit        func(string) string
+		expectErr   error
+	}{
+		{
+			description: "signed with the key",
+			plan:        plan,
+			key:         "key",
+		}, {
+			description: "signed with another key",
+			plan:        plan,
+			key:         "other",
+			expectErr:   ErrPlanSignature,
+		}, {
+			description: "changed after signing",
+			plan:        plan,
+			key:         "key",
+			edit: func(s string) string {
+				return strings.Replace(s, `"id": "a"`, `"id": "b"`, 1)
+			},
+			expectErr: ErrPlanSignature,
+		}, {
+			description: "not a plan",
+			plan:        plan,
+			key:         "key",
+			edit:        func(string) string { return "{" },
+			expectErr:   ErrPlanInvalid,
+		}, {
+			description: "a file outside the output directory",
+			plan: Plan{
+				Files:    []string{"../escape.md"},
+				Contents: map[string][]byte{"../escape.md": nil},
+			},
+			key:       "key",
+			expectErr: ErrPlanInvalid,
+		}, {
+			description: "a file without contents",
+			plan:        Plan{Files: []string{"2022-W48.md"}},
+			key:         "key",
+			expectErr:   ErrPlanInvalid,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			buf, err := SignPlan(tc.plan, "key")
+			require.NoError(err)
+			if tc.edit != nil {
+				buf = []byte(tc.edit(string(buf)))
+			}
+
+			got, err := OpenPlan(buf, tc.key)
+			if tc.expectErr != nil {
+				assert.ErrorIs(err, tc.expectErr)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tc.plan, got)
+		})
+	}
+}
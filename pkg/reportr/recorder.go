@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// ErrNotRecorded is returned when a request being replayed was not recorded.
+var ErrNotRecorded = errors.New("no recorded response")
+
+// recordingKey returns the name of the file holding the response to the
+// request body.  The same query with the same variables always has the same
+// name, so the responses can be found when replayed.
+func recordingKey(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:8])
+}
+
+// readRequest returns the body of the request, leaving the request readable.
+func readRequest(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// recorder saves the raw graphql requests and the successful responses in a
+// directory so they can be replayed later.  The credentials are part of the
+// headers, so they are never saved.
+type recorder struct {
+	dir  string
+	next http.RoundTripper
+}
+
+func (r recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	if err = os.MkdirAll(r.dir, 0755); err != nil {
+		return nil, err
+	}
+	key := filepath.Join(r.dir, recordingKey(body))
+	if err = os.WriteFile(key+".request.json", body, 0644); err != nil {
+		return nil, err
+	}
+	if err = os.WriteFile(key+".json", data, 0644); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// replayer answers the graphql requests with the responses saved by the
+// recorder instead of calling github.
+type replayer struct {
+	dir string
+}
+
+func (r replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	key := recordingKey(body)
+	data, err := os.ReadFile(filepath.Join(r.dir, key+".json"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w in %s for the request %s", ErrNotRecorded, r.dir, key)
+		}
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       req,
+	}, nil
+}
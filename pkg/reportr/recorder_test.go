@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordReplay(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		r.Body.Close()
+		assert.Equal("Bearer secret", r.Header.Get("Authorization"))
+		calls++
+		fmt.Fprintln(w, `{"data": {"organization": {"projectV2": {"id": "projectId"}}}}`)
+	}))
+	defer ts.Close()
+
+	dir := filepath.Join(t.TempDir(), "recording")
+
+	id, err := FetchProjectInfo("example", 55, Login(Config{Url: ts.URL, Token: "secret", Record: dir}))
+	require.NoError(err)
+	assert.Equal("projectId", id)
+	assert.Equal(1, calls)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(err)
+	require.Len(files, 2)
+	for _, f := range files {
+		buf, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		require.NoError(err)
+		assert.False(strings.Contains(string(buf), "secret"))
+	}
+
+	// The replay doesn't need the server or the token.
+	replay := Login(Config{Url: "http://invalid.invalid", Replay: dir})
+
+	id, err = FetchProjectInfo("example", 55, replay)
+	require.NoError(err)
+	assert.Equal("projectId", id)
+	assert.Equal(1, calls)
+
+	_, err = FetchProjectInfo("example", 56, replay)
+	assert.ErrorContains(err, ErrNotRecorded.Error())
+}
@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Redact defines what is removed from the items before the reports are
+// rendered, so the reports can be shared outside of the organization.
+type Redact struct {
+	Enabled     bool   `yaml:"enabled"`                                 // Redact the reports if enabled.
+	Mode        string `yaml:"mode" validate:"one_of=hash,placeholder"` // How the values are replaced.
+	Salt        string `yaml:"salt" secret:"true"`                      // The salt added to the hashed values.
+	Placeholder string `yaml:"placeholder"`                             // The value used in placeholder mode.
+	URLs        bool   `yaml:"urls"`                                    // Replace the urls with hashes and leave out the links.
+	Repos       bool   `yaml:"repos"`                                   // Replace the repository names.
+	People      bool   `yaml:"people"`                                  // Replace the authors and assignees.
+	Bodies      bool   `yaml:"bodies"`                                  // Remove the item bodies.
+}
+
+// Week returns a copy of the week with the items and the authors of the status
+// updates redacted.
+func (r Redact) Week(week WeeklyItems) WeeklyItems {
+	if !r.Enabled {
+		return week
+	}
+
+	week.Items = r.Items(week.Items)
+	week.Open = r.Items(week.Open)
+	week.Planned = r.Items(week.Planned)
+	if r.People && week.Updates != nil {
+		updates := make([]StatusUpdate, 0, len(week.Updates))
+		for _, u := range week.Updates {
+			u.Author = r.replace("user", u.Author)
+			updates = append(updates, u)
+		}
+		week.Updates = updates
+	}
+	return week
+}
+
+// Items returns a copy of the list with the items redacted.
+func (r Redact) Items(list Items) Items {
+	if !r.Enabled || list == nil {
+		return list
+	}
+
+	rv := make(Items, 0, len(list))
+	for _, item := range list {
+		rv = append(rv, r.item(item))
+	}
+	return rv
+}
+
+// item returns the redacted copy of the item.
+func (r Redact) item(item Item) Item {
+	fields := make(map[string]Field, len(item.Fields))
+	for name, f := range item.Fields {
+		switch f.Type {
+		case FIELD_REPOSITORY:
+			if r.Repos {
+				f.Text = r.replace("repo", f.Text)
+			}
+			if r.URLs {
+				f.URL = r.hash("url", f.URL)
+			}
+		case FIELD_USERS:
+			if r.People {
+				users := make([]string, 0, len(f.Users))
+				for _, u := range f.Users {
+					users = append(users, r.replace("user", u))
+				}
+				f.Users = users
+			}
+		case FIELD_PULL_REQUESTS:
+			if r.URLs {
+				f.PullRequests = nil
+			}
+		}
+		fields[name] = f
+	}
+	item.Fields = fields
+
+	if r.URLs {
+		item.URL = r.hash("url", item.URL)
+		item.Repo.URL = r.hash("url", item.Repo.URL)
+		if item.Parent != nil {
+			parent := *item.Parent
+			parent.URL = r.hash("url", parent.URL)
+			item.Parent = &parent
+		}
+	}
+	if r.Repos && len(item.Repo.Slug) > 0 {
+		item.Repo.Slug = r.replace("repo", item.Repo.Slug)
+		item.Repo.Name = item.Repo.Slug
+		item.Repo.Branch = ""
+	}
+	if r.People {
+		item.Reviews = r.reviews(item)
+	}
+	if r.People && len(item.Author) > 0 && !item.IsBot() {
+		item.Author = r.replace("user", item.Author)
+	}
+	if r.Bodies {
+		item.Body = ""
+	}
+
+	return item
+}
+
+// reviews returns the redacted copy of the reviews of the item.  The reviews
+// by the author are left out, since they are never counted and the author can
+// not be told apart from the other reviewers once replaced by a placeholder.
+func (r Redact) reviews(item Item) []Review {
+	if item.Reviews == nil {
+		return nil
+	}
+
+	rv := make([]Review, 0, len(item.Reviews))
+	for _, review := range item.Reviews {
+		if review.Author == item.Author {
+			continue
+		}
+		if !strings.HasSuffix(review.Author, "[bot]") {
+			review.Author = r.replace("user", review.Author)
+		}
+		rv = append(rv, review)
+	}
+	return rv
+}
+
+// replace returns the replacement for the value of the kind, like "repo".
+func (r Redact) replace(kind, value string) string {
+	if r.Mode == "placeholder" && len(value) > 0 {
+		return r.Placeholder
+	}
+	return r.hash(kind, value)
+}
+
+// hash returns the hash of the value of the kind, like "repo-1a2b3c4d".  The
+// hashes are stable so the same value is always replaced the same way.
+func (r Redact) hash(kind, value string) string {
+	if len(value) == 0 {
+		return value
+	}
+
+	sum := sha256.Sum256([]byte(r.Salt + kind + ":" + value))
+	return kind + "-" + hex.EncodeToString(sum[:4])
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	item := itemIssue88
+	item.Parent = &Parent{Number: 1, Title: "Epic", URL: "https://github.com/org/repo/issues/1"}
+	item.Fields = map[string]Field{
+		"Title":     {Type: FIELD_TEXT, Text: "Keep me"},
+		"Assignees": {Type: FIELD_USERS, Users: []string{"octocat"}},
+		"Repo":      {Type: FIELD_REPOSITORY, Text: "org/repo", URL: "https://github.com/org/repo"},
+		"PRs":       {Type: FIELD_PULL_REQUESTS, PullRequests: []string{"https://github.com/org/repo/pull/2"}},
+	}
+	bot := itemPr23
+
+	all := Redact{Enabled: true, Mode: "hash", URLs: true, Repos: true, People: true, Bodies: true}
+
+	tests := []struct {
+		description string
+		redact      Redact
+		check       func(*assert.Assertions, Item, Item)
+	}{
+		{
+			description: "disabled",
+			redact:      Redact{URLs: true, Repos: true, People: true, Bodies: true},
+			check: func(assert *assert.Assertions, got, bot Item) {
+				assert.Equal(item, got)
+			},
+		}, {
+			description: "hashed",
+			redact:      all,
+			check: func(assert *assert.Assertions, got, bot Item) {
+				assert.Equal("Keep me", got.Title())
+				assert.Regexp(`^url-[0-9a-f]{8}$`, got.URL)
+				assert.Regexp(`^url-[0-9a-f]{8}$`, got.Parent.URL)
+				assert.Equal("https://github.com/org/repo/issues/1", item.Parent.URL)
+				assert.Regexp(`^repo-[0-9a-f]{8}$`, got.Repo.Slug)
+				assert.Equal(got.Repo.Slug, got.Fields["Repo"].Text)
+				assert.Regexp(`^user-[0-9a-f]{8}$`, got.Author)
+				assert.Equal([]string{got.Author}, got.Fields["Assignees"].Users)
+				assert.Empty(got.Fields["PRs"].PullRequests)
+				assert.Empty(got.Body)
+				assert.Equal("dependabot[bot]", bot.Author)
+			},
+		}, {
+			description: "salted",
+			redact:      Redact{Enabled: true, Mode: "hash", Salt: "pepper", Repos: true},
+			check: func(assert *assert.Assertions, got, bot Item) {
+				assert.NotEqual(all.replace("repo", "org/repo"), got.Repo.Slug)
+				assert.Equal(item.URL, got.URL)
+				assert.Equal(item.Author, got.Author)
+			},
+		}, {
+			description: "placeholder",
+			redact:      Redact{Enabled: true, Mode: "placeholder", Placeholder: "internal", Repos: true, People: true},
+			check: func(assert *assert.Assertions, got, bot Item) {
+				assert.Equal("internal", got.Repo.Slug)
+				assert.Equal("internal", got.Author)
+				assert.Equal(item.Body, got.Body)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			week := tc.redact.Week(WeeklyItems{Items: Items{item, bot}})
+			tc.check(assert, week.Items[0], week.Items[1])
+		})
+	}
+}
+
+func TestRenderRedacted(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := Config{
+		Team:         "Team",
+		Unclassified: Unclassified{Name: "Other"},
+		Redact:       Redact{Enabled: true, Mode: "placeholder", Placeholder: "internal", URLs: true, Repos: true},
+	}
+	got := Render(cfg, cfg.Redact.Week(WeeklyItems{Items: Items{itemIssue88}}))
+	assert.Contains(got, "- An example item title. **[#88]** (internal)\n")
+	assert.NotContains(got, "github.com")
+}
+
+func TestRedactStatusUpdates(t *testing.T) {
+	updates := []StatusUpdate{
+		{Status: "ON_TRACK", Author: "octocat", Body: "Going well"},
+		{Status: "AT_RISK"},
+	}
+
+	tests := []struct {
+		description string
+		redact      Redact
+		expect      []string
+	}{
+		{
+			description: "disabled",
+			redact:      Redact{People: true},
+			expect:      []string{"octocat", ""},
+		}, {
+			description: "people kept",
+			redact:      Redact{Enabled: true, Mode: "hash", URLs: true},
+			expect:      []string{"octocat", ""},
+		}, {
+			description: "hashed",
+			redact:      Redact{Enabled: true, Mode: "hash", People: true},
+			expect:      []string{(Redact{}).hash("user", "octocat"), ""},
+		}, {
+			description: "placeholder",
+			redact:      Redact{Enabled: true, Mode: "placeholder", Placeholder: "internal", People: true},
+			expect:      []string{"internal", ""},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			week := tc.redact.Week(WeeklyItems{Updates: updates})
+			authors := make([]string, 0, len(week.Updates))
+			for _, u := range week.Updates {
+				authors = append(authors, u.Author)
+			}
+			assert.Equal(tc.expect, authors)
+			assert.Equal("Going well", week.Updates[0].Body)
+			assert.Equal("octocat", updates[0].Author)
+
+			var buf strings.Builder
+			renderStatusUpdates(Config{}, week.Updates, &buf)
+			if tc.redact.Enabled && tc.redact.People {
+				assert.NotContains(buf.String(), "octocat")
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ReleaseNotes defines how the merged pull requests of a window are turned into
+// release notes for each repository.
+type ReleaseNotes struct {
+	Groups    []ReleaseNotesGroup `yaml:"groups"`     // The groups of pull requests, in order.
+	Other     string              `yaml:"other"`      // The name of the group of the unmatched pull requests.
+	TagFormat string              `yaml:"tag_format"` // The golang time format of the draft release tag.
+}
+
+// ReleaseNotesGroup is a group of the release notes, like the features.
+type ReleaseNotesGroup struct {
+	Name  string `yaml:"name"`     // The name of the group.
+	Match Match  `yaml:"match_on"` // The matching criteria of the pull requests in the group.
+}
+
+// ByRepo returns the release notes of each repository with merged pull
+// requests in the list, and the sorted repository slugs.  Each pull request is
+// only listed in the first group it matches.  The group headings are at the
+// depth below the title.
+func (r ReleaseNotes) ByRepo(cfg Config, list Items, depth int) (map[string]string, []string) {
+	repos := make(map[string]Items)
+	var slugs []string
+	for _, item := range list {
+		if item.ItemType != "PR" || item.Abandoned {
+			continue
+		}
+		if _, ok := repos[item.Repo.Slug]; !ok {
+			slugs = append(slugs, item.Repo.Slug)
+		}
+		repos[item.Repo.Slug] = append(repos[item.Repo.Slug], item)
+	}
+	sort.Strings(slugs)
+
+	notes := make(map[string]string, len(slugs))
+	for _, slug := range slugs {
+		var buf strings.Builder
+		left := repos[slug]
+		for _, g := range r.Groups {
+			var mine Items
+			mine, left = Section{Match: g.Match}.Extract(left)
+			r.renderGroup(cfg, depth, g.Name, mine, &buf)
+		}
+		r.renderGroup(cfg, depth, r.Other, left, &buf)
+		notes[slug] = buf.String()
+	}
+
+	return notes, slugs
+}
+
+// renderGroup writes the group of pull requests, if there are any.
+func (r ReleaseNotes) renderGroup(cfg Config, depth int, name string, list Items, buf *strings.Builder) {
+	if len(list) == 0 {
+		return
+	}
+
+	if buf.Len() > 0 {
+		buf.WriteString("\n")
+	}
+	fmt.Fprintf(buf, "%s %s\n\n", cfg.Markdown.Heading(depth), name)
+	for _, item := range list {
+		fmt.Fprintf(buf, "%s %s ([#%d](%s))", cfg.Markdown.ListMarker(),
+			cfg.Markdown.Title(item.Title()), item.Number, item.URL)
+		if len(item.Author) > 0 && !item.IsBot() {
+			fmt.Fprintf(buf, " @%s", item.Author)
+		}
+		buf.WriteString("\n")
+	}
+}
+
+// Render returns the release notes of every repository in the week as a
+// single document.
+func (r ReleaseNotes) Render(cfg Config, week WeeklyItems) string {
+	notes, slugs := r.ByRepo(cfg, week.Items, 2)
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%s %s: %s ... %s\n", cfg.Markdown.Heading(0), cfg.Locale.T("release_notes"),
+		cfg.Locale.Date(week.Start), cfg.Locale.Date(week.End.AddDate(0, 0, -1)))
+	for _, slug := range slugs {
+		fmt.Fprintf(&buf, "\n%s %s\n\n%s", cfg.Markdown.Heading(1), slug, notes[slug])
+	}
+	return buf.String()
+}
+
+// PublishDraftRelease creates a draft github release of the repository with
+// the notes.  The release is left as a draft for a person to review, tag and
+// publish.
+func PublishDraftRelease(cfg Config, slug, tag, notes string) error {
+	body := struct {
+		TagName string `json:"tag_name"`
+		Name    string `json:"name"`
+		Body    string `json:"body"`
+		Draft   bool   `json:"draft"`
+	}{
+		TagName: tag,
+		Name:    tag,
+		Body:    notes,
+		Draft:   true,
+	}
+
+	return doJSON("release", http.MethodPost, githubRestURL(cfg)+"/repos/"+slug+"/releases", body, nil,
+		func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+cfg.Token)
+		})
+}
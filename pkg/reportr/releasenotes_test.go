@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseNotes(t *testing.T) {
+	assert := assert.New(t)
+
+	pr := func(slug string, number int, title, author string, labels ...string) Item {
+		it := Item{
+			ItemType: "PR",
+			Number:   number,
+			URL:      fmt.Sprintf("https://github.com/%s/pull/%d", slug, number),
+			Author:   author,
+			Labels:   labels,
+			Fields:   map[string]Field{"Title": {Type: FIELD_TEXT, Name: "Title", Text: title}},
+		}
+		it.Repo.Slug = slug
+		return it
+	}
+
+	notes := ReleaseNotes{
+		Groups: []ReleaseNotesGroup{
+			{Name: "Features", Match: Match{Labels: []string{"feature"}}},
+			{Name: "Fixes", Match: Match{Labels: []string{"bug"}}},
+		},
+		Other: "Other Changes",
+	}
+	abandoned := pr("org/api", 4, "Never merged", "alice", "feature")
+	abandoned.Abandoned = true
+	week := WeeklyItems{
+		Start: mustParseTime("2022-11-13T00:00:00Z"),
+		End:   mustParseTime("2022-11-20T00:00:00Z"),
+		Items: Items{
+			pr("org/web", 1, "Fix the login", "alice", "bug"),
+			pr("org/api", 2, "Add the search", "bob", "feature", "bug"),
+			pr("org/api", 3, "Bump x", "dependabot[bot]"),
+			abandoned,
+			{ItemType: "ISSUE", Number: 5},
+		},
+	}
+
+	byRepo, slugs := notes.ByRepo(Config{}, week.Items, 1)
+	assert.Equal([]string{"org/api", "org/web"}, slugs)
+	assert.Equal(`## Features
+
+- Add the search ([#2](https://github.com/org/api/pull/2)) @bob
+
+## Other Changes
+
+- Bump x ([#3](https://github.com/org/api/pull/3))
+`, byRepo["org/api"])
+
+	assert.Equal(`# Release Notes: 2022-11-13 ... 2022-11-19
+
+## org/api
+
+### Features
+
+- Add the search ([#2](https://github.com/org/api/pull/2)) @bob
+
+### Other Changes
+
+- Bump x ([#3](https://github.com/org/api/pull/3))
+
+## org/web
+
+### Fixes
+
+- Fix the login ([#1](https://github.com/org/web/pull/1)) @alice
+`, notes.Render(Config{Locale: Locale{DateFormat: "2006-01-02"}}, week))
+}
+
+func TestPublishDraftRelease(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(http.MethodPost, r.Method)
+		assert.Equal("/api/v3/repos/org/api/releases", r.URL.Path)
+		assert.Equal("Bearer token", r.Header.Get("Authorization"))
+		assert.NoError(json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	cfg := Config{Url: server.URL + "/api/graphql", Token: "token"}
+	require.NoError(PublishDraftRelease(cfg, "org/api", "2022.11.19", "notes"))
+	assert.Equal(map[string]any{
+		"tag_name": "2022.11.19",
+		"name":     "2022.11.19",
+		"body":     "notes",
+		"draft":    true,
+	}, got)
+}
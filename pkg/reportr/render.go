@@ -0,0 +1,452 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	gql "github.com/hasura/go-graphql-client"
+)
+
+// MergeArchived fetches the previously reported items that are no longer on the
+// board because they were archived and merges them into the list.  Items that
+// can not be fetched are passed to skip if it is not nil.
+func MergeArchived(cfg Config, client *gql.Client, list Items, reported []string, skip SkipFunc) (Items, error) {
+	present := make(map[string]bool, len(list))
+	for _, item := range list {
+		present[item.ID] = true
+	}
+
+	var missing []string
+	for _, id := range reported {
+		if !present[id] {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return list, nil
+	}
+
+	archived, err := FetchItemsByID(missing, client,
+		cfg.Tuning.IssueCount,
+		cfg.Tuning.LabelCount,
+		cfg.Tuning.FieldValueCount,
+		skip)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := append(cfg.Cancelled.Mark(archived), list...)
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Done().Before(merged[j].Done())
+	})
+
+	return merged, nil
+}
+
+// ClassifiedItems is a section and the items that belong to it.
+type ClassifiedItems struct {
+	Section Section
+	Items   Items
+}
+
+// Classify splits the list of items into the sections they belong to, in the
+// same order the items are matched when rendering.  The unclassified items
+// are last.  Cancelled items and draft pull requests are only included if they
+// have their own section.
+func Classify(cfg Config, list Items) []ClassifiedItems {
+	var rv []ClassifiedItems
+
+	cancelled, left := list.ExtractCancelled()
+	if cfg.Cancelled.Mode == "section" {
+		rv = append(rv, ClassifiedItems{
+			Section: cfg.Cancelled.section(),
+			Items:   cancelled,
+		})
+	}
+
+	drafts, left := cfg.Drafts.Extract(left)
+	if cfg.Drafts.Mode == "section" {
+		rv = append(rv, ClassifiedItems{
+			Section: cfg.Drafts.section(),
+			Items:   drafts,
+		})
+	}
+
+	if cfg.Abandoned.Mode == "section" {
+		var mine Items
+		mine, left = left.ExtractAbandoned()
+		rv = append(rv, ClassifiedItems{
+			Section: cfg.Abandoned.section(),
+			Items:   mine,
+		})
+	}
+
+	if cfg.Dependencies.Enabled {
+		var mine Items
+		mine, left = Section{Match: cfg.Dependencies.Match}.Extract(left)
+		rv = append(rv, ClassifiedItems{
+			Section: Section{
+				Name:        cfg.Dependencies.Name,
+				RenderOrder: cfg.Dependencies.RenderOrder,
+			},
+			Items: mine,
+		})
+	}
+
+	matched, left := cfg.matchSections(left)
+	for i, section := range cfg.Sections {
+		rv = append(rv, ClassifiedItems{
+			Section: section,
+			Items:   matched[i],
+		})
+	}
+
+	return append(rv, ClassifiedItems{
+		Section: Section{
+			Name:        cfg.Unclassified.Name,
+			RenderOrder: cfg.Unclassified.RenderOrder,
+		},
+		Items: left,
+	})
+}
+
+// RenderOrderCollisions describes the enabled sections that share a render
+// order, like "10: Blocked Items, Bugs".  Sections sharing a render order are
+// rendered in the order they are declared.
+func (c Config) RenderOrderCollisions() []string {
+	type named struct {
+		order int
+		name  string
+	}
+	var all []named
+	if c.NewItems.Enabled && c.NewItems.Separate {
+		all = append(all, named{c.NewItems.RenderOrder, c.NewItems.Name})
+	}
+	if c.Cancelled.Mode == "section" {
+		all = append(all, named{c.Cancelled.RenderOrder, c.Cancelled.Name})
+	}
+	if c.Abandoned.Mode == "section" {
+		all = append(all, named{c.Abandoned.RenderOrder, c.Abandoned.Name})
+	}
+	if c.Drafts.Mode == "section" {
+		all = append(all, named{c.Drafts.RenderOrder, c.Drafts.Name})
+	}
+	if c.Dependencies.Enabled {
+		all = append(all, named{c.Dependencies.RenderOrder, c.Dependencies.Name})
+	}
+	for _, section := range c.Sections {
+		all = append(all, named{section.RenderOrder, section.Name})
+	}
+	all = append(all, named{c.Unclassified.RenderOrder, c.Unclassified.Name})
+	if c.Reopened.Enabled {
+		all = append(all, named{c.Reopened.RenderOrder, c.Reopened.Name})
+	}
+	if c.Blocked.Enabled {
+		all = append(all, named{c.Blocked.RenderOrder, c.Blocked.Name})
+	}
+	if c.Capacity.Enabled {
+		all = append(all, named{c.Capacity.RenderOrder, c.Capacity.Name})
+	}
+	if c.Compare.Enabled {
+		all = append(all, named{c.Compare.RenderOrder, c.Compare.Name})
+	}
+	if c.Highlights.Enabled {
+		all = append(all, named{c.Highlights.RenderOrder, c.Highlights.Name})
+	}
+	if c.Reviews.Enabled {
+		all = append(all, named{c.Reviews.RenderOrder, c.Reviews.Name})
+	}
+	if c.Metrics.Enabled {
+		all = append(all, named{c.Metrics.RenderOrder, c.Metrics.Name})
+	}
+	if c.NewContributors.Enabled {
+		all = append(all, named{c.NewContributors.RenderOrder, c.NewContributors.Name})
+	}
+	if c.LabelSection.Enabled {
+		all = append(all, named{c.LabelSection.RenderOrder, c.Locale.T("by_label")})
+	}
+	if c.RepoSection.Enabled {
+		all = append(all, named{c.RepoSection.RenderOrder, c.Locale.T("by_repository")})
+	}
+	if c.Contributors.Enabled {
+		all = append(all, named{c.Contributors.RenderOrder, c.Locale.T("by_contributor")})
+	}
+	if c.Summary.Enabled {
+		all = append(all, named{c.Summary.RenderOrder, c.Summary.Name})
+	}
+
+	names := make(map[int][]string)
+	var orders []int
+	for _, n := range all {
+		if _, ok := names[n.order]; !ok {
+			orders = append(orders, n.order)
+		}
+		names[n.order] = append(names[n.order], n.name)
+	}
+	sort.Ints(orders)
+
+	var rv []string
+	for _, order := range orders {
+		if len(names[order]) > 1 {
+			rv = append(rv, fmt.Sprintf("%d: %s", order, strings.Join(names[order], ", ")))
+		}
+	}
+	return rv
+}
+
+// ReportBasename returns the name of the report file for the week without the
+// file extension.
+func ReportBasename(cfg Config, week WeeklyItems) string {
+	if cfg.Fiscal.Enabled {
+		if label, err := cfg.Fiscal.Label(cfg, week.Start); err == nil {
+			return label
+		}
+	}
+	if cfg.ReportWindow.ISOWeeks {
+		year, num := week.Start.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, num)
+	}
+	return fmt.Sprintf("%s-%s",
+		week.Start.Format("2006.01.02"),
+		week.End.AddDate(0, 0, -1).Format("2006.01.02"))
+}
+
+// Render converts the week of items into a markdown status report.
+func Render(cfg Config, week WeeklyItems) string {
+	// The sections are kept in the order they are declared so the sections
+	// sharing a render order are rendered in a deterministic order.
+	type rendered struct {
+		order int
+		text  string
+	}
+	sections := make([]rendered, 0, len(cfg.Sections)+8)
+	cfg.Appendix = appendixName(cfg, week)
+	add := func(order int, text string) {
+		sections = append(sections, rendered{order: order, text: text})
+	}
+
+	// The cancelled items and excluded drafts are not counted as part of the
+	// week's work.
+	var cancelled, drafts Items
+	cancelled, week.Items = week.Items.ExtractCancelled()
+	drafts, week.Items = cfg.Drafts.Extract(week.Items)
+	if cfg.Cancelled.Mode == "section" {
+		var buf strings.Builder
+		cfg.Cancelled.section().Render(cfg, cancelled, &buf)
+		add(cfg.Cancelled.RenderOrder, buf.String())
+	}
+
+	if cfg.Drafts.Mode == "section" {
+		var buf strings.Builder
+		cfg.Drafts.section().Render(cfg, drafts, &buf)
+		add(cfg.Drafts.RenderOrder, buf.String())
+	}
+
+	left := week.Items
+
+	if cfg.Abandoned.Mode == "section" {
+		var buf strings.Builder
+		var mine Items
+		mine, left = left.ExtractAbandoned()
+		cfg.Abandoned.section().Render(cfg, mine, &buf)
+		add(cfg.Abandoned.RenderOrder, buf.String())
+	}
+
+	if cfg.NewItems.Enabled && cfg.NewItems.Separate && !week.Since.IsZero() {
+		var buf strings.Builder
+		var mine Items
+		mine, left = left.ExtractNew()
+		Section{
+			Name:        fmt.Sprintf("%s %s", cfg.NewItems.Name, cfg.Locale.Date(week.Since)),
+			RenderOrder: cfg.NewItems.RenderOrder,
+			OmitIfEmpty: true,
+		}.Render(cfg, mine, &buf)
+		add(cfg.NewItems.RenderOrder, buf.String())
+	}
+
+	if cfg.Dependencies.Enabled {
+		var buf strings.Builder
+		left = cfg.Dependencies.ExtractAndRender(cfg, left, &buf)
+		add(cfg.Dependencies.RenderOrder, buf.String())
+	}
+
+	matched, left := cfg.matchSections(left)
+	for i, section := range cfg.Sections {
+		var buf strings.Builder
+		section.Render(cfg, matched[i], &buf)
+		add(section.RenderOrder, buf.String())
+	}
+
+	if true {
+		var buf strings.Builder
+		Section{
+			Name:        cfg.Unclassified.Name,
+			RenderOrder: cfg.Unclassified.RenderOrder,
+			OmitIfEmpty: cfg.Unclassified.OmitIfEmpty,
+		}.Render(cfg, left, &buf)
+		add(cfg.Unclassified.RenderOrder, buf.String())
+	}
+
+	if cfg.Reopened.Enabled {
+		var buf strings.Builder
+		reopened, _ := week.Open.ExtractReopened()
+		Section{
+			Name:        cfg.Reopened.Name,
+			RenderOrder: cfg.Reopened.RenderOrder,
+			OmitIfEmpty: cfg.Reopened.OmitIfEmpty,
+		}.Render(cfg, reopened, &buf)
+		add(cfg.Reopened.RenderOrder, buf.String())
+	}
+
+	if cfg.Blocked.Enabled {
+		var buf strings.Builder
+		Section{
+			Name:        cfg.Blocked.Name,
+			RenderOrder: cfg.Blocked.RenderOrder,
+			OmitIfEmpty: cfg.Blocked.OmitIfEmpty,
+			Match:       cfg.Blocked.Match,
+		}.ExtractAndRender(cfg, week.Open, &buf)
+		add(cfg.Blocked.RenderOrder, buf.String())
+	}
+
+	if cfg.Compare.Enabled {
+		var buf strings.Builder
+		cfg.Compare.Render(cfg, week, &buf)
+		add(cfg.Compare.RenderOrder, buf.String())
+	}
+
+	if cfg.Highlights.Enabled {
+		var buf strings.Builder
+		cfg.Highlights.Render(cfg, week.Items, &buf)
+		add(cfg.Highlights.RenderOrder, buf.String())
+	}
+
+	if cfg.Reviews.Enabled {
+		var buf strings.Builder
+		cfg.Reviews.Render(cfg, week.Items, &buf)
+		add(cfg.Reviews.RenderOrder, buf.String())
+	}
+
+	if cfg.Metrics.Enabled {
+		var buf strings.Builder
+		cfg.Metrics.Render(cfg, week.Items, &buf)
+		add(cfg.Metrics.RenderOrder, buf.String())
+	}
+
+	if cfg.NewContributors.Enabled {
+		var buf strings.Builder
+		cfg.NewContributors.Render(week.Items, &buf)
+		add(cfg.NewContributors.RenderOrder, buf.String())
+	}
+
+	if cfg.Capacity.Enabled {
+		var buf strings.Builder
+		cfg.Capacity.Render(cfg, week.Planned, &buf)
+		if cfg.Capacity.Burndown.Enabled && week.Burndown != nil {
+			week.Burndown.Render(cfg, cfg.Capacity.Burndown.Format, &buf)
+		}
+		add(cfg.Capacity.RenderOrder, buf.String())
+	}
+
+	if cfg.LabelSection.Enabled {
+		var buf strings.Builder
+		fmt.Fprintf(&buf, "\n%s %s\n\n", cfg.Markdown.Heading(1), cfg.Locale.T("by_label"))
+		labels := week.Items.GetUniqLabels()
+		keys := make([]string, 0, len(labels))
+		for key := range labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			fmt.Fprintf(&buf, "%s %s (%d)\n", cfg.Markdown.ListMarker(), key, labels[key])
+		}
+
+		add(cfg.LabelSection.RenderOrder, buf.String())
+	}
+
+	if cfg.RepoSection.Enabled {
+		var buf strings.Builder
+		l := newLinks(cfg)
+		fmt.Fprintf(&buf, "\n%s %s\n\n", cfg.Markdown.Heading(1), cfg.Locale.T("by_repository"))
+		repos := week.Items.GetUniqRepos()
+		urls := week.Items.GetRepoURLs()
+		keys := make([]string, 0, len(repos))
+		for key := range repos {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			fmt.Fprintf(&buf, "%s %s (%d)\n", cfg.Markdown.ListMarker(), l.link(key, urls[key]), repos[key])
+		}
+		l.write(&buf)
+
+		add(cfg.RepoSection.RenderOrder, buf.String())
+	}
+
+	if cfg.Contributors.Enabled {
+		var buf strings.Builder
+		l := newLinks(cfg)
+		fmt.Fprintf(&buf, "\n%s %s\n\n", cfg.Markdown.Heading(1), cfg.Locale.T("by_contributor"))
+		authors := week.Items.GetByAuthor()
+		keys := make([]string, 0, len(authors))
+		for key, list := range authors {
+			if cfg.Contributors.ExcludeBots && list[0].IsBot() {
+				continue
+			}
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		if cfg.Contributors.SortByCount {
+			sort.SliceStable(keys, func(i, j int) bool {
+				return len(authors[keys[i]]) > len(authors[keys[j]])
+			})
+		}
+
+		for _, key := range keys {
+			links := make([]string, 0, len(authors[key]))
+			for _, item := range authors[key] {
+				links = append(links, l.link(fmt.Sprintf("#%d", item.Number), item.URL))
+			}
+			fmt.Fprintf(&buf, "%s %s (%d): %s\n", cfg.Markdown.ListMarker(), key, len(authors[key]), strings.Join(links, ", "))
+		}
+		l.write(&buf)
+
+		add(cfg.Contributors.RenderOrder, buf.String())
+	}
+
+	if cfg.Summary.Enabled {
+		var buf strings.Builder
+		fmt.Fprintf(&buf, "\n%s %s\n\n", cfg.Markdown.Heading(1), cfg.Summary.Name)
+		fmt.Fprintf(&buf, "%s\n\n", cfg.Summary.Body)
+		if cfg.StatusUpdates.Enabled && cfg.StatusUpdates.Placement == "summary" {
+			renderStatusUpdates(cfg, week.Updates, &buf)
+		}
+		add(cfg.Summary.RenderOrder, buf.String())
+	}
+
+	var rv strings.Builder
+
+	fmt.Fprintf(&rv, "%s\n\n", header(cfg, week))
+	if cfg.StatusUpdates.Enabled && cfg.StatusUpdates.Placement == "header" {
+		renderStatusUpdates(cfg, week.Updates, &rv)
+	}
+
+	sort.SliceStable(sections, func(i, j int) bool {
+		return sections[i].order < sections[j].order
+	})
+	for _, section := range sections {
+		rv.WriteString(section.text)
+	}
+
+	if f := footer(cfg, week); len(f) > 0 {
+		fmt.Fprintf(&rv, "\n%s\n", f)
+	}
+
+	return WrapMarkdown(rv.String(), cfg.Markdown.Wrap)
+}
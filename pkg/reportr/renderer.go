@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	gmhtml "github.com/yuin/goldmark/renderer/html"
+)
+
+// Renderer converts a week of items into a report of a specific format.
+type Renderer interface {
+	// Render returns the report and the file extension (including the leading
+	// '.') to use for it.
+	Render(cfg Config, week WeeklyItems) ([]byte, string, error)
+}
+
+// RendererFunc is an adapter to allow the use of ordinary functions as
+// Renderers.
+type RendererFunc func(cfg Config, week WeeklyItems) ([]byte, string, error)
+
+// Render calls f(cfg, week).
+func (f RendererFunc) Render(cfg Config, week WeeklyItems) ([]byte, string, error) {
+	return f(cfg, week)
+}
+
+var (
+	renderersLock sync.RWMutex
+	renderers     = map[string]Renderer{
+		"markdown": RendererFunc(renderMarkdown),
+		"html":     RendererFunc(renderHTML),
+		"json":     RendererFunc(renderJSON),
+		"retro":    RendererFunc(renderRetro),
+		"appendix": RendererFunc(renderAppendix),
+	}
+)
+
+// RegisterRenderer registers a renderer by name, replacing any existing
+// renderer with the same name.
+func RegisterRenderer(name string, r Renderer) {
+	renderersLock.Lock()
+	defer renderersLock.Unlock()
+
+	renderers[name] = r
+}
+
+// GetRenderer returns the renderer registered with the name.
+func GetRenderer(name string) (Renderer, bool) {
+	renderersLock.RLock()
+	defer renderersLock.RUnlock()
+
+	r, ok := renderers[name]
+	return r, ok
+}
+
+// RendererNames returns the sorted list of registered renderer names.
+func RendererNames() []string {
+	renderersLock.RLock()
+	defer renderersLock.RUnlock()
+
+	names := make([]string, 0, len(renderers))
+	for name := range renderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func renderMarkdown(cfg Config, week WeeklyItems) ([]byte, string, error) {
+	return []byte(Render(cfg, week)), ".md", nil
+}
+
+func renderHTML(cfg Config, week WeeklyItems) ([]byte, string, error) {
+	md := goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithRendererOptions(gmhtml.WithUnsafe()),
+	)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n</head>\n<body>\n",
+		html.EscapeString(cfg.Team))
+	if err := md.Convert([]byte(Render(cfg, week)), &buf); err != nil {
+		return nil, "", err
+	}
+	buf.WriteString("</body>\n</html>\n")
+
+	return buf.Bytes(), ".html", nil
+}
+
+// jsonReport is the structure of the json report.
+type jsonReport struct {
+	Team     string        `json:"team"`
+	Start    time.Time     `json:"start"`
+	End      time.Time     `json:"end"`
+	Sections []jsonSection `json:"sections"`
+}
+
+type jsonSection struct {
+	Name  string `json:"name"`
+	Items Items  `json:"items"`
+}
+
+func renderJSON(cfg Config, week WeeklyItems) ([]byte, string, error) {
+	report := jsonReport{
+		Team:  cfg.Team,
+		Start: week.Start,
+		End:   week.End,
+	}
+
+	for _, s := range Classify(cfg, week.Items) {
+		report.Sections = append(report.Sections, jsonSection{
+			Name:  s.Section.Name,
+			Items: s.Items,
+		})
+	}
+
+	buf, err := json.MarshalIndent(report, "", "    ")
+	if err != nil {
+		return nil, "", err
+	}
+	return buf, ".json", nil
+}
@@ -0,0 +1,425 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderers(t *testing.T) {
+	cfg := Config{
+		Team: "Team",
+		Sections: []Section{
+			{
+				Name: "Labeled",
+				Match: Match{
+					Labels: []string{"*"},
+				},
+			},
+		},
+		Unclassified: Unclassified{
+			Name:        "Other",
+			RenderOrder: 1000,
+		},
+	}
+	week := WeeklyItems{
+		Items: Items{itemIssue88, itemPr23},
+		Start: mustParseTime("2022-11-27T00:00:00Z"),
+		End:   mustParseTime("2022-12-04T00:00:00Z"),
+	}
+
+	tests := []struct {
+		format   string
+		ext      string
+		contains string
+	}{
+		{
+			format:   "markdown",
+			ext:      ".md",
+			contains: "## Labeled (1)",
+		}, {
+			format:   "html",
+			ext:      ".html",
+			contains: "<h2>Labeled (1)</h2>",
+		}, {
+			format:   "json",
+			ext:      ".json",
+			contains: `"name": "Other"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.format, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			r, ok := GetRenderer(tc.format)
+			require.True(ok)
+
+			data, ext, err := r.Render(cfg, week)
+			require.NoError(err)
+			assert.Equal(tc.ext, ext)
+			assert.Contains(string(data), tc.contains)
+		})
+	}
+}
+
+func TestRenderJSON(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	cfg := Config{
+		Sections: []Section{
+			{
+				Name: "Labeled",
+				Match: Match{
+					Labels: []string{"*"},
+				},
+			},
+		},
+	}
+
+	data, _, err := renderJSON(cfg, WeeklyItems{Items: Items{itemIssue88, itemPr23}})
+	require.NoError(err)
+
+	var got jsonReport
+	require.NoError(json.Unmarshal(data, &got))
+	require.Len(got.Sections, 2)
+	assert.Equal("Labeled", got.Sections[0].Name)
+	assert.Equal(88, got.Sections[0].Items[0].Number)
+	assert.Equal(23, got.Sections[1].Items[0].Number)
+}
+
+func TestRegisterRenderer(t *testing.T) {
+	assert := assert.New(t)
+
+	RegisterRenderer("test", RendererFunc(func(Config, WeeklyItems) ([]byte, string, error) {
+		return []byte("test"), ".txt", nil
+	}))
+
+	r, ok := GetRenderer("test")
+	assert.True(ok)
+	data, ext, err := r.Render(Config{}, WeeklyItems{})
+	assert.NoError(err)
+	assert.Equal("test", string(data))
+	assert.Equal(".txt", ext)
+	assert.Contains(RendererNames(), "test")
+
+	_, ok = GetRenderer("missing")
+	assert.False(ok)
+}
+
+func TestRenderSharedRenderOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := Config{
+		Team: "Team",
+		Sections: []Section{
+			{Name: "First", RenderOrder: 1, Match: Match{Labels: []string{"*"}}},
+			{Name: "Second", RenderOrder: 1, Match: Match{Authors: []string{"*"}}},
+		},
+		Unclassified: Unclassified{Name: "Other", RenderOrder: 2},
+		Blocked:      Blocked{Name: "Blocked"},
+		LabelSection: LabelSection{Enabled: true, RenderOrder: 2},
+	}
+	week := WeeklyItems{Items: Items{itemIssue88, itemPr23}}
+
+	got := Render(cfg, week)
+	first := strings.Index(got, "## First")
+	second := strings.Index(got, "## Second")
+	assert.True(first > 0 && second > first, got)
+
+	assert.Equal([]string{
+		"1: First, Second",
+		"2: Other, By Label",
+	}, cfg.RenderOrderCollisions())
+}
+
+func TestRenderMultiMatch(t *testing.T) {
+	sections := []Section{
+		{Name: "Deployments", RenderOrder: 1, Match: Match{Labels: []string{"deployment"}}},
+		{Name: "Octocat", RenderOrder: 2, Match: Match{Authors: []string{"octocat"}}},
+	}
+	week := WeeklyItems{Items: Items{itemIssue88}}
+
+	tests := []struct {
+		description string
+		multi       MultiMatch
+		expect      []int // The number of items in each section and unclassified.
+		note        bool
+	}{
+		{
+			description: "first match wins",
+			expect:      []int{1, 0, 0},
+		}, {
+			description: "every match",
+			multi:       MultiMatch{Enabled: true},
+			expect:      []int{1, 1, 0},
+		}, {
+			description: "every match with a note",
+			multi:       MultiMatch{Enabled: true, Note: true},
+			expect:      []int{1, 1, 0},
+			note:        true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			cfg := Config{
+				Team:         "Team",
+				Sections:     sections,
+				Unclassified: Unclassified{Name: "Other", RenderOrder: 3},
+				MultiMatch:   tc.multi,
+			}
+
+			classified := Classify(cfg, week.Items)
+			counts := make([]int, 0, len(classified))
+			for _, c := range classified {
+				counts = append(counts, len(c.Items))
+			}
+			assert.Equal(tc.expect, counts)
+
+			got := Render(cfg, week)
+			assert.Equal(tc.note, strings.Contains(got, "_(also in Octocat)_"), got)
+			assert.Equal(tc.note, strings.Contains(got, "_(also in Deployments)_"), got)
+		})
+	}
+}
+
+func TestRenderCancelled(t *testing.T) {
+	cancelled := itemIssue88
+	cancelled.Fields = map[string]Field{
+		"Title":  {Type: FIELD_TEXT, Text: "Dropped"},
+		"Status": {Type: FIELD_TEXT, Text: "won't do"},
+	}
+
+	tests := []struct {
+		description string
+		mode        string
+		cancelled   bool // If the item is marked cancelled.
+		section     bool // If the cancelled section is rendered.
+	}{
+		{
+			description: "ignored",
+			mode:        "ignore",
+		}, {
+			description: "excluded",
+			mode:        "exclude",
+			cancelled:   true,
+		}, {
+			description: "own section",
+			mode:        "section",
+			cancelled:   true,
+			section:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			cfg := Config{
+				Team:         "Team",
+				Unclassified: Unclassified{Name: "Other", RenderOrder: 3, OmitIfEmpty: true},
+				Cancelled: Cancelled{
+					Mode:        tc.mode,
+					Statuses:    []string{"Won't Do", "Cancelled"},
+					Name:        "Cancelled",
+					RenderOrder: 2,
+					OmitIfEmpty: true,
+				},
+			}
+
+			list := cfg.Cancelled.Mark(Items{cancelled})
+			assert.Equal(tc.cancelled, list[0].Cancelled)
+			assert.Equal(tc.cancelled, list[0].IsDone())
+			if !tc.cancelled {
+				return
+			}
+
+			got := Render(cfg, WeeklyItems{Items: list})
+			assert.Equal(tc.section, strings.Contains(got, "# Cancelled (1)"), got)
+			assert.NotContains(got, "Other", got)
+
+			classified := Classify(cfg, list)
+			assert.Equal(tc.section, len(classified) == 2)
+		})
+	}
+}
+
+func TestRenderAbandoned(t *testing.T) {
+	merged := itemIssue88
+	merged.Fields = map[string]Field{
+		"Title":  {Type: FIELD_TEXT, Text: "Merged"},
+		"Status": {Type: FIELD_TEXT, Text: "Done"},
+	}
+	abandoned := merged
+	abandoned.ID = "abandoned-id"
+	abandoned.ItemType = "PR"
+	abandoned.Abandoned = true
+	list := Items{merged, abandoned}
+
+	tests := []struct {
+		description string
+		mode        string
+		done        int
+		section     bool // If the abandoned section is rendered.
+	}{
+		{
+			description: "done",
+			mode:        "done",
+			done:        2,
+		}, {
+			description: "not done",
+			mode:        "not_done",
+			done:        1,
+		}, {
+			description: "own section",
+			mode:        "section",
+			done:        2,
+			section:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			cfg := Config{
+				Team:         "Team",
+				Unclassified: Unclassified{Name: "Other", RenderOrder: 3},
+				Abandoned: Abandoned{
+					Mode:        tc.mode,
+					Name:        "Abandoned",
+					RenderOrder: 2,
+					OmitIfEmpty: true,
+				},
+			}
+
+			done, open := cfg.Abandoned.Split(list)
+			assert.Len(done, tc.done)
+			assert.Len(open, len(list)-tc.done)
+
+			got := Render(cfg, WeeklyItems{Items: done})
+			assert.Equal(tc.section, strings.Contains(got, "# Abandoned (1)"), got)
+			assert.Equal(tc.section, len(Classify(cfg, done)) == 2)
+		})
+	}
+}
+
+func TestRenderDrafts(t *testing.T) {
+	draft := itemIssue88
+	draft.ItemType = "PR"
+	draft.Draft = true
+	week := WeeklyItems{Items: Items{itemIssue88, draft}}
+
+	tests := []struct {
+		description string
+		mode        string
+		expect      []int // The number of items in each classified section.
+	}{
+		{
+			description: "included",
+			mode:        "include",
+			expect:      []int{2},
+		}, {
+			description: "excluded",
+			mode:        "exclude",
+			expect:      []int{1},
+		}, {
+			description: "own section",
+			mode:        "section",
+			expect:      []int{1, 1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			cfg := Config{
+				Team:         "Team",
+				Unclassified: Unclassified{Name: "Other", RenderOrder: 3},
+				Drafts: Drafts{
+					Mode:        tc.mode,
+					Name:        "Drafts",
+					RenderOrder: 2,
+					OmitIfEmpty: true,
+				},
+			}
+
+			classified := Classify(cfg, week.Items)
+			counts := make([]int, 0, len(classified))
+			for _, c := range classified {
+				counts = append(counts, len(c.Items))
+			}
+			assert.Equal(tc.expect, counts)
+
+			got := Render(cfg, week)
+			assert.Equal(tc.mode == "section", strings.Contains(got, "# Drafts (1)"), got)
+			assert.Contains(got, fmt.Sprintf("# Other (%d)", tc.expect[len(tc.expect)-1]), got)
+		})
+	}
+}
+
+func TestRenderPriority(t *testing.T) {
+	priority := func(title string, n float64) Item {
+		item := itemIssue88
+		item.Fields = map[string]Field{
+			"Title":    {Type: FIELD_TEXT, Text: title},
+			"Priority": {Type: FIELD_NUMBER, Number: n},
+		}
+		return item
+	}
+	list := Items{priority("Urgent", 0), priority("Normal", 2), priority("Someday", 4), itemIssue88}
+
+	tests := []struct {
+		description string
+		priority    Priority
+		expect      []string
+		notExpect   []string
+	}{
+		{
+			description: "disabled",
+			priority:    Priority{High: 0, BoldHigh: true, Low: 3, CollapseLow: true},
+			notExpect:   []string{"**Urgent**", "<details>"},
+		}, {
+			description: "bold and marked",
+			priority:    Priority{Enabled: true, High: 0, BoldHigh: true, HighMarker: "🔥"},
+			expect:      []string{"- **Urgent** **[", "(https://github.com/org/repo)) 🔥\n- Normal"},
+			notExpect:   []string{"<details>"},
+		}, {
+			description: "low collapsed",
+			priority:    Priority{Enabled: true, High: -1, Low: 3, CollapseLow: true},
+			expect:      []string{"<details><summary>1 low priority items</summary>\n\n- Someday"},
+			notExpect:   []string{"**Urgent**"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			tc.priority.Field = "Priority"
+			cfg := Config{Priority: tc.priority}
+
+			var buf strings.Builder
+			Section{Name: "Work"}.Render(cfg, list, &buf)
+			for _, want := range tc.expect {
+				assert.Contains(buf.String(), want)
+			}
+			for _, want := range tc.notExpect {
+				assert.NotContains(buf.String(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import "time"
+
+// MarkReopened marks the items in the list that are not done, but were listed
+// as done in one of the previous reports, with the start of the newest report
+// they were listed in.
+func (h History) MarkReopened(open Items) Items {
+	reported := make(map[string]time.Time)
+	for _, r := range h.Reports {
+		for _, id := range r.ItemIDs {
+			if when, ok := reported[id]; !ok || r.Start.After(when) {
+				reported[id] = r.Start
+			}
+		}
+	}
+
+	rv := make(Items, 0, len(open))
+	for _, item := range open {
+		item.ReportedDone = reported[item.ID]
+		rv = append(rv, item)
+	}
+	return rv
+}
+
+// ExtractReopened returns the subset list of items that were reported as done
+// before being reopened, and a separate list of left over items.
+func (list Items) ExtractReopened() (matching, remaining Items) {
+	for _, item := range list {
+		if !item.ReportedDone.IsZero() {
+			matching = append(matching, item)
+		} else {
+			remaining = append(remaining, item)
+		}
+	}
+
+	return matching, remaining
+}
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReopened(t *testing.T) {
+	assert := assert.New(t)
+
+	h := History{
+		Reports: []ReportRecord{
+			{Start: mustParseTime("2022-11-14T00:00:00Z"), ItemIDs: []string{"a", "b"}},
+			{Start: mustParseTime("2022-11-07T00:00:00Z"), ItemIDs: []string{"a"}},
+		},
+	}
+
+	open := Items{
+		{ID: "a", Number: 1, URL: "https://github.com/org/repo/issues/1"},
+		{ID: "c", Number: 3, URL: "https://github.com/org/repo/issues/3"},
+	}
+	open = h.MarkReopened(open)
+	assert.Equal(mustParseTime("2022-11-14T00:00:00Z"), open[0].ReportedDone)
+	assert.True(open[1].ReportedDone.IsZero())
+
+	reopened, left := open.ExtractReopened()
+	assert.Equal(Items{open[0]}, reopened)
+	assert.Equal(Items{open[1]}, left)
+
+	var buf strings.Builder
+	Section{Name: "Reopened Items"}.Render(Config{}, reopened, &buf)
+	assert.Contains(buf.String(), "[#1](https://github.com/org/repo/issues/1)")
+	assert.Contains(buf.String(), "_(reported done the week of ")
+	assert.NotContains(buf.String(), "#3")
+}
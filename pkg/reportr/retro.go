@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// renderRetro renders the skeleton of a retrospective, pre-filled with what
+// shipped in the week, the items of the iteration carried over, and a few
+// metrics.  The sections to discuss are left empty.  The iteration items are
+// only known for the most recent week when the capacity is enabled.
+func renderRetro(cfg Config, week WeeklyItems) ([]byte, string, error) {
+	var buf strings.Builder
+	h1, h2 := cfg.Markdown.Heading(0), cfg.Markdown.Heading(1)
+	item := func(it Item) {
+		fmt.Fprintf(&buf, "%s %s ([#%d](%s))\n", cfg.Markdown.ListMarker(),
+			cfg.Markdown.Title(it.Title()), it.Number, it.URL)
+	}
+
+	fmt.Fprintf(&buf, "%s %s %s: %s ... %s\n", h1, cfg.Team, cfg.Locale.T("retrospective"),
+		cfg.Locale.Date(week.Start), cfg.Locale.Date(week.End.AddDate(0, 0, -1)))
+	if title := iterationTitle(cfg.Capacity.Field, week.Planned); len(title) > 0 {
+		fmt.Fprintf(&buf, "\n%s\n", title)
+	}
+
+	_, shipped := week.Items.ExtractCancelled()
+	fmt.Fprintf(&buf, "\n%s %s\n\n", h2, cfg.Locale.T("what_shipped"))
+	for _, s := range Classify(cfg, shipped) {
+		if len(s.Items) == 0 {
+			continue
+		}
+		fmt.Fprintf(&buf, "%s %s\n\n", cfg.Markdown.Heading(2), s.Section.Name)
+		for _, it := range s.Items {
+			item(it)
+		}
+		fmt.Fprintln(&buf)
+	}
+
+	carried := week.Planned.GetNotDone()
+	if len(carried) > 0 {
+		fmt.Fprintf(&buf, "%s %s\n\n", h2, cfg.Locale.T("carried_over"))
+		for _, it := range carried {
+			item(it)
+		}
+		fmt.Fprintln(&buf)
+	}
+
+	fmt.Fprintf(&buf, "%s %s\n\n", h2, cfg.Locale.T("metrics"))
+	fmt.Fprintf(&buf, "| %s | %s |\n| --- | ---: |\n", cfg.Locale.T("metric"), cfg.Locale.T("value"))
+	var prs, issues int
+	for _, it := range shipped {
+		switch {
+		case it.ItemType == "PR" && !it.Abandoned:
+			prs++
+		case it.ItemType == "ISSUE":
+			issues++
+		}
+	}
+	fmt.Fprintf(&buf, "| %s | %d |\n", cfg.Locale.T("items_done"), len(shipped))
+	fmt.Fprintf(&buf, "| %s | %d |\n", cfg.Locale.T("prs_merged"), prs)
+	fmt.Fprintf(&buf, "| %s | %d |\n", cfg.Locale.T("issues_closed"), issues)
+	if len(week.Planned) > 0 {
+		done := len(week.Planned) - len(carried)
+		fmt.Fprintf(&buf, "| %s | %d / %d (%d%%) |\n", cfg.Locale.T("planned_done"),
+			done, len(week.Planned), done*100/len(week.Planned))
+	}
+	var times []time.Duration
+	for _, list := range mergeTimes(cfg.ReportWindow, shipped) {
+		times = append(times, list...)
+	}
+	if len(times) > 0 {
+		sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+		fmt.Fprintf(&buf, "| %s | %s |\n", cfg.Locale.T("median_time_to_merge"), formatDuration(percentile(times, 50)))
+	}
+
+	for _, key := range []string{"went_well", "needs_improvement", "action_items"} {
+		fmt.Fprintf(&buf, "\n%s %s\n\n%s\n", h2, cfg.Locale.T(key), cfg.Markdown.ListMarker())
+	}
+
+	return []byte(buf.String()), ".retro.md", nil
+}
+
+// iterationTitle returns the title of the iteration field the planned items
+// are in.
+func iterationTitle(field string, planned Items) string {
+	for _, item := range planned {
+		if f, ok := item.Fields[strings.TrimSpace(field)]; ok {
+			return f.Title
+		}
+	}
+	return ""
+}
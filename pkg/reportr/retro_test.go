@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderRetro(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	start := mustParseTime("2022-11-13T00:00:00Z")
+	item := func(kind string, number int, title, status string) Item {
+		return Item{
+			ItemType:  kind,
+			Number:    number,
+			URL:       "https://github.com/org/repo/" + title,
+			CreatedAt: start,
+			DoneAt:    start.Add(time.Duration(number) * time.Hour),
+			Fields: map[string]Field{
+				"Title":  {Type: FIELD_TEXT, Name: "Title", Text: title},
+				"Status": {Type: FIELD_TEXT, Name: "Status", Text: status},
+				"Sprint": {Type: FIELD_ITERATION, Name: "Sprint", Title: "Sprint 7"},
+			},
+		}
+	}
+
+	shipped := Items{item("PR", 2, "search", "Done"), item("ISSUE", 4, "crash", "Done")}
+	cfg := Config{
+		Team:         "Team",
+		Locale:       Locale{DateFormat: "2006-01-02"},
+		Capacity:     Capacity{Field: "Sprint"},
+		Unclassified: Unclassified{Name: "Other"},
+	}
+	week := WeeklyItems{
+		Start:   start,
+		End:     start.AddDate(0, 0, 7),
+		Items:   shipped,
+		Planned: append(Items{item("ISSUE", 5, "login", "In Progress")}, shipped...),
+	}
+
+	data, ext, err := renderRetro(cfg, week)
+	require.NoError(err)
+	assert.Equal(".retro.md", ext)
+	assert.Equal(`# Team Retrospective: 2022-11-13 ... 2022-11-19
+
+Sprint 7
+
+## What Shipped
+
+### Other
+
+- search ([#2](https://github.com/org/repo/search))
+- crash ([#4](https://github.com/org/repo/crash))
+
+## Carried Over
+
+- login ([#5](https://github.com/org/repo/login))
+
+## Metrics
+
+| Metric | Value |
+| --- | ---: |
+| Items done | 2 |
+| Pull requests merged | 1 |
+| Issues closed | 1 |
+| Planned items done | 2 / 3 (66%) |
+| Median time to merge | 2h 0m |
+
+## What Went Well
+
+-
+
+## What Needs Improvement
+
+-
+
+## Action Items
+
+-
+`, string(data))
+}
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// reviewer is the review activity of a single person.
+type reviewer struct {
+	name      string
+	reviews   int
+	approvals int
+}
+
+// reviewActivity returns the people that reviewed the merged pull requests in
+// the list, with the most reviews first, and the average time from opening the
+// pull requests to their first review, in business days if configured.  The
+// authors reviewing or commenting on their own pull requests are not counted.
+// If the people are redacted the items no longer have those reviews, and the
+// authors can not be compared since they may all share a placeholder.
+func reviewActivity(r ReportWindow, list Items, redacted bool) (people []reviewer, firstReview time.Duration, reviewed int) {
+	byName := make(map[string]*reviewer)
+	var total time.Duration
+	for _, item := range list {
+		if item.ItemType != "PR" || item.Abandoned {
+			continue
+		}
+
+		var first time.Time
+		for _, r := range item.Reviews {
+			if (!redacted && r.Author == item.Author) || len(r.Author) == 0 {
+				continue
+			}
+			p, ok := byName[r.Author]
+			if !ok {
+				p = &reviewer{name: r.Author}
+				byName[r.Author] = p
+			}
+			p.reviews++
+			if r.State == "APPROVED" {
+				p.approvals++
+			}
+			if first.IsZero() || r.SubmittedAt.Before(first) {
+				first = r.SubmittedAt
+			}
+		}
+
+		if !first.IsZero() && !item.CreatedAt.IsZero() {
+			total += r.Duration(item.CreatedAt, first)
+			reviewed++
+		}
+	}
+
+	for _, p := range byName {
+		people = append(people, *p)
+	}
+	sort.Slice(people, func(i, j int) bool {
+		if people[i].reviews != people[j].reviews {
+			return people[i].reviews > people[j].reviews
+		}
+		return people[i].name < people[j].name
+	})
+
+	if reviewed > 0 {
+		firstReview = total / time.Duration(reviewed)
+	}
+	return people, firstReview, reviewed
+}
+
+// Render writes the section with the reviews given by each person for the
+// merged pull requests and the average time to the first review.  Nothing is
+// written if none of the pull requests were reviewed.
+func (r Reviews) Render(cfg Config, list Items, w io.Writer) {
+	redacted := cfg.Redact.Enabled && cfg.Redact.People
+	people, firstReview, reviewed := reviewActivity(cfg.ReportWindow, list, redacted)
+	if len(people) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\n%s %s\n\n", cfg.Markdown.Heading(1), r.Name)
+	fmt.Fprintf(w, "| %s | %s | %s |\n", cfg.Locale.T("reviewer"), cfg.Locale.T("reviews"), cfg.Locale.T("approvals"))
+	fmt.Fprintf(w, "| --- | ---: | ---: |\n")
+	for _, p := range people {
+		fmt.Fprintf(w, "| %s | %d | %d |\n", p.name, p.reviews, p.approvals)
+	}
+
+	if reviewed > 0 {
+		fmt.Fprintf(w, "\n%s\n", fmt.Sprintf(cfg.Locale.T("average_first_review"), formatDuration(firstReview), reviewed))
+	}
+}
+
+// formatDuration returns the duration rounded to the two largest of days,
+// hours and minutes, like "2d 3h" or "45m".
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	days := d / (24 * time.Hour)
+	hours := (d % (24 * time.Hour)) / time.Hour
+	minutes := (d % time.Hour) / time.Minute
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviews(t *testing.T) {
+	review := func(author, state, at string) Review {
+		return Review{Author: author, State: state, SubmittedAt: mustParseTime(at)}
+	}
+
+	tests := []struct {
+		description string
+		list        Items
+		expect      string
+	}{
+		{
+			description: "no reviews",
+			list:        Items{{ItemType: "PR", Author: "alice"}},
+		}, {
+			description: "reviews of merged pull requests",
+			list: Items{
+				{
+					ItemType:  "PR",
+					Author:    "alice",
+					CreatedAt: mustParseTime("2022-11-14T08:00:00Z"),
+					Reviews: []Review{
+						review("alice", "COMMENTED", "2022-11-14T09:00:00Z"),
+						review("bob", "CHANGES_REQUESTED", "2022-11-14T10:00:00Z"),
+						review("bob", "APPROVED", "2022-11-14T12:00:00Z"),
+					},
+				}, {
+					ItemType:  "PR",
+					Author:    "bob",
+					CreatedAt: mustParseTime("2022-11-15T08:00:00Z"),
+					Reviews: []Review{
+						review("carol", "APPROVED", "2022-11-15T12:30:00Z"),
+					},
+				}, {
+					ItemType:  "PR",
+					Author:    "carol",
+					Abandoned: true,
+					Reviews: []Review{
+						review("bob", "APPROVED", "2022-11-15T12:30:00Z"),
+					},
+				}, {
+					ItemType: "ISSUE",
+					Author:   "dave",
+				},
+			},
+			expect: `
+## Review Activity
+
+| Reviewer | Reviews | Approvals |
+| --- | ---: | ---: |
+| bob | 2 | 1 |
+| carol | 1 | 1 |
+
+Average time to first review: 3h 15m (2 pull requests)
+`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var buf strings.Builder
+			Reviews{Enabled: true, Name: "Review Activity"}.Render(Config{}, tc.list, &buf)
+			assert.Equal(tc.expect, buf.String())
+		})
+	}
+}
+
+func TestReviewsRedacted(t *testing.T) {
+	review := func(author, state, at string) Review {
+		return Review{Author: author, State: state, SubmittedAt: mustParseTime(at)}
+	}
+	list := Items{
+		{
+			ItemType:  "PR",
+			Author:    "alice",
+			CreatedAt: mustParseTime("2022-11-14T08:00:00Z"),
+			Reviews: []Review{
+				review("alice", "COMMENTED", "2022-11-14T09:00:00Z"),
+				review("bob", "APPROVED", "2022-11-14T12:00:00Z"),
+				review("copilot[bot]", "COMMENTED", "2022-11-14T13:00:00Z"),
+			},
+		},
+	}
+	hash := Redact{Enabled: true, Mode: "hash", People: true}
+
+	tests := []struct {
+		description string
+		redact      Redact
+		expect      string
+	}{
+		{
+			description: "hashed",
+			redact:      hash,
+			expect: "| copilot[bot] | 1 | 0 |\n" +
+				"| " + hash.replace("user", "bob") + " | 1 | 1 |\n",
+		}, {
+			description: "placeholder",
+			redact:      Redact{Enabled: true, Mode: "placeholder", Placeholder: "internal", People: true},
+			expect: "| copilot[bot] | 1 | 0 |\n" +
+				"| internal | 1 | 1 |\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			redacted := tc.redact.Items(list)
+			require.Len(redacted[0].Reviews, 2)
+			assert.NotEqual("bob", redacted[0].Reviews[0].Author)
+
+			var buf strings.Builder
+			Reviews{Enabled: true, Name: "Review Activity"}.Render(Config{Redact: tc.redact}, redacted, &buf)
+			assert.NotContains(buf.String(), "alice")
+			assert.NotContains(buf.String(), "bob")
+			assert.Contains(buf.String(), tc.expect)
+			assert.Contains(buf.String(), "Average time to first review: 4h 0m (1 pull requests)")
+			assert.Equal("bob", list[0].Reviews[1].Author)
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("0m", formatDuration(0))
+	assert.Equal("45m", formatDuration(45*time.Minute+10*time.Second))
+	assert.Equal("3h 15m", formatDuration(195*time.Minute))
+	assert.Equal("2d 3h", formatDuration(51*time.Hour+20*time.Minute))
+}
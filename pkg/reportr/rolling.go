@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// rollingMarker is the prefix of the html comment that starts each report in a
+// rolling report file.  The marker holds the start date of the report and its
+// key, like "<!-- status-reportr: 2022-11-27 FY23Q1-W09 -->".
+const rollingMarker = "<!-- status-reportr: "
+
+// rollingDate is the layout of the start date in the markers.
+const rollingDate = "2006-01-02"
+
+// rollingBlock is a single report in a rolling report file.
+type rollingBlock struct {
+	key   string
+	start time.Time
+	body  string
+}
+
+// MergeRolling adds the report of the window starting at start to the existing
+// rolling report file contents, replacing any report with the same key.  The
+// reports are ordered newest first by their start date, so keys that do not
+// sort as text (like fiscal weeks) stay in order.  Anything before the first
+// report is preserved at the top.
+func MergeRolling(existing, key string, start time.Time, report string) string {
+	preamble, blocks := splitRolling(existing)
+	blocks[key] = rollingBlock{key: key, start: start, body: report}
+
+	list := make([]rollingBlock, 0, len(blocks))
+	for _, b := range blocks {
+		list = append(list, b)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].start.Equal(list[j].start) {
+			return list[i].start.After(list[j].start)
+		}
+		return list[i].key > list[j].key
+	})
+
+	var buf strings.Builder
+	buf.WriteString(preamble)
+	for _, b := range list {
+		fmt.Fprintf(&buf, "%s%s %s -->\n", rollingMarker, b.start.Format(rollingDate), b.key)
+		buf.WriteString(strings.TrimRight(b.body, "\n"))
+		buf.WriteString("\n\n")
+	}
+
+	return buf.String()
+}
+
+// splitRolling splits the rolling report file contents into the preamble and
+// the reports by key.  The markers written before the start date was added
+// only have the key, the start date is taken from the key if it is a date.
+func splitRolling(existing string) (string, map[string]rollingBlock) {
+	blocks := make(map[string]rollingBlock)
+
+	parts := strings.Split(existing, rollingMarker)
+	preamble := parts[0]
+	for _, part := range parts[1:] {
+		marker, body, found := strings.Cut(part, " -->\n")
+		if !found {
+			continue
+		}
+
+		b := rollingBlock{key: marker, body: body}
+		if date, key, ok := strings.Cut(marker, " "); ok {
+			if start, err := time.Parse(rollingDate, date); err == nil {
+				b.key, b.start = key, start
+			}
+		}
+		if b.start.IsZero() && len(marker) >= len("2006.01.02") {
+			b.start, _ = time.Parse("2006.01.02", marker[:len("2006.01.02")])
+		}
+		blocks[b.key] = b
+	}
+
+	return preamble, blocks
+}
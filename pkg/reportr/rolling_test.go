@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeRolling(t *testing.T) {
+	assert := assert.New(t)
+
+	older := time.Date(2022, 11, 20, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2022, 11, 27, 0, 0, 0, 0, time.UTC)
+
+	got := MergeRolling("", "2022.11.20", older, "# Older\n")
+	assert.Equal("<!-- status-reportr: 2022-11-20 2022.11.20 -->\n# Older\n\n", got)
+
+	got = MergeRolling("Preamble\n\n"+got, "2022.11.27", newer, "# Newer\n")
+	assert.Equal("Preamble\n\n"+
+		"<!-- status-reportr: 2022-11-27 2022.11.27 -->\n# Newer\n\n"+
+		"<!-- status-reportr: 2022-11-20 2022.11.20 -->\n# Older\n\n", got)
+
+	got = MergeRolling(got, "2022.11.20", older, "# Replaced\n")
+	assert.Equal("Preamble\n\n"+
+		"<!-- status-reportr: 2022-11-27 2022.11.27 -->\n# Newer\n\n"+
+		"<!-- status-reportr: 2022-11-20 2022.11.20 -->\n# Replaced\n\n", got)
+}
+
+func TestMergeRollingLegacyMarkers(t *testing.T) {
+	assert := assert.New(t)
+
+	// The markers written without the start date are still ordered and
+	// replaced by their key.
+	existing := "<!-- status-reportr: 2022.11.20 -->\n# Older\n\n"
+	got := MergeRolling(existing, "2022.11.27", time.Date(2022, 11, 27, 0, 0, 0, 0, time.UTC), "# Newer\n")
+	assert.Equal("<!-- status-reportr: 2022-11-27 2022.11.27 -->\n# Newer\n\n"+
+		"<!-- status-reportr: 2022-11-20 2022.11.20 -->\n# Older\n\n", got)
+}
+
+func TestMergeRollingFiscal(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	cfg := Config{Fiscal: Fiscal{Enabled: true, StartMonth: 10}}
+	week9 := WeeklyItems{Start: time.Date(2024, 11, 26, 0, 0, 0, 0, time.UTC)}
+	week10 := WeeklyItems{Start: time.Date(2024, 12, 3, 0, 0, 0, 0, time.UTC)}
+	require.Equal("FY25Q1-W09", ReportBasename(cfg, week9))
+	require.Equal("FY25Q1-W10", ReportBasename(cfg, week10))
+
+	// Week 10 is on top even with a custom label that is not zero padded.
+	for _, label := range []string{"", "FY{{.ShortYear}}Q{{.Quarter}}-W{{.Week}}"} {
+		cfg.Fiscal.LabelTemplate = label
+		got := MergeRolling("", ReportBasename(cfg, week9), week9.Start, "# Week 9\n")
+		got = MergeRolling(got, ReportBasename(cfg, week10), week10.Start, "# Week 10\n")
+		assert.Regexp(`(?s)^<!-- status-reportr: 2024-12-03 FY25Q1-W10 -->\n# Week 10\n\n`+
+			`<!-- status-reportr: 2024-11-26 FY25Q1-W0?9 -->\n# Week 9\n\n$`, got)
+	}
+}
@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"unicode"
+)
+
+// ProjectInfo describes a github project.
+type ProjectInfo struct {
+	ID     string
+	Number int
+	Title  string
+}
+
+// Directory returns the name of the directory to place the reports for the
+// project in.
+func (p ProjectInfo) Directory() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d", p.Number)
+
+	dash := true
+	for _, r := range strings.ToLower(p.Title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash {
+				b.WriteRune('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+
+	return b.String()
+}
+
+// ProjectRollup is the reports generated for a project.
+type ProjectRollup struct {
+	Project   ProjectInfo
+	Directory string
+	Reports   []ReportRecord
+}
+
+// sharedItem is an item reported in several projects.
+type sharedItem struct {
+	item     Item
+	projects []string // The titles of the projects, in the rollup order.
+}
+
+// sharedItems returns the items that were reported in more than one project,
+// identified by the url of their content, in the order they were first seen.
+func sharedItems(projects []ProjectRollup) []sharedItem {
+	var order []string
+	seen := make(map[string]*sharedItem)
+	for _, p := range projects {
+		for _, r := range p.Reports {
+			for _, item := range r.Items {
+				if len(item.URL) == 0 {
+					continue
+				}
+				s, ok := seen[item.URL]
+				if !ok {
+					s = &sharedItem{item: item}
+					seen[item.URL] = s
+					order = append(order, item.URL)
+				}
+				if !contains(s.projects, p.Project.Title) {
+					s.projects = append(s.projects, p.Project.Title)
+				}
+			}
+		}
+	}
+
+	var rv []sharedItem
+	for _, url := range order {
+		if s := seen[url]; len(s.projects) > 1 {
+			rv = append(rv, *s)
+		}
+	}
+	return rv
+}
+
+// RenderRollup converts the reports generated for several projects into a
+// single markdown document.
+func RenderRollup(cfg Config, projects []ProjectRollup) string {
+	var buf strings.Builder
+
+	fmt.Fprintf(&buf, "# %s: %s\n", cfg.Locale.T("status_reports"), cfg.Owner)
+
+	var total int
+	for _, p := range projects {
+		var count int
+		for _, r := range p.Reports {
+			count += r.Shown()
+		}
+		total += count
+
+		fmt.Fprintf(&buf, "\n## %s (%d)\n\n", p.Project.Title, count)
+		for _, r := range p.Reports {
+			var fiscal string
+			if cfg.Fiscal.Enabled {
+				if label, err := cfg.Fiscal.Label(cfg, r.Start); err == nil {
+					fiscal = label + ": "
+				}
+			}
+			fmt.Fprintf(&buf, "- [%s%s ... %s](%s) (%d %s)\n",
+				fiscal,
+				cfg.Locale.Date(r.Start),
+				cfg.Locale.Date(r.End.AddDate(0, 0, -1)),
+				path.Join(p.Directory, r.Filename),
+				r.Shown(),
+				cfg.Locale.T("items"),
+			)
+		}
+	}
+
+	// The items on several boards are listed once, with the other projects
+	// they are tracked in, and only counted once.
+	shared := sharedItems(projects)
+	if len(shared) > 0 {
+		fmt.Fprintf(&buf, "\n## %s\n\n", cfg.Locale.T("tracked_in_several"))
+		for _, s := range shared {
+			fmt.Fprintf(&buf, "- [%s](%s) (%s; %s)\n",
+				s.item.Title(),
+				s.item.URL,
+				s.projects[0],
+				fmt.Sprintf(cfg.Locale.T("also_tracked_in"), strings.Join(s.projects[1:], ", ")),
+			)
+			total -= len(s.projects) - 1
+		}
+	}
+
+	fmt.Fprintf(&buf, "\n%d %s across %d projects.\n", total, cfg.Locale.T("items"), len(projects))
+
+	return buf.String()
+}
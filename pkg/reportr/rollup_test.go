@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectDirectory(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("12-platform-team-board", ProjectInfo{Number: 12, Title: "Platform Team: Board!"}.Directory())
+	assert.Equal("3", ProjectInfo{Number: 3}.Directory())
+}
+
+func TestRenderRollup(t *testing.T) {
+	assert := assert.New(t)
+
+	got := RenderRollup(Config{Owner: "org"}, []ProjectRollup{
+		{
+			Project:   ProjectInfo{Number: 1, Title: "One"},
+			Directory: "1-one",
+			Reports: []ReportRecord{
+				NewReportRecord("a.md", WeeklyItems{
+					Start: mustParseTime("2022-11-27T00:00:00Z"),
+					End:   mustParseTime("2022-12-04T00:00:00Z"),
+					Items: Items{itemPr23, itemPr24},
+				}, mustParseTime("2022-12-05T00:00:00Z")),
+			},
+		}, {
+			Project:   ProjectInfo{Number: 2, Title: "Two"},
+			Directory: "2-two",
+		},
+	})
+
+	assert.Equal("# Status Reports: org\n"+
+		"\n## One (2)\n\n"+
+		"- [Nov 27, 2022 ... Dec 3, 2022](1-one/a.md) (2 items)\n"+
+		"\n## Two (0)\n\n"+
+		"\n2 items across 2 projects.\n", got)
+}
+
+func TestRenderRollupHidden(t *testing.T) {
+	assert := assert.New(t)
+
+	record := NewReportRecord("a.md", WeeklyItems{
+		Start: mustParseTime("2022-11-27T00:00:00Z"),
+		End:   mustParseTime("2022-12-04T00:00:00Z"),
+		Items: Items{itemPr23, itemPr24},
+	}, mustParseTime("2022-12-05T00:00:00Z"))
+	record.Hidden = 1
+
+	got := RenderRollup(Config{Owner: "org"}, []ProjectRollup{
+		{
+			Project:   ProjectInfo{Number: 1, Title: "One"},
+			Directory: "1-one",
+			Reports:   []ReportRecord{record},
+		},
+	})
+
+	assert.Equal("# Status Reports: org\n"+
+		"\n## One (1)\n\n"+
+		"- [Nov 27, 2022 ... Dec 3, 2022](1-one/a.md) (1 items)\n"+
+		"\n1 items across 1 projects.\n", got)
+}
+
+func TestRenderRollupShared(t *testing.T) {
+	assert := assert.New(t)
+
+	start := mustParseTime("2022-11-27T00:00:00Z")
+	end := mustParseTime("2022-12-04T00:00:00Z")
+	now := mustParseTime("2022-12-05T00:00:00Z")
+	record := func(items ...Item) ReportRecord {
+		r := NewReportRecord("a.md", WeeklyItems{Start: start, End: end, Items: items}, now)
+		r.Items = items
+		return r
+	}
+
+	// The same pull request is on two boards with a different project item id.
+	other := itemPr23
+	other.ID = "other-board-id"
+
+	got := RenderRollup(Config{Owner: "org"}, []ProjectRollup{
+		{
+			Project:   ProjectInfo{Number: 1, Title: "One"},
+			Directory: "1-one",
+			Reports:   []ReportRecord{record(itemPr23, itemPr24)},
+		}, {
+			Project:   ProjectInfo{Number: 2, Title: "Two"},
+			Directory: "2-two",
+			Reports:   []ReportRecord{record(other, itemIssue88)},
+		},
+	})
+
+	assert.Contains(got, "\n## Tracked in Several Projects\n\n"+
+		"- [Update Something](https://github.com/org/repo/pull/23) (One; also tracked in Two)\n")
+	assert.NotContains(got, "pull/24) (")
+	assert.Contains(got, "\n3 items across 2 projects.\n")
+}
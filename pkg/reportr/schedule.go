@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WeeklyItems is the list of items completed in a report window.
+type WeeklyItems struct {
+	Items Items
+	Start time.Time
+	End   time.Time
+
+	// Since is when the report for this week was previously generated, or the
+	// zero time if it was not.
+	Since time.Time
+
+	// Open is the list of items that are not done.  It is only populated for
+	// the most recent week since it represents the current state of the board.
+	Open Items
+
+	// Planned is the list of items, done or not, in the current iteration.  It
+	// is only populated for the most recent week, like Open.
+	Planned Items
+
+	// Burndown is the burndown of the current iteration, if recorded.
+	Burndown *Burndown
+
+	// Updates are the newest status updates posted on the project before the
+	// end of the week.
+	Updates []StatusUpdate
+
+	// Previous is the record of the report of the window before, if any.
+	Previous *ReportRecord
+}
+
+// SplitByWeeks splits the list of items into weeks starting on the specified
+// weekday, newest first.  Only complete weeks before now are included.
+func SplitByWeeks(list Items, now time.Time, first time.Weekday) []WeeklyItems {
+	return splitWindows(list, getClosestWeekday(now, first), getPreviousWeek)
+}
+
+// SplitByWindow splits the list of items into the report windows, newest
+// first.  The windows are weeks unless they are a number of business days, in
+// which case the newest window still ends on the first weekday.
+func SplitByWindow(list Items, now time.Time, r ReportWindow) []WeeklyItems {
+	end := getClosestWeekday(now, r.FirstWeekday())
+	if r.BusinessDays < 1 {
+		return splitWindows(list, end, getPreviousWeek)
+	}
+
+	return splitWindows(list, end, func(when time.Time) time.Time {
+		for n := 0; n < r.BusinessDays; {
+			when = when.AddDate(0, 0, -1)
+			if r.IsBusinessDay(when) {
+				n++
+			}
+		}
+		return when
+	})
+}
+
+// splitWindows splits the list of items into the windows ending at end, newest
+// first, with previous returning the start of the window ending at a time.
+func splitWindows(list Items, end time.Time, previous func(time.Time) time.Time) []WeeklyItems {
+	var weeks []WeeklyItems
+
+	start := previous(end)
+
+	sort.SliceStable(list,
+		func(i, j int) bool {
+			return list[i].DoneAt.Before(list[j].DoneAt)
+		})
+
+	list = list.GetOlder(end)
+	for len(list) > 0 {
+		issues := list.GetInRange(start, end)
+		list = list.GetOlder(start)
+		weeks = append(weeks, WeeklyItems{
+			Items: issues,
+			Start: start,
+			End:   end,
+		})
+
+		end = start
+		start = previous(end)
+	}
+
+	return weeks
+}
+
+// IsBusinessDay returns if the day is not on a weekend or a holiday.
+func (r ReportWindow) IsBusinessDay(day time.Time) bool {
+	switch day.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+	date := day.Format("2006-01-02")
+	for _, holiday := range r.Holidays {
+		if strings.TrimSpace(holiday) == date {
+			return false
+		}
+	}
+	return true
+}
+
+// Duration returns the time from one time to another.  If the windows are in
+// business days, the time on weekends and holidays is not counted.
+func (r ReportWindow) Duration(from, to time.Time) time.Duration {
+	if r.BusinessDays < 1 {
+		return to.Sub(from)
+	}
+
+	from, to = from.UTC(), to.UTC()
+	var rv time.Duration
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	for ; day.Before(to); day = day.AddDate(0, 0, 1) {
+		if !r.IsBusinessDay(day) {
+			continue
+		}
+		start, end := day, day.AddDate(0, 0, 1)
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		rv += end.Sub(start)
+	}
+	return rv
+}
+
+// CheckHolidays reports any holiday that is not a YYYY-MM-DD date.
+func (r ReportWindow) CheckHolidays() error {
+	for _, holiday := range r.Holidays {
+		if _, err := time.Parse("2006-01-02", strings.TrimSpace(holiday)); err != nil {
+			return fmt.Errorf("report_window.holidays: '%s' is not a YYYY-MM-DD date", holiday)
+		}
+	}
+	return nil
+}
+
+// getClosestWeekday returns the midnight UTC of the specified weekday that is
+// on or before now.
+func getClosestWeekday(now time.Time, first time.Weekday) time.Time {
+	days := (7 + int(now.Weekday()) - int(first)) % 7
+	tmp := now.AddDate(0, 0, -1*days)
+	y := tmp.Year()
+	m := tmp.Month()
+	d := tmp.Day()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func getPreviousWeek(when time.Time) time.Time {
+	return when.AddDate(0, 0, -7)
+}
@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetClosestWeekday(t *testing.T) {
+	tests := []struct {
+		description string
+		now         string
+		first       time.Weekday
+		expect      string
+	}{
+		{
+			description: "thursday to sunday",
+			now:         "2022-12-01T09:01:53Z",
+			first:       time.Sunday,
+			expect:      "2022-11-27T00:00:00Z",
+		}, {
+			description: "thursday to monday",
+			now:         "2022-12-01T09:01:53Z",
+			first:       time.Monday,
+			expect:      "2022-11-28T00:00:00Z",
+		}, {
+			description: "sunday to monday",
+			now:         "2022-11-27T09:01:53Z",
+			first:       time.Monday,
+			expect:      "2022-11-21T00:00:00Z",
+		}, {
+			description: "monday to monday",
+			now:         "2022-11-28T09:01:53Z",
+			first:       time.Monday,
+			expect:      "2022-11-28T00:00:00Z",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			got := getClosestWeekday(mustParseTime(tc.now), tc.first)
+			assert.Equal(mustParseTime(tc.expect), got)
+		})
+	}
+}
+
+func TestSplitByWeeks(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	list := Items{markDone(itemIssue88), markDone(itemPr23)}
+	weeks := SplitByWeeks(list, mustParseTime("2022-12-06T00:00:00Z"), time.Monday)
+
+	require.NotEmpty(weeks)
+	assert.Equal(mustParseTime("2022-11-28T00:00:00Z"), weeks[0].Start)
+	assert.Equal(mustParseTime("2022-12-05T00:00:00Z"), weeks[0].End)
+	assert.Equal(Items{list[1]}, weeks[0].Items)
+	assert.Equal(Items{list[0]}, weeks[len(weeks)-1].Items)
+
+	year, num := weeks[0].Start.ISOWeek()
+	assert.Equal(2022, year)
+	assert.Equal(48, num)
+	assert.Equal("2022-W48", ReportBasename(Config{ReportWindow: ReportWindow{ISOWeeks: true}}, weeks[0]))
+	assert.Equal("2022.11.28-2022.12.04", ReportBasename(Config{}, weeks[0]))
+}
+
+// markDone returns a copy of the item with the status set to done.
+func markDone(item Item) Item {
+	fields := make(map[string]Field, len(item.Fields))
+	for k, v := range item.Fields {
+		fields[k] = v
+	}
+	fields["Status"] = Field{
+		Type: FIELD_TEXT,
+		Name: "Status",
+		Text: "Done",
+	}
+	item.Fields = fields
+	return item
+}
+
+func TestSplitByWindow(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	list := Items{markDone(itemIssue88), markDone(itemPr23)}
+	now := mustParseTime("2022-12-06T00:00:00Z")
+
+	weeks := SplitByWindow(list, now, ReportWindow{ISOWeeks: true})
+	require.NotEmpty(weeks)
+	assert.Equal(SplitByWeeks(list, now, time.Monday), weeks)
+
+	// The Thanksgiving holidays stretch the window before of 5 business days.
+	r := ReportWindow{ISOWeeks: true, BusinessDays: 5, Holidays: []string{"2022-11-24", " 2022-11-25"}}
+	weeks = SplitByWindow(list, now, r)
+	require.NotEmpty(weeks)
+	assert.Equal(mustParseTime("2022-11-28T00:00:00Z"), weeks[0].Start)
+	assert.Equal(mustParseTime("2022-12-05T00:00:00Z"), weeks[0].End)
+	require.True(len(weeks) > 1)
+	assert.Equal(mustParseTime("2022-11-17T00:00:00Z"), weeks[1].Start)
+	assert.Equal(weeks[1].End, weeks[0].Start)
+}
+
+func TestBusinessDuration(t *testing.T) {
+	r := ReportWindow{BusinessDays: 5, Holidays: []string{"2022-11-24"}}
+
+	tests := []struct {
+		description string
+		window      ReportWindow
+		from, to    string
+		expect      time.Duration
+	}{
+		{
+			description: "calendar days",
+			from:        "2022-11-18T12:00:00Z",
+			to:          "2022-11-21T12:00:00Z",
+			expect:      72 * time.Hour,
+		}, {
+			description: "over the weekend",
+			window:      r,
+			from:        "2022-11-18T12:00:00Z",
+			to:          "2022-11-21T12:00:00Z",
+			expect:      24 * time.Hour,
+		}, {
+			description: "over a holiday",
+			window:      r,
+			from:        "2022-11-23T20:00:00Z",
+			to:          "2022-11-25T02:00:00Z",
+			expect:      6 * time.Hour,
+		}, {
+			description: "on a weekend",
+			window:      r,
+			from:        "2022-11-19T10:00:00Z",
+			to:          "2022-11-19T12:00:00Z",
+		}, {
+			description: "within a day",
+			window:      r,
+			from:        "2022-11-21T10:00:00Z",
+			to:          "2022-11-21T12:30:00Z",
+			expect:      150 * time.Minute,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expect, tc.window.Duration(mustParseTime(tc.from), mustParseTime(tc.to)))
+		})
+	}
+}
+
+func TestCheckHolidays(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(ReportWindow{Holidays: []string{"2022-12-25", " 2023-01-01 "}}.CheckHolidays())
+	assert.Error(ReportWindow{Holidays: []string{"Dec 25"}}.CheckHolidays())
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+)
+
+var ErrEncryptedSecret = errors.New("encrypted secret")
+
+// ResolveSecrets replaces the secret references in the fields tagged with
+// `secret:"true"` with the values they refer to.  The references are:
+//
+//	file:<path>    - the contents of the file, like a mounted secret.
+//	cmd:<command>  - the output of the shell command, like
+//	                 sops -d --extract '["token"]' secrets.enc.yml
+//
+// Encrypted values are not decrypted here; decrypting sops or age files is
+// delegated to those tools with a cmd: reference.  A sops or age encrypted
+// value used directly is an ErrEncryptedSecret.  Any other value is used as
+// is.
+func ResolveSecrets(cfg *Config) error {
+	return resolveSecrets(reflect.ValueOf(cfg).Elem(), "")
+}
+
+func resolveSecrets(v reflect.Value, path string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := yamlKey(f)
+		if len(name) == 0 || name == "-" {
+			continue
+		}
+		key := path + name
+		field := v.Field(i)
+
+		switch field.Kind() {
+		case reflect.Struct:
+			if err := resolveSecrets(field, key+"."); err != nil {
+				return err
+			}
+		case reflect.Slice:
+			if field.Type().Elem().Kind() != reflect.Struct {
+				continue
+			}
+			for j := 0; j < field.Len(); j++ {
+				if err := resolveSecrets(field.Index(j), fmt.Sprintf("%s.%d.", key, j)); err != nil {
+					return err
+				}
+			}
+		case reflect.String:
+			if f.Tag.Get("secret") != "true" {
+				continue
+			}
+			val, err := resolveSecret(field.String())
+			if err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			field.SetString(val)
+		}
+	}
+
+	return nil
+}
+
+// resolveSecret returns the value the secret reference refers to.
+func resolveSecret(val string) (string, error) {
+	switch {
+	case strings.HasPrefix(val, "file:"):
+		buf, err := os.ReadFile(strings.TrimPrefix(val, "file:"))
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(buf), "\r\n"), nil
+
+	case strings.HasPrefix(val, "cmd:"):
+		command := strings.TrimPrefix(val, "cmd:")
+		cmd := exec.Command("sh", "-c", command)
+		cmd.Stderr = os.Stderr
+		buf, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("command '%s' failed: %w", command, err)
+		}
+		return string(bytes.TrimRight(buf, "\r\n")), nil
+
+	case strings.HasPrefix(val, "ENC["):
+		return "", fmt.Errorf("%w: the value is sops encrypted, use cmd:sops -d --extract '[\"key\"]' <file> to decrypt it",
+			ErrEncryptedSecret)
+
+	case strings.HasPrefix(val, "-----BEGIN AGE ENCRYPTED FILE-----"):
+		return "", fmt.Errorf("%w: the value is age encrypted, use cmd:age -d -i <key file> <file> to decrypt it",
+			ErrEncryptedSecret)
+	}
+
+	return val, nil
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSecrets(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(file, []byte("from-file\n"), 0600))
+
+	tests := []struct {
+		description string
+		token       string
+		expect      string
+		err         error
+		wantErr     bool
+	}{
+		{
+			description: "plain value",
+			token:       "plain",
+			expect:      "plain",
+		}, {
+			description: "file reference",
+			token:       "file:" + file,
+			expect:      "from-file",
+		}, {
+			description: "command reference",
+			token:       "cmd:echo from-cmd",
+			expect:      "from-cmd",
+		}, {
+			description: "missing file",
+			token:       "file:" + file + ".missing",
+			wantErr:     true,
+		}, {
+			description: "failed command",
+			token:       "cmd:exit 3",
+			wantErr:     true,
+		}, {
+			description: "sops encrypted value",
+			token:       "ENC[AES256_GCM,data:abc,iv:def,tag:ghi,type:str]",
+			err:         ErrEncryptedSecret,
+		}, {
+			description: "age encrypted value",
+			token:       "-----BEGIN AGE ENCRYPTED FILE-----\nYWdlLWVuY3J5cHRpb24ub3JnL3YxCg==\n-----END AGE ENCRYPTED FILE-----\n",
+			err:         ErrEncryptedSecret,
+		}, {
+			description: "decryption delegated to a command",
+			token:       "cmd:printf 'ENC[AES256_GCM]' | sed 's/.*/decrypted/'",
+			expect:      "decrypted",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// Only the fields tagged as secrets are resolved.
+			cfg := Config{Token: tc.token, Team: "cmd:echo team"}
+			err := ResolveSecrets(&cfg)
+
+			if tc.wantErr || tc.err != nil {
+				require.Error(err)
+				if tc.err != nil {
+					assert.True(errors.Is(err, tc.err))
+				}
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tc.expect, cfg.Token)
+			assert.Equal("cmd:echo team", cfg.Team)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RenderSnapshot converts the items on the board into a markdown summary of
+// each Status column with the number of items in it, followed by the items of
+// each column.  The configured columns are first, in order, then the rest by
+// name and the items without a status last.  Archived items are left out.
+func RenderSnapshot(cfg Config, list Items, now time.Time) string {
+	var board Items
+	for _, item := range list {
+		if !item.Archived {
+			board = append(board, item)
+		}
+	}
+
+	groups, values := board.GroupByField("Status")
+
+	columns := make([]string, 0, len(values)+1)
+	for _, c := range cfg.Snapshot.Columns {
+		if _, ok := groups[c]; ok && !contains(columns, c) {
+			columns = append(columns, c)
+		}
+	}
+	for _, v := range values {
+		if !contains(columns, v) {
+			columns = append(columns, v)
+		}
+	}
+	if _, ok := groups[""]; ok {
+		columns = append(columns, "")
+	}
+
+	name := func(column string) string {
+		if len(column) == 0 {
+			return cfg.Locale.T("no_status")
+		}
+		return column
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%s %s: %s\n\n", cfg.Markdown.Heading(0), cfg.Locale.T("board_snapshot"), cfg.Team)
+	fmt.Fprintf(&buf, "_%s_\n\n", cfg.Locale.Date(now))
+
+	fmt.Fprintf(&buf, "| %s | %s |\n", cfg.Locale.T("status"), cfg.Locale.T("count"))
+	fmt.Fprintf(&buf, "| --- | ---: |\n")
+	for _, column := range columns {
+		fmt.Fprintf(&buf, "| %s | %s |\n", name(column), cfg.Summarize(groups[column]))
+	}
+	fmt.Fprintf(&buf, "| **%s** | %s |\n", cfg.Locale.T("total"), cfg.Summarize(board))
+
+	if cfg.Snapshot.ListItems {
+		for _, column := range columns {
+			Section{Name: name(column)}.Render(cfg, groups[column], &buf)
+		}
+	}
+
+	return WrapMarkdown(buf.String(), cfg.Markdown.Wrap)
+}
+
+// contains returns if the list contains the string.
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderSnapshot(t *testing.T) {
+	status := func(title, status string) Item {
+		item := itemIssue88
+		item.Fields = map[string]Field{
+			"Title": {Type: FIELD_TEXT, Text: title},
+		}
+		if len(status) > 0 {
+			item.Fields["Status"] = Field{Type: FIELD_TEXT, Text: status}
+		}
+		return item
+	}
+	archived := status("Archived", "Done")
+	archived.Archived = true
+
+	list := Items{
+		status("Shipped", "Done"),
+		status("Review", "In Review"),
+		status("Started", "In Progress"),
+		status("Idea", ""),
+		status("Blocked", "Blocked"),
+		archived,
+	}
+
+	tests := []struct {
+		description string
+		snapshot    Snapshot
+		expect      string
+	}{
+		{
+			description: "counts only",
+			snapshot:    Snapshot{Columns: []string{"Todo", "In Progress", "Done"}},
+			expect: `# Board Snapshot: Team
+
+_Aug 4, 2022_
+
+| Status | Items |
+| --- | ---: |
+| In Progress | 1 |
+| Done | 1 |
+| Blocked | 1 |
+| In Review | 1 |
+| No Status | 1 |
+| **Total** | 5 |
+`,
+		}, {
+			description: "with the items",
+			snapshot:    Snapshot{ListItems: true},
+			expect: `# Board Snapshot: Team
+
+_Aug 4, 2022_
+
+| Status | Items |
+| --- | ---: |
+| Blocked | 1 |
+| Done | 1 |
+| In Progress | 1 |
+| In Review | 1 |
+| No Status | 1 |
+| **Total** | 5 |
+
+## Blocked (1)
+
+- Blocked **[[#88](https://github.com/org/repo/issues/88)]** ([org/repo](https://github.com/org/repo))
+
+## Done (1)
+
+- Shipped **[[#88](https://github.com/org/repo/issues/88)]** ([org/repo](https://github.com/org/repo))
+
+## In Progress (1)
+
+- Started **[[#88](https://github.com/org/repo/issues/88)]** ([org/repo](https://github.com/org/repo))
+
+## In Review (1)
+
+- Review **[[#88](https://github.com/org/repo/issues/88)]** ([org/repo](https://github.com/org/repo))
+
+## No Status (1)
+
+- Idea **[[#88](https://github.com/org/repo/issues/88)]** ([org/repo](https://github.com/org/repo))
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			cfg := Config{Team: "Team", Snapshot: tc.snapshot}
+			got := RenderSnapshot(cfg, list, mustParseTime("2022-08-04T12:00:00Z"))
+			assert.Equal(tc.expect, got)
+		})
+	}
+}
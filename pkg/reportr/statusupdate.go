@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// StatusUpdate is a status update posted on the project.
+type StatusUpdate struct {
+	Body       string
+	Status     string // ON_TRACK, AT_RISK, OFF_TRACK, COMPLETE or INACTIVE
+	StartDate  string
+	TargetDate string
+	Created    time.Time
+	Author     string
+}
+
+// StatusUpdatesAsOf returns up to count of the newest status updates posted
+// before the time.  The updates must be newest first.
+func StatusUpdatesAsOf(updates []StatusUpdate, when time.Time, count int) []StatusUpdate {
+	var rv []StatusUpdate
+	for _, u := range updates {
+		if len(rv) >= count {
+			break
+		}
+		if u.Created.Before(when) {
+			rv = append(rv, u)
+		}
+	}
+	return rv
+}
+
+// renderStatusUpdates renders the status updates as quotes.
+func renderStatusUpdates(cfg Config, updates []StatusUpdate, w io.Writer) {
+	for _, u := range updates {
+		status := cfg.Locale.T(strings.ToLower(u.Status))
+		if len(status) == 0 {
+			status = u.Status
+		}
+
+		by := cfg.Locale.Date(u.Created)
+		if len(u.Author) > 0 {
+			by += ", " + u.Author
+		}
+		fmt.Fprintf(w, "> **%s** (%s)\n", status, by)
+
+		if body := strings.TrimSpace(u.Body); len(body) > 0 {
+			fmt.Fprintf(w, ">\n")
+			for _, line := range strings.Split(body, "\n") {
+				fmt.Fprintf(w, "> %s\n", strings.TrimRight(line, " \r"))
+			}
+		}
+		fmt.Fprintln(w)
+	}
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gql "github.com/hasura/go-graphql-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchStatusUpdates(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body.Close()
+		assert.Contains(string(body), "statusUpdates(first: $count, orderBy: {field: CREATED_AT, direction: DESC})")
+
+		fmt.Fprintln(w, `
+{
+  "data": {
+    "organization": {
+      "projectV2": {
+        "statusUpdates": {
+          "nodes": [
+            {
+              "body": "Shipping soon.",
+              "status": "ON_TRACK",
+              "startDate": "2022-08-01",
+              "targetDate": "2022-09-01",
+              "createdAt": "2022-08-10T12:00:00Z",
+              "creator": { "login": "octocat", "__typename": "User" }
+            }
+          ]
+        }
+      }
+    }
+  }
+}`)
+	}))
+	defer ts.Close()
+
+	got, err := FetchStatusUpdates("org", 5, gql.NewClient(ts.URL, nil), 3)
+	require.NoError(err)
+	assert.Equal([]StatusUpdate{
+		{
+			Body:       "Shipping soon.",
+			Status:     "ON_TRACK",
+			StartDate:  "2022-08-01",
+			TargetDate: "2022-09-01",
+			Created:    mustParseTime("2022-08-10T12:00:00Z"),
+			Author:     "octocat",
+		},
+	}, got)
+}
+
+func TestRenderStatusUpdates(t *testing.T) {
+	updates := []StatusUpdate{
+		{Status: "AT_RISK", Body: "Waiting on\nreviews.", Created: mustParseTime("2022-08-12T00:00:00Z"), Author: "octocat"},
+		{Status: "ON_TRACK", Created: mustParseTime("2022-08-05T00:00:00Z")},
+		{Status: "ON_TRACK", Created: mustParseTime("2022-07-28T00:00:00Z")},
+	}
+
+	tests := []struct {
+		description string
+		placement   string
+		summary     bool
+		expect      string
+	}{
+		{
+			description: "header",
+			placement:   "header",
+			expect:      "## Team\n\n> **On Track** (Aug 5, 2022)\n\n",
+		}, {
+			description: "summary",
+			placement:   "summary",
+			summary:     true,
+			expect:      "## Summary\n\nBody\n\n> **On Track** (Aug 5, 2022)\n\n",
+		}, {
+			description: "summary disabled",
+			placement:   "summary",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			cfg := Config{
+				Team:          "Team",
+				StatusUpdates: StatusUpdates{Enabled: true, Count: 1, Placement: tc.placement},
+				Summary:       Summary{Enabled: tc.summary, Name: "Summary", Body: "Body"},
+				Unclassified:  Unclassified{OmitIfEmpty: true},
+			}
+			week := WeeklyItems{
+				Start:   mustParseTime("2022-07-31T00:00:00Z"),
+				End:     mustParseTime("2022-08-07T00:00:00Z"),
+				Updates: StatusUpdatesAsOf(updates, mustParseTime("2022-08-07T00:00:00Z"), 1),
+			}
+
+			got := Render(cfg, week)
+			if len(tc.expect) == 0 {
+				assert.NotContains(got, "On Track")
+				return
+			}
+			assert.Contains(got, tc.expect)
+		})
+	}
+
+	assert := assert.New(t)
+	var buf strings.Builder
+	renderStatusUpdates(Config{}, updates[:1], &buf)
+	assert.Equal("> **At Risk** (Aug 12, 2022, octocat)\n>\n> Waiting on\n> reviews.\n\n", buf.String())
+	assert.Len(StatusUpdatesAsOf(updates, mustParseTime("2022-08-13T00:00:00Z"), 5), 3)
+}
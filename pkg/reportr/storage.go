@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+var ErrMissingCredentials = errors.New("missing credentials")
+
+// DefaultHTTPTimeout is the limit on a single request of the delivery targets
+// when tuning.http_timeout is not set.
+const DefaultHTTPTimeout = 60 * time.Second
+
+// HTTPClient is the client the delivery targets use.  It has a timeout so an
+// endpoint that stalls fails the delivery instead of hanging the run.
+var HTTPClient = &http.Client{Timeout: DefaultHTTPTimeout}
+
+// objectStore uploads each report to a bucket using a PUT request per report.
+// The credentials are read from the environment when the deliverer is created.
+type objectStore struct {
+	name   string // The delivery type, for error messages.
+	bucket string
+	prefix string
+	url    func(key string) string
+	sign   func(req *http.Request, payload []byte) error
+}
+
+// newS3Deliverer creates an S3 deliverer using the AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY and optional AWS_SESSION_TOKEN environment variables.
+// If an endpoint is configured, path style urls are used so S3 compatible
+// services work.
+func newS3Deliverer(d Delivery) (Deliverer, error) {
+	region := d.Region
+	if len(region) == 0 {
+		region = os.Getenv("AWS_REGION")
+	}
+	if len(region) == 0 {
+		region = "us-east-1"
+	}
+
+	key := os.Getenv("AWS_ACCESS_KEY_ID")
+	secret := os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if len(key) == 0 || len(secret) == 0 {
+		return nil, fmt.Errorf("%w: s3 requires AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY", ErrMissingCredentials)
+	}
+	token := os.Getenv("AWS_SESSION_TOKEN")
+
+	url := func(k string) string {
+		return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", d.Bucket, region, k)
+	}
+	if len(d.Endpoint) > 0 {
+		url = func(k string) string {
+			return fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(d.Endpoint, "/"), d.Bucket, k)
+		}
+	}
+
+	return objectStore{
+		name:   "s3",
+		bucket: d.Bucket,
+		prefix: d.Prefix,
+		url:    url,
+		sign: func(req *http.Request, payload []byte) error {
+			if len(token) > 0 {
+				req.Header.Set("X-Amz-Security-Token", token)
+			}
+			signV4(req, payload, key, secret, region, "s3", time.Now())
+			return nil
+		},
+	}, nil
+}
+
+// newGCSDeliverer creates a Google Cloud Storage deliverer using the oauth
+// access token in the GOOGLE_OAUTH_ACCESS_TOKEN environment variable, like
+// the one printed by 'gcloud auth print-access-token'.
+func newGCSDeliverer(d Delivery) (Deliverer, error) {
+	token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN")
+	if len(token) == 0 {
+		return nil, fmt.Errorf("%w: gcs requires GOOGLE_OAUTH_ACCESS_TOKEN", ErrMissingCredentials)
+	}
+
+	endpoint := "https://storage.googleapis.com"
+	if len(d.Endpoint) > 0 {
+		endpoint = strings.TrimSuffix(d.Endpoint, "/")
+	}
+
+	return objectStore{
+		name:   "gcs",
+		bucket: d.Bucket,
+		prefix: d.Prefix,
+		url: func(k string) string {
+			return fmt.Sprintf("%s/%s/%s", endpoint, d.Bucket, k)
+		},
+		sign: func(req *http.Request, _ []byte) error {
+			req.Header.Set("Authorization", "Bearer "+token)
+			return nil
+		},
+	}, nil
+}
+
+func (o objectStore) Deliver(_ Config, r Report) error {
+	key := o.prefix + filepath.Base(r.Path)
+
+	req, err := http.NewRequest(http.MethodPut, o.url(escapePath(key)), bytes.NewReader(r.Data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType(r.Path))
+
+	if err = o.sign(req, r.Data); err != nil {
+		return err
+	}
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s upload of '%s' failed: %w", o.name, key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s upload of '%s' to bucket '%s' failed: %s %s",
+			o.name, key, o.bucket, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	logf("Uploaded %s to %s://%s/%s", filepath.Base(r.Path), o.name, o.bucket, key)
+	return nil
+}
+
+// contentType returns the mime type of the report file based on the extension.
+func contentType(file string) string {
+	ext := filepath.Ext(file)
+	if ext == ".md" {
+		return "text/markdown; charset=utf-8"
+	}
+	if t := mime.TypeByExtension(ext); len(t) > 0 {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+// escapePath escapes each segment of the object key using the RFC 3986
+// unreserved characters, as required for signing.
+func escapePath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, s := range segments {
+		var buf strings.Builder
+		for _, b := range []byte(s) {
+			if ('A' <= b && b <= 'Z') || ('a' <= b && b <= 'z') || ('0' <= b && b <= '9') ||
+				b == '-' || b == '_' || b == '.' || b == '~' {
+				buf.WriteByte(b)
+				continue
+			}
+			fmt.Fprintf(&buf, "%%%02X", b)
+		}
+		segments[i] = buf.String()
+	}
+	return strings.Join(segments, "/")
+}
+
+// signV4 signs the request using AWS signature version 4.  All the headers
+// already set on the request are signed along with the host.
+func signV4(req *http.Request, payload []byte, key, secret, region, service string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+
+	sum := sha256.Sum256(payload)
+	payloadHash := hex.EncodeToString(sum[:])
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(v, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var canonical strings.Builder
+	canonical.WriteString(req.Method + "\n")
+	canonical.WriteString(req.URL.EscapedPath() + "\n")
+	canonical.WriteString(req.URL.RawQuery + "\n")
+	for _, k := range names {
+		canonical.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signed := strings.Join(names, ";")
+	canonical.WriteString("\n" + signed + "\n" + payloadHash)
+
+	scope := day + "/" + region + "/" + service + "/aws4_request"
+	hash := sha256.Sum256([]byte(canonical.String()))
+	toSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	k := hmacSHA256([]byte("AWS4"+secret), day)
+	k = hmacSHA256(k, region)
+	k = hmacSHA256(k, service)
+	k = hmacSHA256(k, "aws4_request")
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		key, scope, signed, hex.EncodeToString(hmacSHA256(k, toSign))))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
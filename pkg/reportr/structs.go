@@ -0,0 +1,706 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Item represents a github issue, draft issue or pr in an easier to use form.
+type Item struct {
+	ID        string
+	Archived  bool
+	Fields    map[string]Field
+	Labels    []string
+	DoneAt    time.Time
+	ItemType  string // ISSUE, PR
+	Abandoned bool   `json:",omitempty" yaml:",omitempty"` // If the item is a pr closed without being merged.
+	Draft     bool   `json:",omitempty" yaml:",omitempty"` // If the item is a draft pr.
+	Private   bool   `json:",omitempty" yaml:",omitempty"` // If the item is in a private repository.
+	Number    int
+	URL       string
+	Body      string
+	Author    string
+	IsNew     bool           `json:"-" yaml:"-"` // If the item is new since the previous run.
+	AlsoIn    []string       `json:"-" yaml:"-"` // The other sections the item is listed in.
+	Cancelled bool           `json:"-" yaml:"-"` // If the item will not be done, but is reported.
+	Dropped   []DroppedField `json:"-" yaml:"-"` // The field values of types that are not modeled.
+	Repo      struct {
+		Name   string
+		Slug   string
+		URL    string
+		Branch string
+	}
+	Parent *Parent `json:",omitempty" yaml:",omitempty"` // The parent (epic) issue, if any.
+
+	CreatedAt time.Time `json:",omitempty" yaml:",omitempty"` // When the pull request was opened.
+	Reviews   []Review  `json:",omitempty" yaml:",omitempty"` // The reviews of the pull request.
+
+	// If the author's first pull request was merged in the report window.
+	NewContributor bool `json:"-" yaml:"-"`
+
+	// The start of the report the item was listed as done in before it was
+	// reopened.
+	ReportedDone time.Time `json:"-" yaml:"-"`
+}
+
+// Review is a submitted review of a pull request.
+type Review struct {
+	Author      string
+	State       string // APPROVED, CHANGES_REQUESTED, COMMENTED or DISMISSED
+	SubmittedAt time.Time
+}
+
+// Parent is the issue that an item is a sub-issue of or is tracked in.
+type Parent struct {
+	Number int
+	Title  string
+	URL    string
+}
+
+// IsDone returns if the item is complete & is marked "done", or is cancelled.
+func (it Item) IsDone() bool {
+	if it.Cancelled {
+		return true
+	}
+	if status, ok := it.Fields["Status"]; ok {
+		return status.Type == FIELD_TEXT && "done" == strings.ToLower(status.Text)
+	}
+	return false
+}
+
+// Done returns the time the item was completed at.
+func (it Item) Done() time.Time {
+	if it.IsDone() {
+		return it.DoneAt
+	}
+	return time.Time{}
+}
+
+// HasLabel returns if the item has this label.
+func (it Item) HasLabel(l string) bool {
+	l = strings.TrimSpace(l)
+
+	for _, label := range it.Labels {
+		if globMatch(l, NormalizeText(label)) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterLabels returns the normalized labels of the item that match any of the
+// globs provided.  If no globs are provided all the labels are returned.
+func (it Item) FilterLabels(globs ...string) []string {
+	if len(globs) == 0 {
+		return normalizeAll(it.Labels)
+	}
+
+	var rv []string
+	for _, label := range it.Labels {
+		label = NormalizeText(label)
+		for _, g := range globs {
+			if globMatch(strings.TrimSpace(g), label) {
+				rv = append(rv, label)
+				break
+			}
+		}
+	}
+	return rv
+}
+
+// HasPrefix returns if the item title prefix matches the one specified.
+func (it Item) HasPrefix(prefix string) bool {
+	return globMatch(
+		strings.TrimSpace(prefix)+"*",
+		strings.TrimSpace(it.Title()),
+	)
+}
+
+// IsBranch returns if the item is associated with the specified repo/branch.
+func (it Item) IsBranch(org, repo, branch string) bool {
+	slug := strings.TrimSpace(org) + "/" + strings.TrimSpace(repo)
+	branch = strings.TrimSpace(branch)
+
+	return len(it.Repo.Slug) > 0 &&
+		len(it.Repo.Branch) > 0 &&
+		globMatch(slug, strings.TrimSpace(it.Repo.Slug)) &&
+		globMatch(branch, strings.TrimSpace(it.Repo.Branch))
+}
+
+// HasField returns if the item has a text field with the name and a value
+// matching the one specified.  Repository fields match the repository slug.
+// For people and pull request fields, any of the users or pull request urls
+// matching the value is enough.
+func (it Item) HasField(name, value string) bool {
+	field, ok := it.Fields[strings.TrimSpace(name)]
+	if !ok {
+		return false
+	}
+
+	value = strings.TrimSpace(value)
+	switch field.Type {
+	case FIELD_TEXT, FIELD_REPOSITORY:
+		return globMatch(value, strings.TrimSpace(field.Text))
+	case FIELD_USERS:
+		for _, user := range field.Users {
+			if globMatch(value, user) {
+				return true
+			}
+		}
+	case FIELD_PULL_REQUESTS:
+		for _, url := range field.PullRequests {
+			if globMatch(value, url) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasAuthor returns if the item author matches the one specified.
+func (it Item) HasAuthor(author string) bool {
+	return len(it.Author) > 0 &&
+		globMatch(strings.TrimSpace(author), strings.TrimSpace(it.Author))
+}
+
+// IsBot returns if the item was authored by a bot.
+func (it Item) IsBot() bool {
+	return strings.HasSuffix(it.Author, "[bot]")
+}
+
+// Title returns the normalized title of the item, or the empty string.
+func (it Item) Title() string {
+	if status, ok := it.Fields["Title"]; ok {
+		if status.Type == FIELD_TEXT {
+			return NormalizeText(status.Text)
+		}
+	}
+	return ""
+}
+
+// IsLate returns if the item was completed after the day in the date field with
+// the name.  Items without the date field are not late.
+func (it Item) IsLate(name string) bool {
+	field, ok := it.Fields[strings.TrimSpace(name)]
+	if !ok || field.Type != FIELD_DATE || it.DoneAt.IsZero() {
+		return false
+	}
+	return !it.DoneAt.Before(field.Date.AddDate(0, 0, 1))
+}
+
+// FieldNumber returns the value of the numeric field with the name and if it
+// was present.
+func (it Item) FieldNumber(name string) (float64, bool) {
+	if field, ok := it.Fields[strings.TrimSpace(name)]; ok {
+		if field.Type == FIELD_NUMBER {
+			return field.Number, true
+		}
+	}
+	return 0, false
+}
+
+// Excerpt returns a short single line excerpt of the item body.  If
+// firstSentence is true, only the first sentence is used.  If length is greater
+// than zero the excerpt is limited to that many characters and an ellipsis is
+// appended if truncated.
+func (it Item) Excerpt(firstSentence bool, length int) string {
+	text := strings.Join(strings.Fields(it.Body), " ")
+
+	if firstSentence {
+		for i, r := range text {
+			if r == '.' || r == '!' || r == '?' {
+				if i+1 == len(text) || text[i+1] == ' ' {
+					text = text[:i+1]
+					break
+				}
+			}
+		}
+	}
+
+	if length > 0 {
+		runes := []rune(text)
+		if len(runes) > length {
+			text = strings.TrimSpace(string(runes[:length])) + "..."
+		}
+	}
+
+	return text
+}
+
+const (
+	FIELD_EMPTY int = iota
+	FIELD_DATE
+	FIELD_TEXT
+	FIELD_NUMBER
+	FIELD_ITERATION
+	FIELD_USERS
+	FIELD_REPOSITORY
+	FIELD_PULL_REQUESTS
+)
+
+// Field provides a single record that can represent any of the data types that
+// can be present.
+type Field struct {
+	Type int
+	Name string
+
+	// One of these is valid.
+	Date   time.Time
+	Number float64
+	Text   string
+	Users  []string // The logins of the people.
+
+	// repository (the slug is in Text) and linked pull requests
+	URL          string
+	PullRequests []string // The urls of the pull requests.
+
+	// iteration
+	Duration    time.Duration
+	IterationId string
+	StartDate   time.Time
+	Title       string
+}
+
+// Value returns the value of the field as text.
+func (f Field) Value() string {
+	switch f.Type {
+	case FIELD_DATE:
+		return f.Date.Format("2006-01-02")
+	case FIELD_TEXT, FIELD_REPOSITORY:
+		return f.Text
+	case FIELD_NUMBER:
+		return strconv.FormatFloat(f.Number, 'f', -1, 64)
+	case FIELD_ITERATION:
+		return f.Title
+	case FIELD_USERS:
+		return strings.Join(f.Users, ", ")
+	case FIELD_PULL_REQUESTS:
+		return strings.Join(f.PullRequests, ", ")
+	}
+	return ""
+}
+
+// Items provides a handy way to deal with an array of items.
+type Items []Item
+
+// GetDone returns the subset list of items that are done.
+func (list Items) GetDone() Items {
+	var done Items
+	for _, item := range list {
+		if item.IsDone() {
+			done = append(done, item)
+		}
+	}
+
+	sort.SliceStable(done, func(i, j int) bool {
+		return done[i].Done().Before(done[j].Done())
+	})
+
+	return done
+}
+
+// GetNotDone returns the subset list of items that are not done.
+func (list Items) GetNotDone() Items {
+	var open Items
+	for _, item := range list {
+		if !item.IsDone() {
+			open = append(open, item)
+		}
+	}
+
+	return open
+}
+
+// GetOlder returns the subset list of items that are older or equal to the time.
+func (list Items) GetOlder(when time.Time) Items {
+	var done Items
+	for _, item := range list {
+		if at := item.Done(); !at.Equal(time.Time{}) {
+			if at.Before(when) || at.Equal(when) {
+				done = append(done, item)
+			}
+		}
+	}
+	return done
+}
+
+// GetSince returns the subset list of items that were closed or merged at or
+// after the time.
+func (list Items) GetSince(when time.Time) Items {
+	var rv Items
+	for _, item := range list {
+		if !item.DoneAt.IsZero() && !item.DoneAt.Before(when) {
+			rv = append(rv, item)
+		}
+	}
+	return rv
+}
+
+// GetInRange returns the subset list of items that are inside the time window.
+func (list Items) GetInRange(start, end time.Time) Items {
+	if start.After(end) {
+		tmp := start
+		start = end
+		end = tmp
+	}
+
+	var done Items
+	for _, item := range list {
+		at := item.Done()
+		switch {
+		case at.Before(end) && at.After(start):
+			done = append(done, item)
+		case at.Equal(start):
+			done = append(done, item)
+		}
+	}
+	return done
+}
+
+// CaseInsensitive makes all the matching ignore the case.  It is set from the
+// configuration.
+var CaseInsensitive bool
+
+// matchCase returns the item to match the globs against.  If the case is
+// ignored, it is a copy with the values matched in lower case.
+func (it Item) matchCase(ignoreCase bool) Item {
+	if !ignoreCase && !CaseInsensitive {
+		return it
+	}
+
+	rv := it
+	rv.Labels = make([]string, 0, len(it.Labels))
+	for _, label := range it.Labels {
+		rv.Labels = append(rv.Labels, strings.ToLower(label))
+	}
+	rv.Author = strings.ToLower(it.Author)
+	rv.Repo.Slug = strings.ToLower(it.Repo.Slug)
+	rv.Repo.Branch = strings.ToLower(it.Repo.Branch)
+
+	rv.Fields = make(map[string]Field, len(it.Fields))
+	for name, field := range it.Fields {
+		field.Text = strings.ToLower(field.Text)
+		field.Users = lowerAll(field.Users)
+		field.PullRequests = lowerAll(field.PullRequests)
+		rv.Fields[name] = field
+	}
+	return rv
+}
+
+// matchCase returns the glob to match with, in lower case if the case is
+// ignored.
+func matchCase(glob string, ignoreCase bool) string {
+	if !ignoreCase && !CaseInsensitive {
+		return glob
+	}
+	return strings.ToLower(glob)
+}
+
+// normalizeAll returns a normalized copy of the list.
+func normalizeAll(list []string) []string {
+	if list == nil {
+		return nil
+	}
+	rv := make([]string, 0, len(list))
+	for _, s := range list {
+		rv = append(rv, NormalizeText(s))
+	}
+	return rv
+}
+
+// lowerAll returns a copy of the list in lower case.
+func lowerAll(list []string) []string {
+	if list == nil {
+		return nil
+	}
+	rv := make([]string, 0, len(list))
+	for _, s := range list {
+		rv = append(rv, strings.ToLower(s))
+	}
+	return rv
+}
+
+// ExtractByLabels returns the subset list of items have a matching label, and
+// a separate list of left over items.  The case is ignored if ignoreCase is
+// true.
+func (list Items) ExtractByLabels(ignoreCase bool, labels ...string) (matching, remaining Items) {
+	for _, item := range list {
+		var match bool
+		candidate := item.matchCase(ignoreCase)
+		for _, label := range labels {
+			if candidate.HasLabel(matchCase(label, ignoreCase)) {
+				match = true
+				break
+			}
+		}
+
+		if match {
+			matching = append(matching, item)
+		} else {
+			remaining = append(remaining, item)
+		}
+	}
+
+	return matching, remaining
+}
+
+// ExtractByPrefixes returns the subset list of items have a matching prefix, and
+// a separate list of left over items.  The case is ignored if ignoreCase is
+// true.
+func (list Items) ExtractByPrefixes(ignoreCase bool, prefixes ...string) (matching, remaining Items) {
+	for _, item := range list {
+		var match bool
+		candidate := item.matchCase(ignoreCase)
+		for _, prefix := range prefixes {
+			if candidate.HasPrefix(matchCase(prefix, ignoreCase)) {
+				match = true
+				break
+			}
+		}
+
+		if match {
+			matching = append(matching, item)
+		} else {
+			remaining = append(remaining, item)
+		}
+	}
+
+	return matching, remaining
+}
+
+// ExtractByBranch returns the subset list of items have a matching branch, and
+// a separate list of left over items.  The case is ignored if ignoreCase is
+// true.
+func (list Items) ExtractByBranch(ignoreCase bool, org, repo, branch string) (matching, remaining Items) {
+	org = matchCase(org, ignoreCase)
+	repo = matchCase(repo, ignoreCase)
+	branch = matchCase(branch, ignoreCase)
+	for _, item := range list {
+		if item.matchCase(ignoreCase).IsBranch(org, repo, branch) {
+			matching = append(matching, item)
+		} else {
+			remaining = append(remaining, item)
+		}
+	}
+
+	return matching, remaining
+}
+
+// ExtractByAuthors returns the subset list of items have a matching author, and
+// a separate list of left over items.  The case is ignored if ignoreCase is
+// true.
+func (list Items) ExtractByAuthors(ignoreCase bool, authors ...string) (matching, remaining Items) {
+	for _, item := range list {
+		var match bool
+		candidate := item.matchCase(ignoreCase)
+		for _, author := range authors {
+			if candidate.HasAuthor(matchCase(author, ignoreCase)) {
+				match = true
+				break
+			}
+		}
+
+		if match {
+			matching = append(matching, item)
+		} else {
+			remaining = append(remaining, item)
+		}
+	}
+
+	return matching, remaining
+}
+
+// ExtractByField returns the subset list of items have a matching field value,
+// and a separate list of left over items.  The case of the value is ignored if
+// ignoreCase is true.
+func (list Items) ExtractByField(ignoreCase bool, name, value string) (matching, remaining Items) {
+	value = matchCase(value, ignoreCase)
+	for _, item := range list {
+		if item.matchCase(ignoreCase).HasField(name, value) {
+			matching = append(matching, item)
+		} else {
+			remaining = append(remaining, item)
+		}
+	}
+
+	return matching, remaining
+}
+
+// SumField returns the sum of the numeric field with the name across the items.
+// Items without the field are ignored.
+func (list Items) SumField(name string) float64 {
+	var sum float64
+	for _, item := range list {
+		if n, ok := item.FieldNumber(name); ok {
+			sum += n
+		}
+	}
+	return sum
+}
+
+// GetUniqLabels returns a map of labels and the number of times they were
+// encountered in the provided list.
+func (list Items) GetUniqLabels() map[string]int {
+	rv := make(map[string]int)
+
+	for _, item := range list {
+		for _, label := range item.Labels {
+			rv[NormalizeText(label)]++
+		}
+	}
+
+	return rv
+}
+
+// GetUniqRepos returns a map of repository slugs and the number of times they
+// were encountered in the provided list.  Items without a repository are
+// ignored.
+func (list Items) GetUniqRepos() map[string]int {
+	rv := make(map[string]int)
+
+	for _, item := range list {
+		if len(item.Repo.Slug) > 0 {
+			rv[item.Repo.Slug]++
+		}
+	}
+
+	return rv
+}
+
+// GetByAuthor returns a map of authors and the items they authored.  Items
+// without an author are ignored.
+func (list Items) GetByAuthor() map[string]Items {
+	rv := make(map[string]Items)
+
+	for _, item := range list {
+		if len(item.Author) > 0 {
+			rv[item.Author] = append(rv[item.Author], item)
+		}
+	}
+
+	return rv
+}
+
+// GetRepoURLs returns a map of repository slugs to repository URLs.
+func (list Items) GetRepoURLs() map[string]string {
+	rv := make(map[string]string)
+
+	for _, item := range list {
+		if len(item.Repo.Slug) > 0 {
+			rv[item.Repo.Slug] = item.Repo.URL
+		}
+	}
+
+	return rv
+}
+
+// MarkNew returns a copy of the list with the items that are not in the list of
+// known ids marked as new.
+func (list Items) MarkNew(known []string) Items {
+	ids := make(map[string]bool, len(known))
+	for _, id := range known {
+		ids[id] = true
+	}
+
+	rv := make(Items, 0, len(list))
+	for _, item := range list {
+		item.IsNew = !ids[item.ID]
+		rv = append(rv, item)
+	}
+
+	return rv
+}
+
+// ExtractCancelled returns the subset list of items that are cancelled, and a
+// separate list of left over items.
+func (list Items) ExtractCancelled() (matching, remaining Items) {
+	for _, item := range list {
+		if item.Cancelled {
+			matching = append(matching, item)
+		} else {
+			remaining = append(remaining, item)
+		}
+	}
+
+	return matching, remaining
+}
+
+// ExtractArchived returns the subset list of items that are archived, and a
+// separate list of left over items.
+func (list Items) ExtractArchived() (matching, remaining Items) {
+	for _, item := range list {
+		if item.Archived {
+			matching = append(matching, item)
+		} else {
+			remaining = append(remaining, item)
+		}
+	}
+
+	return matching, remaining
+}
+
+// ExtractAbandoned returns the subset list of items that are pull requests
+// closed without being merged, and a separate list of left over items.
+func (list Items) ExtractAbandoned() (matching, remaining Items) {
+	for _, item := range list {
+		if item.Abandoned {
+			matching = append(matching, item)
+		} else {
+			remaining = append(remaining, item)
+		}
+	}
+
+	return matching, remaining
+}
+
+// ExtractDrafts returns the subset list of items that are draft pull requests,
+// and a separate list of left over items.
+func (list Items) ExtractDrafts() (matching, remaining Items) {
+	for _, item := range list {
+		if item.Draft {
+			matching = append(matching, item)
+		} else {
+			remaining = append(remaining, item)
+		}
+	}
+
+	return matching, remaining
+}
+
+// ExtractNew returns the subset list of items that are new, and a separate list
+// of left over items.
+func (list Items) ExtractNew() (matching, remaining Items) {
+	for _, item := range list {
+		if item.IsNew {
+			matching = append(matching, item)
+		} else {
+			remaining = append(remaining, item)
+		}
+	}
+
+	return matching, remaining
+}
+
+// GroupByField groups the items by the value of the named field.  The items
+// without a value are grouped under the empty string.  The sorted list of
+// values (without the empty string) is also returned.
+func (list Items) GroupByField(name string) (map[string]Items, []string) {
+	name = strings.TrimSpace(name)
+	groups := make(map[string]Items)
+	var values []string
+	for _, item := range list {
+		value := strings.TrimSpace(item.Fields[name].Value())
+		if _, ok := groups[value]; !ok && len(value) > 0 {
+			values = append(values, value)
+		}
+		groups[value] = append(groups[value], item)
+	}
+	sort.Strings(values)
+
+	return groups, values
+}
@@ -0,0 +1,228 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		description   string
+		body          string
+		firstSentence bool
+		length        int
+		expect        string
+	}{
+		{
+			description: "empty body",
+		}, {
+			description: "whole body, whitespace collapsed",
+			body:        "First line.\n\nSecond   line.",
+			expect:      "First line. Second line.",
+		}, {
+			description:   "first sentence",
+			body:          "Version 1.2 is out! More details below.",
+			firstSentence: true,
+			expect:        "Version 1.2 is out!",
+		}, {
+			description: "truncated",
+			body:        "A fairly long body of text.",
+			length:      8,
+			expect:      "A fairly...",
+		}, {
+			description:   "first sentence shorter than length",
+			body:          "Short. Longer text follows here.",
+			firstSentence: true,
+			length:        10,
+			expect:        "Short.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			item := Item{Body: tc.body}
+			assert.Equal(tc.expect, item.Excerpt(tc.firstSentence, tc.length))
+		})
+	}
+}
+
+func TestFilterLabels(t *testing.T) {
+	tests := []struct {
+		description string
+		labels      []string
+		globs       []string
+		expect      []string
+	}{
+		{
+			description: "no labels",
+			globs:       []string{"*"},
+		}, {
+			description: "no globs includes all",
+			labels:      []string{"bug", "area/ui"},
+			expect:      []string{"bug", "area/ui"},
+		}, {
+			description: "filtered by glob",
+			labels:      []string{"bug", "area/ui", "area/api", "priority"},
+			globs:       []string{"area/*", "priority"},
+			expect:      []string{"area/ui", "area/api", "priority"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			item := Item{Labels: tc.labels}
+			assert.Equal(tc.expect, item.FilterLabels(tc.globs...))
+		})
+	}
+}
+
+func TestHasField(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		expect bool
+	}{
+		{name: "Status", value: "Todo", expect: true},
+		{name: "Status", value: "T*", expect: true},
+		{name: "Status", value: "Done"},
+		{name: "Reviewer", value: "hubot", expect: true},
+		{name: "Reviewer", value: "octo*", expect: true},
+		{name: "Reviewer", value: "someone"},
+		{name: "Service", value: "org/*", expect: true},
+		{name: "Service", value: "other/*"},
+		{name: "Fix", value: "*/org/service/pull/*", expect: true},
+		{name: "Fix", value: "*/pull/6"},
+		{name: "Priority", value: "*"},
+		{name: "Missing", value: "*"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name+"="+tc.value, func(t *testing.T) {
+			assert.Equal(t, tc.expect, itemIssue89.HasField(tc.name, tc.value))
+		})
+	}
+}
+
+func TestFieldValue(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("Todo", itemIssue89.Fields["Status"].Value())
+	assert.Equal("123.456", itemIssue89.Fields["Priority"].Value())
+	assert.Equal("2022-08-05", itemIssue89.Fields["Goal"].Value())
+	assert.Equal("title", itemIssue89.Fields["Iteration"].Value())
+	assert.Equal("octocat, hubot", itemIssue89.Fields["Reviewer"].Value())
+	assert.Equal("org/service", itemIssue89.Fields["Service"].Value())
+	assert.Equal("", itemIssue89.Fields["Missing"].Value())
+}
+
+func TestGetSince(t *testing.T) {
+	assert := assert.New(t)
+
+	list := Items{itemIssue88, itemIssue89, itemPr23, {}}
+	assert.Equal(Items{itemPr23}, list.GetSince(mustParseTime("2022-12-01T00:00:00Z")))
+	assert.Len(list.GetSince(time.Time{}), 3)
+}
+
+func TestSumField(t *testing.T) {
+	assert := assert.New(t)
+
+	list := Items{itemIssue88, itemIssue89, itemIssue89}
+	assert.Equal(123.456*2, list.SumField("Priority"))
+	assert.Equal(0.0, list.SumField("Title"))
+	assert.Equal(0.0, list.SumField("Missing"))
+}
+
+func TestGetUniqRepos(t *testing.T) {
+	assert := assert.New(t)
+
+	list := Items{itemIssue88, itemPr23, {}}
+	assert.Equal(map[string]int{"org/repo": 2}, list.GetUniqRepos())
+}
+
+func TestGetByAuthor(t *testing.T) {
+	assert := assert.New(t)
+
+	got := Items{itemIssue88, itemPr23, itemPr24, {}}.GetByAuthor()
+	assert.Equal(map[string]Items{
+		"octocat":         {itemIssue88},
+		"dependabot[bot]": {itemPr23, itemPr24},
+	}, got)
+	assert.True(got["dependabot[bot]"][0].IsBot())
+	assert.False(got["octocat"][0].IsBot())
+}
+
+func TestMarkNew(t *testing.T) {
+	assert := assert.New(t)
+
+	list := Items{itemIssue88, itemPr23, itemPr24}.MarkNew([]string{"id123"})
+	assert.True(list[0].IsNew)
+	assert.False(list[1].IsNew)
+	assert.True(list[2].IsNew)
+
+	mine, left := list.ExtractNew()
+	assert.Len(mine, 2)
+	assert.Equal(Items{list[1]}, left)
+}
+
+func TestIsLate(t *testing.T) {
+	goal := func(date string) Item {
+		return Item{
+			DoneAt: mustParseTime("2022-08-04T22:16:25Z"),
+			Fields: map[string]Field{
+				"Goal": {Type: FIELD_DATE, Date: mustParseTime(date)},
+			},
+		}
+	}
+
+	tests := []struct {
+		description string
+		item        Item
+		expect      bool
+	}{
+		{
+			description: "before the goal",
+			item:        goal("2022-08-05T00:00:00Z"),
+		}, {
+			description: "on the goal day",
+			item:        goal("2022-08-04T00:00:00Z"),
+		}, {
+			description: "after the goal",
+			item:        goal("2022-08-03T00:00:00Z"),
+			expect:      true,
+		}, {
+			description: "no goal",
+			item:        itemIssue88,
+		}, {
+			description: "not done",
+			item:        Item{Fields: goal("2022-08-03T00:00:00Z").Fields},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(tc.expect, tc.item.IsLate("Goal"))
+		})
+	}
+
+	assert := assert.New(t)
+	list := Items{goal("2022-08-03T00:00:00Z"), goal("2022-08-05T00:00:00Z")}
+	assert.Equal(1, Overdue{Enabled: true, Field: "Goal"}.Count(list))
+	assert.Equal(0, Overdue{Field: "Goal"}.Count(list))
+
+	var buf strings.Builder
+	cfg := Config{Overdue: Overdue{Enabled: true, Field: "Goal", Marker: "!!"}}
+	Section{Name: "Late"}.Render(cfg, list, &buf)
+	assert.Contains(buf.String(), "Late (2, 1 overdue)")
+	assert.Equal(1, strings.Count(buf.String(), "!!"))
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	gql "github.com/hasura/go-graphql-client"
+)
+
+// OnGraphQLErrors is called with the number of errors github returned to a
+// query or mutation of the items, including the ones the run recovers from by
+// retrying, shrinking the query or skipping the item.
+var OnGraphQLErrors func(n int)
+
+// observeGraphQL reports the errors github returned in err to OnGraphQLErrors
+// and returns err.  The errors of the client itself, like a failed connection,
+// are not github errors and are not counted.
+func observeGraphQL(err error) error {
+	var errs gql.Errors
+	if OnGraphQLErrors == nil || !errors.As(err, &errs) {
+		return err
+	}
+
+	var n int
+	for _, e := range errs {
+		switch e.Extensions["code"] {
+		case gql.ErrRequestError, gql.ErrJsonEncode, gql.ErrJsonDecode, gql.ErrGraphQLEncode, gql.ErrGraphQLDecode:
+			continue
+		}
+		n++
+	}
+	if n > 0 {
+		OnGraphQLErrors(n)
+	}
+	return err
+}
+
+// Telemetry counts the work done by the runs so a long running process can
+// expose it to prometheus, and operations can alert when the weekly reports
+// stop being generated.  The zero value is ready to use.
+type Telemetry struct {
+	lock sync.Mutex
+
+	fetches       int
+	fetchDuration time.Duration
+	items         int
+	graphqlErrors int
+	archived      int
+	runs          int
+	failures      int
+	lastSuccess   time.Time
+}
+
+// ObserveFetch records a fetch of the project items that took d.
+func (t *Telemetry) ObserveFetch(d time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.fetches++
+	t.fetchDuration += d
+}
+
+// AddItems records the number of items reported.
+func (t *Telemetry) AddItems(n int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.items += n
+}
+
+// AddArchived records the number of items archived.
+func (t *Telemetry) AddArchived(n int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.archived += n
+}
+
+// AddGraphQLErrors records the number of errors github returned.
+func (t *Telemetry) AddGraphQLErrors(n int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.graphqlErrors += n
+}
+
+// ObserveRun records the outcome of a run finished at the time.
+func (t *Telemetry) ObserveRun(when time.Time, err error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.runs++
+	if err == nil {
+		t.lastSuccess = when
+		return
+	}
+	t.failures++
+}
+
+// WriteTo writes the metrics in the prometheus text exposition format.
+func (t *Telemetry) WriteTo(w io.Writer) (int64, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var last float64
+	if !t.lastSuccess.IsZero() {
+		last = float64(t.lastSuccess.UnixNano()) / float64(time.Second)
+	}
+
+	metrics := []struct {
+		name  string
+		kind  string
+		help  string
+		value float64
+	}{
+		{"fetch_duration_seconds_total", "counter", "The time spent fetching the project items.", t.fetchDuration.Seconds()},
+		{"fetches_total", "counter", "The number of times the project items were fetched.", float64(t.fetches)},
+		{"items_processed_total", "counter", "The number of items reported.", float64(t.items)},
+		{"graphql_errors_total", "counter", "The number of errors github returned to the queries and mutations of the items.", float64(t.graphqlErrors)},
+		{"archived_items_total", "counter", "The number of items archived.", float64(t.archived)},
+		{"runs_total", "counter", "The number of runs.", float64(t.runs)},
+		{"run_failures_total", "counter", "The number of runs that failed.", float64(t.failures)},
+		{"last_success_timestamp_seconds", "gauge", "The unix time of the last successful run, 0 if there was none.", last},
+	}
+
+	var total int64
+	for _, m := range metrics {
+		n, err := fmt.Fprintf(w, "# HELP status_reportr_%[1]s %[2]s\n# TYPE status_reportr_%[1]s %[3]s\nstatus_reportr_%[1]s %[4]s\n",
+			m.name, m.help, m.kind, strconv.FormatFloat(m.value, 'f', -1, 64))
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// ServeHTTP serves the metrics to prometheus.
+func (t *Telemetry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = t.WriteTo(w)
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gql "github.com/hasura/go-graphql-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTelemetry(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var tel Telemetry
+	tel.ObserveFetch(1500 * time.Millisecond)
+	tel.ObserveFetch(time.Second)
+	tel.AddItems(7)
+	tel.AddArchived(5)
+	tel.AddGraphQLErrors(3)
+	tel.ObserveRun(time.Unix(1668000000, 0), nil)
+	tel.ObserveRun(time.Unix(1668100000, 0), APIErrors{{Type: "FORBIDDEN"}})
+	tel.ObserveRun(time.Unix(1668200000, 0), errors.New("disk full"))
+
+	server := httptest.NewServer(&tel)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(err)
+
+	assert.Contains(resp.Header.Get("Content-Type"), "text/plain")
+	for _, line := range []string{
+		"# TYPE status_reportr_fetch_duration_seconds_total counter\n",
+		"status_reportr_fetch_duration_seconds_total 2.5\n",
+		"status_reportr_fetches_total 2\n",
+		"status_reportr_items_processed_total 7\n",
+		"status_reportr_graphql_errors_total 3\n",
+		"status_reportr_archived_items_total 5\n",
+		"status_reportr_runs_total 3\n",
+		"status_reportr_run_failures_total 2\n",
+		"# TYPE status_reportr_last_success_timestamp_seconds gauge\n",
+		"status_reportr_last_success_timestamp_seconds 1668000000\n",
+	} {
+		assert.Contains(string(body), line)
+	}
+}
+
+func TestObserveGraphQL(t *testing.T) {
+	assert := assert.New(t)
+
+	var count int
+	OnGraphQLErrors = func(n int) { count += n }
+	defer func() { OnGraphQLErrors = nil }()
+
+	// Errors github returned are counted even when the run recovers.
+	archiveBackoff = 0
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		r.Body.Close()
+		calls++
+		if calls == 1 {
+			fmt.Fprintln(w, `{"data": null, "errors": [{"message": "one"}, {"message": "two"}]}`)
+			return
+		}
+		fmt.Fprintln(w, `{"data": {"i0": {"clientMutationId": null}}}`)
+	}))
+	defer ts.Close()
+
+	assert.Empty(Archive("project", gql.NewClient(ts.URL, nil), []string{"a"}, 20, 1))
+	assert.Equal(2, count)
+
+	// The errors of the client are not from github.
+	ts.Close()
+	assert.Error(ArchiveItem("project", "a", gql.NewClient(ts.URL, nil)))
+	assert.Equal(2, count)
+
+	assert.Nil(observeGraphQL(nil))
+	assert.Equal(2, count)
+}
@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// DefaultHeaderTemplate is the report header used when header_template is not
+// set.
+const DefaultHeaderTemplate = `{{heading 0}} {{t "status_report"}}: {{date .Start}} ... {{date .End}}
+
+{{heading 1}} {{.Team}}{{if .ProjectTitle}}: {{.ProjectTitle}}{{end}}{{if .Points}} ({{.Points}}){{end}}
+{{- if .Description}}
+
+{{.Description}}{{end}}
+{{- if .Readme}}
+
+{{.Readme}}{{end}}`
+
+// TemplateData is the data available to the header and footer templates.
+type TemplateData struct {
+	Start     time.Time // The first day of the report.
+	End       time.Time // The last day of the report.
+	Team      string
+	Points    string // The points summary, empty unless points are enabled.
+	Generated time.Time
+	Version   string // The version of the tool.
+	Project   int    // The project number.
+	Items     int    // The number of items reported.
+	Open      int    // The number of open items.
+	Fiscal    string // The fiscal label of the report, empty unless enabled.
+
+	// The project details, empty unless enabled.
+	ProjectTitle string
+	Description  string
+	Readme       string
+}
+
+// newTemplateData returns the template data for the week.
+func newTemplateData(cfg Config, week WeeklyItems) TemplateData {
+	data := TemplateData{
+		Start:     week.Start,
+		End:       week.End.AddDate(0, 0, -1),
+		Team:      cfg.Team,
+		Generated: cfg.Generated,
+		Version:   cfg.Version,
+		Project:   cfg.Project,
+		Items:     len(week.Items),
+		Open:      len(week.Open),
+	}
+	if data.Generated.IsZero() {
+		data.Generated = time.Now()
+	}
+	if cfg.Points.Enabled {
+		data.Points = cfg.Summarize(week.Items)
+	}
+	if cfg.Fiscal.Enabled {
+		data.Fiscal, _ = cfg.Fiscal.Label(cfg, week.Start)
+	}
+	if cfg.ProjectDetails.Title {
+		data.ProjectTitle = strings.TrimSpace(cfg.Details.Title)
+	}
+	if cfg.ProjectDetails.Description {
+		data.Description = strings.TrimSpace(cfg.Details.ShortDescription)
+	}
+	if cfg.ProjectDetails.Readme {
+		data.Readme = strings.TrimSpace(cfg.Details.Readme)
+	}
+	return data
+}
+
+// parseTemplate parses the named template with the functions for the config.
+func parseTemplate(cfg Config, name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(template.FuncMap{
+		"t":       cfg.Locale.T,
+		"date":    cfg.Locale.Date,
+		"heading": cfg.Markdown.Heading,
+	}).Parse(text)
+}
+
+// CheckTemplates reports any header, footer or fiscal label template that can
+// not be parsed or used.
+func (c Config) CheckTemplates() error {
+	for name, text := range map[string]string{
+		"header_template": c.HeaderTemplate,
+		"footer_template": c.FooterTemplate,
+	} {
+		if _, err := parseTemplate(c, name, text); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	if c.Fiscal.Enabled {
+		if _, err := c.Fiscal.Label(c, time.Time{}); err != nil {
+			return fmt.Errorf("fiscal.label: %w", err)
+		}
+	}
+	return nil
+}
+
+// executeTemplate returns the template rendered for the week with the trailing
+// whitespace removed.
+func executeTemplate(cfg Config, name, text string, week WeeklyItems) (string, error) {
+	tmpl, err := parseTemplate(cfg, name, text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf strings.Builder
+	if err = tmpl.Execute(&buf, newTemplateData(cfg, week)); err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(buf.String(), " \t\n"), nil
+}
+
+// header returns the report header for the week.  The default header is used
+// if the configured one fails to render.
+func header(cfg Config, week WeeklyItems) string {
+	text := cfg.HeaderTemplate
+	if len(strings.TrimSpace(text)) == 0 {
+		text = DefaultHeaderTemplate
+	}
+
+	rv, err := executeTemplate(cfg, "header_template", text, week)
+	if err != nil {
+		logf("header_template: %v, using the default header", err)
+		rv, _ = executeTemplate(cfg, "header_template", DefaultHeaderTemplate, week)
+	}
+	return rv
+}
+
+// footer returns the report footer for the week followed by the metadata
+// footer if enabled, or an empty string if there is neither.
+func footer(cfg Config, week WeeklyItems) string {
+	var parts []string
+
+	if len(strings.TrimSpace(cfg.FooterTemplate)) > 0 {
+		rv, err := executeTemplate(cfg, "footer_template", cfg.FooterTemplate, week)
+		if err != nil {
+			logf("footer_template: %v, the footer is left out", err)
+		} else if len(rv) > 0 {
+			parts = append(parts, rv)
+		}
+	}
+
+	if cfg.Metadata.Enabled {
+		parts = append(parts, metadata(cfg, week))
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// metadata returns the generation metadata footer for the week.
+func metadata(cfg Config, week WeeklyItems) string {
+	data := newTemplateData(cfg, week)
+
+	version := data.Version
+	if len(version) == 0 {
+		version = "dev"
+	}
+
+	return "---\n\n_" + fmt.Sprintf(cfg.Locale.T("generated"),
+		cfg.Locale.Date(data.Generated)+" "+data.Generated.Format("15:04 MST"),
+		version,
+		data.Project,
+		data.Items,
+		data.Open,
+	) + "_"
+}
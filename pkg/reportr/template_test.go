@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderHeaderAndFooter(t *testing.T) {
+	week := WeeklyItems{
+		Start: mustParseTime("2022-11-27T00:00:00Z"),
+		End:   mustParseTime("2022-12-04T00:00:00Z"),
+	}
+
+	tests := []struct {
+		description string
+		cfg         Config
+		prefix      string
+		suffix      string
+	}{
+		{
+			description: "default header",
+			cfg: Config{
+				Team:     "Team",
+				Locale:   Locale{DateFormat: "2006-01-02"},
+				Markdown: Markdown{HeadingLevel: 2},
+			},
+			prefix: "## Status Report: 2022-11-27 ... 2022-12-03\n\n### Team\n\n",
+		}, {
+			description: "custom header and footer",
+			cfg: Config{
+				Team:           "Team",
+				Version:        "v1.2.3",
+				Generated:      mustParseTime("2022-12-05T10:00:00Z"),
+				HeaderTemplate: "{{heading 1}} {{.Team}} {{.Start.Format \"Jan 2\"}}\n",
+				FooterTemplate: "_{{.Version}} at {{.Generated.Format \"2006-01-02\"}}_\n",
+			},
+			prefix: "## Team Nov 27\n\n",
+			suffix: "\n_v1.2.3 at 2022-12-05_\n",
+		}, {
+			description: "metadata after the footer",
+			cfg: Config{
+				Team:           "Team",
+				Project:        5,
+				Version:        "v1.2.3",
+				Generated:      mustParseTime("2022-12-05T10:00:00Z"),
+				Locale:         Locale{DateFormat: "2006-01-02"},
+				FooterTemplate: "Thanks!",
+				Metadata:       Metadata{Enabled: true},
+			},
+			suffix: "\nThanks!\n\n---\n\n_Generated 2022-12-05 10:00 UTC by status-reportr v1.2.3 from project 5 with 0 items, 0 open._\n",
+		}, {
+			description: "project details",
+			cfg: Config{
+				Team:           "Team",
+				Locale:         Locale{DateFormat: "2006-01-02"},
+				ProjectDetails: ProjectHeader{Title: true, Description: true, Readme: true},
+				Details: ProjectDetails{
+					Title:            "Platform",
+					ShortDescription: "The platform work.",
+					Readme:           "Read me.\n",
+				},
+			},
+			prefix: "# Status Report: 2022-11-27 ... 2022-12-03\n\n## Team: Platform\n\nThe platform work.\n\nRead me.\n\n",
+		}, {
+			description: "project details not included",
+			cfg: Config{
+				Team:           "Team",
+				Locale:         Locale{DateFormat: "2006-01-02"},
+				ProjectDetails: ProjectHeader{Description: true},
+				Details:        ProjectDetails{Title: "Platform"},
+			},
+			prefix: "# Status Report: 2022-11-27 ... 2022-12-03\n\n## Team\n\n",
+		}, {
+			description: "broken header uses the default",
+			cfg: Config{
+				Team:           "Team",
+				Locale:         Locale{DateFormat: "2006-01-02"},
+				HeaderTemplate: "{{.Missing}}",
+			},
+			prefix: "# Status Report: 2022-11-27 ... 2022-12-03\n\n## Team\n\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			got := Render(tc.cfg, week)
+			assert.True(strings.HasPrefix(got, tc.prefix), got)
+			assert.True(strings.HasSuffix(got, tc.suffix), got)
+		})
+	}
+}
+
+func TestCheckTemplates(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(Config{HeaderTemplate: DefaultHeaderTemplate}.CheckTemplates())
+	assert.Error(Config{HeaderTemplate: "{{.Team"}.CheckTemplates())
+	assert.Error(Config{FooterTemplate: "{{unknown}}"}.CheckTemplates())
+}
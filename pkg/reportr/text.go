@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeText returns the text in the NFC unicode form without the invisible
+// characters that make equal looking text compare differently.  The zero width
+// spaces, word joiners and byte order marks are removed everywhere.  The zero
+// width joiners and non-joiners are only trimmed from the ends, since they are
+// part of emoji sequences and some scripts.
+func NormalizeText(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case '\u200b', '\u2060', '\ufeff':
+			return -1
+		}
+		return r
+	}, s)
+
+	s = strings.TrimFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '\u200c' || r == '\u200d'
+	})
+
+	return norm.NFC.String(s)
+}
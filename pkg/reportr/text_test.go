@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		description string
+		in          string
+		expect      string
+	}{
+		{description: "plain", in: "Fix: the thing", expect: "Fix: the thing"},
+		{description: "spaces", in: "  Fix \t", expect: "Fix"},
+		{description: "decomposed", in: "Cafe\u0301", expect: "Café"},
+		{description: "zero width space", in: "\u200bFix:\u200b the thing", expect: "Fix: the thing"},
+		{description: "byte order mark", in: "\ufeffFix", expect: "Fix"},
+		{description: "word joiner", in: "Fi\u2060x", expect: "Fix"},
+		{description: "joiners at the ends", in: "\u200d\u200cFix\u200d", expect: "Fix"},
+		{description: "emoji sequence", in: "\U0001F469\u200d\U0001F4BB Fix", expect: "\U0001F469\u200d\U0001F4BB Fix"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expect, NormalizeText(tc.in))
+		})
+	}
+}
+
+func TestNormalizedMatching(t *testing.T) {
+	assert := assert.New(t)
+
+	item := Item{
+		Labels: []string{"cafe\u0301", "\u200barea/ci"},
+		Fields: map[string]Field{
+			"Title": {Type: FIELD_TEXT, Text: "\u200bFix: Cafe\u0301 menu"},
+		},
+	}
+
+	assert.Equal("Fix: Café menu", item.Title())
+	assert.True(item.HasPrefix("Fix:"))
+	assert.True(item.HasPrefix("Fix: Café"))
+	assert.True(item.HasLabel("café"))
+	assert.True(item.HasLabel("area/*"))
+	assert.Equal([]string{"café", "area/ci"}, item.FilterLabels())
+	assert.Equal(map[string]int{"café": 1, "area/ci": 1}, Items{item}.GetUniqLabels())
+}
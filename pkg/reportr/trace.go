@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// traceMutex keeps the entries of the concurrent requests from interleaving.
+var traceMutex sync.Mutex
+
+// tracer appends every graphql request and response to a file for
+// troubleshooting the queries and the field mapping.  The token is scrubbed
+// from everything written, and the credentials in the headers are never seen
+// since they are added by the transports it wraps.
+type tracer struct {
+	file  string
+	token string
+	next  http.RoundTripper
+}
+
+func (t tracer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+
+	var entry strings.Builder
+	fmt.Fprintf(&entry, "=== %s %s %s\n%s\n", start.UTC().Format(time.RFC3339), req.Method, req.URL, body)
+	if err != nil {
+		fmt.Fprintf(&entry, "--- error after %s: %v\n\n", elapsed, err)
+		return resp, t.write(entry.String(), err)
+	}
+
+	data, rerr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if rerr != nil {
+		return nil, rerr
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	fmt.Fprintf(&entry, "--- %s after %s\n%s\n\n", resp.Status, elapsed, bytes.TrimSpace(data))
+	return resp, t.write(entry.String(), nil)
+}
+
+// write appends the entry to the trace file, returning the error of the
+// request if there is one.
+func (t tracer) write(entry string, err error) error {
+	if len(t.token) > 0 {
+		entry = strings.ReplaceAll(entry, t.token, "[token]")
+	}
+
+	traceMutex.Lock()
+	defer traceMutex.Unlock()
+
+	f, ferr := os.OpenFile(t.file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if ferr != nil {
+		if err != nil {
+			return err
+		}
+		return ferr
+	}
+	_, werr := f.WriteString(entry)
+	cerr := f.Close()
+
+	switch {
+	case err != nil:
+		return err
+	case werr != nil:
+		return werr
+	}
+	return cerr
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrace(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		r.Body.Close()
+		assert.Equal("Bearer secret", r.Header.Get("Authorization"))
+		// A response echoing the token is scrubbed too.
+		fmt.Fprintln(w, `{"data": {"organization": {"projectV2": {"id": "secret"}}}}`)
+	}))
+	defer ts.Close()
+
+	file := filepath.Join(t.TempDir(), "trace.log")
+	client := Login(Config{Url: ts.URL, Token: "secret", Trace: file})
+
+	id, err := FetchProjectInfo("example", 55, client)
+	require.NoError(err)
+	assert.Equal("secret", id)
+	_, err = FetchProjectInfo("example", 56, client)
+	require.NoError(err)
+
+	buf, err := os.ReadFile(file)
+	require.NoError(err)
+	trace := string(buf)
+
+	assert.Equal(2, strings.Count(trace, "=== "))
+	assert.Equal(2, strings.Count(trace, "--- 200 OK after "))
+	assert.Contains(trace, "POST "+ts.URL)
+	assert.Contains(trace, `"number":55`)
+	assert.Contains(trace, `"number":56`)
+	assert.Contains(trace, `{"id": "[token]"}`)
+	assert.NotContains(trace, "secret")
+}
+
+func TestTraceError(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	file := filepath.Join(t.TempDir(), "trace.log")
+	client := Login(Config{Url: "http://invalid.invalid", Replay: t.TempDir(), Trace: file})
+
+	_, err := FetchProjectInfo("example", 55, client)
+	assert.ErrorContains(err, ErrNotRecorded.Error())
+
+	buf, err := os.ReadFile(file)
+	require.NoError(err)
+	assert.Contains(string(buf), "--- error after ")
+	assert.Contains(string(buf), ErrNotRecorded.Error())
+}
@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// wikiHome is the name of the wiki page that lists the reports.
+const wikiHome = "Home.md"
+
+// wiki publishes the markdown reports as pages of a repository wiki using git
+// over https.  The Home page is rewritten to list every report page, newest
+// first.
+type wiki struct {
+	repo string // The owner/name of the repository.
+	url  string // The git url of the wiki, if not derived from the repository.
+}
+
+func newWikiDeliverer(d Delivery) (Deliverer, error) {
+	if len(d.Repo) == 0 && len(d.Endpoint) == 0 {
+		return nil, fmt.Errorf("wiki delivery requires a repo")
+	}
+	return wiki{repo: d.Repo, url: d.Endpoint}, nil
+}
+
+// gitURL returns the url of the wiki repository based on the github api url.
+func (w wiki) gitURL(cfg Config) string {
+	if len(w.url) > 0 {
+		return w.url
+	}
+
+	host := "github.com"
+	if u, err := url.Parse(cfg.Url); err == nil && len(u.Host) > 0 && u.Host != "api.github.com" {
+		host = u.Host
+	}
+	return fmt.Sprintf("https://%s/%s.wiki.git", host, w.repo)
+}
+
+func (w wiki) Deliver(cfg Config, r Report) error {
+	// Only the markdown reports make sense as wiki pages.
+	if r.Format != "markdown" {
+		return nil
+	}
+
+	dir, err := os.MkdirTemp("", "status-reportr-wiki-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	// The token is passed as a header so it is never part of a url that may be
+	// printed in an error.
+	auth := "http.extraHeader=Authorization: Basic " +
+		base64.StdEncoding.EncodeToString([]byte("x-access-token:"+cfg.Token))
+	git := func(args ...string) error {
+		sub := args[0]
+		args = append([]string{
+			"-c", auth,
+			"-c", "user.name=status-reportr",
+			"-c", "user.email=status-reportr@users.noreply.github.com",
+		}, args...)
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("wiki git %s failed: %w: %s", sub, err, strings.TrimSpace(stderr.String()))
+		}
+		return nil
+	}
+
+	if err = git("clone", "--quiet", "--depth", "1", w.gitURL(cfg), "."); err != nil {
+		return err
+	}
+
+	name := filepath.Base(r.Path)
+	if err = os.WriteFile(filepath.Join(dir, name), r.Data, 0644); err != nil {
+		return err
+	}
+
+	home, err := wikiIndex(cfg, dir)
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(filepath.Join(dir, wikiHome), []byte(home), 0644); err != nil {
+		return err
+	}
+
+	if err = git("add", "--all"); err != nil {
+		return err
+	}
+	if err = git("diff", "--cached", "--quiet"); err == nil {
+		logf("The wiki page %s is unchanged", name)
+		return nil
+	}
+	if err = git("commit", "--quiet", "-m", "Status report "+strings.TrimSuffix(name, ".md")); err != nil {
+		return err
+	}
+	if err = git("push", "--quiet", "origin", "HEAD"); err != nil {
+		return err
+	}
+
+	logf("Published the wiki page %s", name)
+	return nil
+}
+
+// wikiIndex returns the Home page listing the report pages in the directory,
+// newest first.
+func wikiIndex(cfg Config, dir string) (string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.md"))
+	if err != nil {
+		return "", err
+	}
+
+	pages := make([]string, 0, len(files))
+	for _, file := range files {
+		name := filepath.Base(file)
+		// Skip the home page and the special _Sidebar and _Footer pages.
+		if name == wikiHome || strings.HasPrefix(name, "_") {
+			continue
+		}
+		pages = append(pages, strings.TrimSuffix(name, ".md"))
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(pages)))
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "# %s: %s\n\n", cfg.Locale.T("status_reports"), cfg.Team)
+	for _, page := range pages {
+		fmt.Fprintf(&buf, "- [%s](%s)\n", page, url.PathEscape(page))
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package reportr
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWikiDeliverer(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	// A bare repository with a page stands in for the wiki.
+	tmp := t.TempDir()
+	remote := filepath.Join(tmp, "wiki.git")
+	seed := filepath.Join(tmp, "seed")
+	for _, args := range [][]string{
+		{"init", "--quiet", "--bare", remote},
+		{"clone", "--quiet", remote, seed},
+		{"-C", seed, "checkout", "--quiet", "-b", "master"},
+	} {
+		require.NoError(exec.Command("git", args...).Run(), args)
+	}
+	require.NoError(os.WriteFile(filepath.Join(seed, "Home.md"), []byte("Welcome\n"), 0644))
+	for _, args := range [][]string{
+		{"-C", seed, "add", "."},
+		{"-C", seed, "-c", "user.name=t", "-c", "user.email=t@t", "commit", "--quiet", "-m", "init"},
+		{"-C", seed, "push", "--quiet", "origin", "master"},
+	} {
+		require.NoError(exec.Command("git", args...).Run(), args)
+	}
+
+	d, err := NewDeliverer(Delivery{Type: "wiki", Endpoint: remote})
+	require.NoError(err)
+
+	cfg := Config{Team: "Team", Token: "token"}
+	for _, name := range []string{"2022-W47.md", "2022-W48.md", "2022-W48.md"} {
+		err = d.Deliver(cfg, Report{
+			Format: "markdown",
+			Path:   filepath.Join("out", name),
+			Data:   []byte("# " + name + "\n"),
+		})
+		require.NoError(err)
+	}
+	require.NoError(d.Deliver(cfg, Report{Format: "html", Path: "out/2022-W48.html"}))
+
+	check := filepath.Join(tmp, "check")
+	require.NoError(exec.Command("git", "clone", "--quiet", remote, check).Run())
+
+	home, err := os.ReadFile(filepath.Join(check, "Home.md"))
+	require.NoError(err)
+	assert.Equal("# Status Reports: Team\n\n- [2022-W48](2022-W48)\n- [2022-W47](2022-W47)\n", string(home))
+
+	page, err := os.ReadFile(filepath.Join(check, "2022-W48.md"))
+	require.NoError(err)
+	assert.Equal("# 2022-W48.md\n", string(page))
+	assert.NoFileExists(filepath.Join(check, "2022-W48.html"))
+
+	out, err := exec.Command("git", "-C", check, "rev-list", "--count", "HEAD").Output()
+	require.NoError(err)
+	assert.Equal("3\n", string(out))
+}
+
+func TestWikiGitURL(t *testing.T) {
+	assert := assert.New(t)
+
+	w := wiki{repo: "org/repo"}
+	assert.Equal("https://github.com/org/repo.wiki.git", w.gitURL(Config{Url: "https://api.github.com/graphql"}))
+	assert.Equal("https://ghe.example.com/org/repo.wiki.git", w.gitURL(Config{Url: "https://ghe.example.com/api/graphql"}))
+	assert.Equal("https://example.com/x.git", wiki{url: "https://example.com/x.git"}.gitURL(Config{}))
+}
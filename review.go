@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/schmidtw/status-reportr/pkg/reportr"
+)
+
+var errAborted = errors.New("review aborted, nothing was rendered or archived")
+
+// reviewRow is a single line in the review browser, either a section heading
+// or an item.
+type reviewRow struct {
+	heading string
+	item    reportr.Item
+}
+
+// reviewModel is the interactive browser used to approve the items before the
+// reports are rendered and the items archived.
+type reviewModel struct {
+	cfg      reportr.Config
+	weeks    []reportr.WeeklyItems
+	rows     [][]reviewRow // The rows of each week.
+	cursor   []int         // The selected row of each week.
+	week     int           // The week being shown.
+	height   int
+	excluded map[string]bool
+	approved bool
+}
+
+// newReviewModel builds the rows of the browser for the weeks, grouped by the
+// sections the items are rendered in.
+func newReviewModel(cfg reportr.Config, weeks []reportr.WeeklyItems) reviewModel {
+	m := reviewModel{
+		cfg:      cfg,
+		weeks:    weeks,
+		rows:     make([][]reviewRow, len(weeks)),
+		cursor:   make([]int, len(weeks)),
+		excluded: make(map[string]bool),
+	}
+
+	for i, week := range weeks {
+		for _, c := range reportr.Classify(cfg, week.Items) {
+			if len(c.Items) == 0 {
+				continue
+			}
+			m.rows[i] = append(m.rows[i], reviewRow{heading: c.Section.Name})
+			for _, item := range c.Items {
+				m.rows[i] = append(m.rows[i], reviewRow{item: item})
+			}
+		}
+		m.cursor[i] = m.next(i, -1, 1)
+	}
+
+	return m
+}
+
+// next returns the next item row from the row in the direction, or the row if
+// there is none.
+func (m reviewModel) next(week, row, dir int) int {
+	for i := row + dir; i >= 0 && i < len(m.rows[week]); i += dir {
+		if len(m.rows[week][i].heading) == 0 {
+			return i
+		}
+	}
+	return row
+}
+
+// Init implements tea.Model.
+func (m reviewModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (m reviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+	case tea.KeyMsg:
+		if len(m.weeks) == 0 {
+			m.approved = msg.String() == "enter"
+			return m, tea.Quit
+		}
+
+		w := m.week
+		switch msg.String() {
+		case "up", "k":
+			m.cursor[w] = m.next(w, m.cursor[w], -1)
+		case "down", "j":
+			m.cursor[w] = m.next(w, m.cursor[w], 1)
+		case "left", "h":
+			if m.week > 0 {
+				m.week--
+			}
+		case "right", "l":
+			if m.week < len(m.weeks)-1 {
+				m.week++
+			}
+		case " ", "x":
+			if c := m.cursor[w]; c >= 0 && c < len(m.rows[w]) {
+				id := m.rows[w][c].item.ID
+				m.excluded[id] = !m.excluded[id]
+			}
+		case "a":
+			// Toggle every item in the week based on the first one.
+			var exclude bool
+			for _, row := range m.rows[w] {
+				if len(row.heading) == 0 {
+					exclude = !m.excluded[row.item.ID]
+					break
+				}
+			}
+			for _, row := range m.rows[w] {
+				if len(row.heading) == 0 {
+					m.excluded[row.item.ID] = exclude
+				}
+			}
+		case "enter":
+			m.approved = true
+			return m, tea.Quit
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+// View implements tea.Model.
+func (m reviewModel) View() string {
+	var buf strings.Builder
+
+	if len(m.weeks) == 0 {
+		buf.WriteString("There are no items to report.\n\nenter continue • q quit\n")
+		return buf.String()
+	}
+
+	w := m.week
+	week := m.weeks[w]
+	fmt.Fprintf(&buf, "%s (%d/%d)\n\n", reportr.ReportBasename(m.cfg, week), w+1, len(m.weeks))
+
+	// Only show the rows that fit around the cursor.
+	start, end := 0, len(m.rows[w])
+	if avail := m.height - 5; avail > 0 && end > avail {
+		start = m.cursor[w] - avail/2
+		if start < 0 {
+			start = 0
+		}
+		if start+avail < end {
+			end = start + avail
+		}
+		start = end - avail
+	}
+
+	for i := start; i < end; i++ {
+		row := m.rows[w][i]
+		if len(row.heading) > 0 {
+			fmt.Fprintf(&buf, "  %s\n", row.heading)
+			continue
+		}
+
+		pointer := " "
+		if i == m.cursor[w] {
+			pointer = ">"
+		}
+		check := "x"
+		if m.excluded[row.item.ID] {
+			check = " "
+		}
+		fmt.Fprintf(&buf, "%s [%s] #%d %s (%s)\n", pointer, check, row.item.Number, row.item.Title(), row.item.Repo.Slug)
+	}
+
+	buf.WriteString("\n↑/↓ move • ←/→ week • space toggle • a toggle all • enter approve • q quit\n")
+	return buf.String()
+}
+
+// approvedWeeks returns the weeks with the excluded items removed.
+func (m reviewModel) approvedWeeks() []reportr.WeeklyItems {
+	rv := make([]reportr.WeeklyItems, 0, len(m.weeks))
+	for _, week := range m.weeks {
+		var items reportr.Items
+		for _, item := range week.Items {
+			if !m.excluded[item.ID] {
+				items = append(items, item)
+			}
+		}
+		week.Items = items
+		rv = append(rv, week)
+	}
+	return rv
+}
+
+// review lets the user browse the items of each week and exclude the ones that
+// should not be reported or archived.  Only the approved items are returned.
+func review(cfg reportr.Config, weeks []reportr.WeeklyItems) ([]reportr.WeeklyItems, error) {
+	final, err := tea.NewProgram(newReviewModel(cfg, weeks), tea.WithAltScreen()).Run()
+	if err != nil {
+		return nil, err
+	}
+
+	m := final.(reviewModel)
+	if !m.approved {
+		return nil, errAborted
+	}
+
+	return m.approvedWeeks(), nil
+}
@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2022 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/schmidtw/status-reportr/pkg/reportr"
+	"github.com/stretchr/testify/assert"
+)
+
+// key returns the key message bubbletea sends for the key.
+func key(k string) tea.KeyMsg {
+	switch k {
+	case "up":
+		return tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "left":
+		return tea.KeyMsg{Type: tea.KeyLeft}
+	case "right":
+		return tea.KeyMsg{Type: tea.KeyRight}
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "space":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+func TestReviewModel(t *testing.T) {
+	item := func(id string, n int) reportr.Item {
+		return reportr.Item{
+			ID:     id,
+			Number: n,
+			Fields: map[string]reportr.Field{"Status": {Type: reportr.FIELD_TEXT, Text: "Done"}},
+		}
+	}
+	weeks := []reportr.WeeklyItems{
+		{Items: reportr.Items{item("a", 1), item("b", 2)}},
+		{Items: reportr.Items{item("c", 3)}},
+	}
+
+	tests := []struct {
+		description    string
+		weeks          []reportr.WeeklyItems
+		keys           []string
+		expectApproved bool
+		expectIDs      [][]string
+	}{
+		{
+			description:    "approve everything",
+			weeks:          weeks,
+			keys:           []string{"enter"},
+			expectApproved: true,
+			expectIDs:      [][]string{{"a", "b"}, {"c"}},
+		}, {
+			description:    "exclude the first item",
+			weeks:          weeks,
+			keys:           []string{"space", "enter"},
+			expectApproved: true,
+			expectIDs:      [][]string{{"b"}, {"c"}},
+		}, {
+			description:    "exclude the second item",
+			weeks:          weeks,
+			keys:           []string{"down", "x", "enter"},
+			expectApproved: true,
+			expectIDs:      [][]string{{"a"}, {"c"}},
+		}, {
+			description:    "toggle an item back on",
+			weeks:          weeks,
+			keys:           []string{"j", "x", "k", "x", "j", "x", "enter"},
+			expectApproved: true,
+			expectIDs:      [][]string{{"b"}, {"c"}},
+		}, {
+			description:    "moving past the ends stays on the items",
+			weeks:          weeks,
+			keys:           []string{"up", "down", "down", "down", "space", "enter"},
+			expectApproved: true,
+			expectIDs:      [][]string{{"a"}, {"c"}},
+		}, {
+			description:    "exclude the whole week",
+			weeks:          weeks,
+			keys:           []string{"a", "enter"},
+			expectApproved: true,
+			expectIDs:      [][]string{nil, {"c"}},
+		}, {
+			description:    "toggle the whole week back on",
+			weeks:          weeks,
+			keys:           []string{"a", "a", "enter"},
+			expectApproved: true,
+			expectIDs:      [][]string{{"a", "b"}, {"c"}},
+		}, {
+			description:    "toggle the whole week based on the first item",
+			weeks:          weeks,
+			keys:           []string{"space", "a", "enter"},
+			expectApproved: true,
+			expectIDs:      [][]string{{"a", "b"}, {"c"}},
+		}, {
+			description:    "exclude from the next week",
+			weeks:          weeks,
+			keys:           []string{"right", "right", "space", "enter"},
+			expectApproved: true,
+			expectIDs:      [][]string{{"a", "b"}, nil},
+		}, {
+			description:    "back to the first week",
+			weeks:          weeks,
+			keys:           []string{"l", "h", "left", "space", "enter"},
+			expectApproved: true,
+			expectIDs:      [][]string{{"b"}, {"c"}},
+		}, {
+			description: "quit",
+			weeks:       weeks,
+			keys:        []string{"space", "q"},
+		}, {
+			description: "escape",
+			weeks:       weeks,
+			keys:        []string{"esc"},
+		}, {
+			description:    "no weeks approved",
+			keys:           []string{"enter"},
+			expectApproved: true,
+			expectIDs:      [][]string{},
+		}, {
+			description: "no weeks quit",
+			keys:        []string{"q"},
+			expectIDs:   [][]string{},
+		},
+	}
+
+	cfg := testConfig(t, "")
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var m tea.Model = newReviewModel(cfg, tc.weeks)
+			var cmd tea.Cmd
+			for _, k := range tc.keys {
+				assert.Nil(cmd, "no keys are expected after quitting")
+				m, cmd = m.Update(key(k))
+			}
+			assert.NotNil(cmd)
+
+			final := m.(reviewModel)
+			assert.Equal(tc.expectApproved, final.approved)
+			if !tc.expectApproved {
+				return
+			}
+
+			got := final.approvedWeeks()
+			ids := make([][]string, 0, len(got))
+			for _, week := range got {
+				var list []string
+				for _, item := range week.Items {
+					list = append(list, item.ID)
+				}
+				ids = append(ids, list)
+			}
+			assert.Equal(tc.expectIDs, ids)
+		})
+	}
+}